@@ -4,9 +4,11 @@ pragma solidity ^0.8.15;
 import "forge-std/Script.sol";
 
 import {L2ENSRegistry} from "src/l2/L2ENSRegistry.sol";
+import {L2Forwarder} from "src/l2/L2Forwarder.sol";
 
 contract ContractScript is Script {
     L2ENSRegistry l2Registry;
+    L2Forwarder l2Forwarder;
 
     address _owner = 0x84C970BFcD59a0e98eC6f13Cbdf24AA1a741f033;
     address _goerliENSResolver = 0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e;    
@@ -21,8 +23,9 @@ contract ContractScript is Script {
     function run() public {
         vm.startBroadcast();
 
-        // Deploy the L2 Registry
-        l2Registry = new L2ENSRegistry();
+        // Deploy the meta-transaction forwarder and the L2 Registry trusting it
+        l2Forwarder = new L2Forwarder();
+        l2Registry = new L2ENSRegistry(address(l2Forwarder));
 
         // Register the eth domain
         l2Registry.setSubnodeRecord(
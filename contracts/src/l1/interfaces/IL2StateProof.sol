@@ -0,0 +1,13 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.15;
+
+import {OptimismHelper} from "src/l1/types/OptimismHelper.sol";
+
+/**
+ * @dev Exposes OptimismHelper.L2StateProof in a compiled ABI. The callbacks take the proof as
+ * ABI-encoded bytes, so the struct does not otherwise appear in any artifact. Off-chain code
+ * encodes and decodes proofs as the arguments of l2StateProof.
+ */
+interface IL2StateProof {
+    function l2StateProof(OptimismHelper.L2StateProof calldata _proof) external;
+}
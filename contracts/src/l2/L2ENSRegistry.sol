@@ -2,11 +2,13 @@
 pragma solidity ^0.8.15;
 
 import "src/l1/interfaces/ENS.sol";
+import {ERC2771Context} from "openzeppelin-contracts/contracts/metatx/ERC2771Context.sol";
 
 /**
- * The ENS registry contract.
+ * The ENS registry contract. Calls relayed through the trusted forwarder act
+ * on behalf of the signer of the forwarded request.
  */
-contract L2ENSRegistry is ENS {
+contract L2ENSRegistry is ENS, ERC2771Context {
     struct Record {
         address owner;
         address resolver;
@@ -19,14 +21,16 @@ contract L2ENSRegistry is ENS {
     // Permits modifications only by the owner of the specified node.
     modifier authorised(bytes32 node) {
         address owner_ = records[node].owner;
-        require(owner_ == msg.sender || operators[owner_][msg.sender]);
+        address sender_ = _msgSender();
+        require(owner_ == sender_ || operators[owner_][sender_]);
         _;
     }
 
     /**
      * @dev Constructs a new ENS registry.
+     * @param _trustedForwarder The meta-transaction forwarder, or zero for none.
      */
-    constructor() {
+    constructor(address _trustedForwarder) ERC2771Context(_trustedForwarder) {
         records[0x0].owner = msg.sender;
     }
 
@@ -159,7 +163,7 @@ contract L2ENSRegistry is ENS {
 
     /**
      * @dev Enable or disable approval for a third party ("operator") to manage
-     *  all of `msg.sender`'s ENS records. Emits the ApprovalForAll event.
+     *  all of the sender's ENS records. Emits the ApprovalForAll event.
      * @param _operator Address to add to the set of authorized operators.
      * @param _approved True if the operator is approved, false to revoke approval.
      */
@@ -168,8 +172,8 @@ contract L2ENSRegistry is ENS {
         virtual
         override
     {
-        operators[msg.sender][_operator] = _approved;
-        emit ApprovalForAll(msg.sender, _operator, _approved);
+        operators[_msgSender()][_operator] = _approved;
+        emit ApprovalForAll(_msgSender(), _operator, _approved);
     }
 
     /**
@@ -0,0 +1,10 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.15;
+
+import {MinimalForwarder} from "openzeppelin-contracts/contracts/metatx/MinimalForwarder.sol";
+
+/**
+ * Forwards EIP-712 signed registry writes submitted by the gateway's relayer,
+ * so users without L2 ETH can update their records.
+ */
+contract L2Forwarder is MinimalForwarder {}
@@ -16,7 +16,7 @@ contract ENSRegistryTest is BaseTest {
         vm.startPrank(deployer);
 
         // Deploy the L2 Registry
-        l2Registry = new L2ENSRegistry();
+        l2Registry = new L2ENSRegistry(address(0));
 
         l2Registry.setSubnodeRecord(
             0x0,
@@ -0,0 +1,19 @@
+// Package bindings holds the contract ABIs the gateway uses, generated from
+// the forge artifacts in contracts/out, along with abigen bindings for the
+// registries and the L2 forwarder, and typed argument structs for the L1
+// registry's methods and revert errors and the L2 registry setters the
+// relayer forwards. The L2 registry and forwarder bindings embed their
+// bytecode so tests can deploy them to a simulated chain.
+//
+// To regenerate after changing the contracts:
+//
+//	(cd ../../contracts && forge build)
+//	go generate ./bindings
+//
+// The internal/gen tests fail when the checked-in files differ from a fresh
+// generation, and "go run ./internal/gen -check" does the same against the
+// forge artifacts. The artifacts test skips when contracts/out is missing;
+// "make check-bindings" in contracts/ builds them and requires it to run.
+package bindings
+
+//go:generate go run ./internal/gen -artifacts ../../contracts/out -out .
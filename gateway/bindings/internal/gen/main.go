@@ -0,0 +1,436 @@
+// Command gen generates the bindings package from forge build artifacts.
+//
+// It embeds the ABI of each contract and a selector table, and fails if a
+// method, error or event the gateway depends on is missing or has changed
+// signature.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const header = "// Code generated by internal/gen from forge artifacts. DO NOT EDIT.\n\npackage bindings\n"
+
+type contract struct {
+	// Name is the contract name and the prefix of its generated identifiers.
+	Name string
+	// Artifact is the artifact path relative to forge's out directory.
+	Artifact string
+	// Required are the signatures the gateway depends on.
+	Required []string
+	// Bind generates abigen bindings for the contract.
+	Bind bool
+	// Deploy embeds the contract's bytecode in its bindings, so tests can
+	// deploy it.
+	Deploy bool
+	// Calls and Errors get typed argument structs and unpackers.
+	Calls  []string
+	Errors []string
+}
+
+var contracts = []contract{
+	{
+		Name:     "L1ENSRegistry",
+		Artifact: "L1ENSRegistry.sol/L1ENSRegistry.json",
+		Required: []string{
+			"owner(bytes32)",
+			"ownerWithProof(bytes,bytes)",
+			"resolver(bytes32)",
+			"resolverWithProof(bytes,bytes)",
+			"ttl(bytes32)",
+			"ttlWithProof(bytes,bytes)",
+			"recordExists(bytes32)",
+			"recordExistsWithProof(bytes,bytes)",
+			"isApprovedForAll(address,address)",
+			"isApprovedForAllWithProof(bytes,bytes)",
+			"setRecord(bytes32,address,address,uint64)",
+			"setSubnodeRecord(bytes32,bytes32,address,address,uint64)",
+			"setOwner(bytes32,address)",
+			"setSubnodeOwner(bytes32,bytes32,address)",
+			"setResolver(bytes32,address)",
+			"setTTL(bytes32,uint64)",
+			"setApprovalForAll(address,bool)",
+			"error OffchainLookup(address,string[],bytes,bytes4,bytes)",
+			"error StorageHandledByL2(uint256,address)",
+			"error InvalidStateRoot()",
+			"error AccountDNE()",
+			"error StorageDNE()",
+		},
+		Bind: true,
+		Calls: []string{
+			"owner", "resolver", "ttl", "recordExists", "isApprovedForAll",
+			"setRecord", "setSubnodeRecord", "setOwner", "setSubnodeOwner",
+			"setResolver", "setTTL", "setApprovalForAll",
+		},
+		Errors: []string{"OffchainLookup", "StorageHandledByL2"},
+	},
+	{
+		Name:     "L2ENSRegistry",
+		Artifact: "L2ENSRegistry.sol/L2ENSRegistry.json",
+		Required: []string{
+			"owner(bytes32)",
+			"resolver(bytes32)",
+			"ttl(bytes32)",
+			"setRecord(bytes32,address,address,uint64)",
+			"setSubnodeRecord(bytes32,bytes32,address,address,uint64)",
+			"setOwner(bytes32,address)",
+			"setSubnodeOwner(bytes32,bytes32,address)",
+			"setResolver(bytes32,address)",
+			"setTTL(bytes32,uint64)",
+			"setApprovalForAll(address,bool)",
+			"event NewOwner(bytes32,bytes32,address)",
+			"event Transfer(bytes32,address)",
+			"event NewResolver(bytes32,address)",
+			"event NewTTL(bytes32,uint64)",
+			"event ApprovalForAll(address,address,bool)",
+			"isTrustedForwarder(address)",
+		},
+		Bind:   true,
+		Deploy: true,
+		Calls:  []string{"setOwner", "setResolver", "setSubnodeRecord"},
+	},
+	{
+		Name:     "L2Forwarder",
+		Artifact: "L2Forwarder.sol/L2Forwarder.json",
+		Required: []string{
+			"execute((address,address,uint256,uint256,uint256,bytes),bytes)",
+			"getNonce(address)",
+			"verify((address,address,uint256,uint256,uint256,bytes),bytes)",
+		},
+		Bind:   true,
+		Deploy: true,
+	},
+	{
+		Name:     "L2StateProof",
+		Artifact: "IL2StateProof.sol/IL2StateProof.json",
+		Required: []string{
+			"l2StateProof((bytes32,(uint256,bytes32,uint256,uint256,bytes),(uint256,bytes32[]),bytes,bytes))",
+		},
+	},
+}
+
+func main() {
+	artifacts := flag.String("artifacts", "../../contracts/out", "forge out directory")
+	out := flag.String("out", ".", "output directory")
+	check := flag.Bool("check", false, "fail if the files in -out differ from a fresh generation instead of writing them")
+	flag.Parse()
+
+	files, err := generate(os.DirFS(*artifacts))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *check {
+		if stale := staleFiles(*out, files); len(stale) > 0 {
+			log.Fatalf("bindings are out of date, run go generate ./bindings: %s", strings.Join(stale, ", "))
+		}
+		return
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(*out, name), src, 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// generate returns the formatted source of every generated file by name,
+// from the forge artifacts in fsys.
+func generate(fsys fs.FS) (map[string][]byte, error) {
+	var abis, selectors bytes.Buffer
+	abis.WriteString(header)
+	selectors.WriteString(header)
+	files := make(map[string][]byte)
+	for _, c := range contracts {
+		abiJSON, bytecode, parsed, err := readArtifact(fsys, c.Artifact)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", c.Name, err)
+		}
+		if err := checkRequired(parsed, c.Required); err != nil {
+			return nil, fmt.Errorf("%s: %w", c.Name, err)
+		}
+		// abigen declares the ABI of bound contracts.
+		if !c.Bind {
+			fmt.Fprintf(&abis, "\n// %sABI is the ABI of %s.\nconst %sABI = `%s`\n", c.Name, c.Name, c.Name, abiJSON)
+		}
+		writeSelectors(&selectors, c.Name, parsed)
+		if c.Bind {
+			bin := ""
+			if c.Deploy {
+				if bytecode == "" {
+					return nil, fmt.Errorf("%s: artifact has no bytecode", c.Name)
+				}
+				bin = bytecode
+			}
+			code, err := bind.Bind([]string{c.Name}, []string{abiJSON}, []string{bin}, nil, "bindings", bind.LangGo, nil, nil)
+			if err != nil {
+				return nil, fmt.Errorf("%s: binding: %w", c.Name, err)
+			}
+			files[strings.ToLower(c.Name)+".go"] = []byte(code)
+		}
+		if len(c.Calls) > 0 || len(c.Errors) > 0 {
+			code, err := typedArgs(c, parsed)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", c.Name, err)
+			}
+			files[strings.ToLower(c.Name)+"_args.go"] = code
+		}
+	}
+	files["abis.go"] = abis.Bytes()
+	files["selectors.go"] = selectors.Bytes()
+	for name, src := range files {
+		formatted, err := format.Source(src)
+		if err != nil {
+			return nil, fmt.Errorf("formatting %s: %w", name, err)
+		}
+		files[name] = formatted
+	}
+	return files, nil
+}
+
+// staleFiles returns the names of the generated files whose copy in dir is
+// missing or differs.
+func staleFiles(dir string, files map[string][]byte) []string {
+	var stale []string
+	for name, src := range files {
+		existing, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil || !bytes.Equal(existing, src) {
+			stale = append(stale, name)
+		}
+	}
+	sort.Strings(stale)
+	return stale
+}
+
+// readArtifact returns the indented ABI and the creation bytecode, if any,
+// from a forge artifact.
+func readArtifact(fsys fs.FS, path string) (string, string, *abi.ABI, error) {
+	raw, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return "", "", nil, fmt.Errorf("reading artifact (run forge build in contracts/): %w", err)
+	}
+	var artifact struct {
+		ABI      json.RawMessage `json:"abi"`
+		Bytecode struct {
+			Object string `json:"object"`
+		} `json:"bytecode"`
+	}
+	if err := json.Unmarshal(raw, &artifact); err != nil {
+		return "", "", nil, fmt.Errorf("decoding %s: %w", path, err)
+	}
+	if len(artifact.ABI) == 0 {
+		return "", "", nil, fmt.Errorf("%s has no abi", path)
+	}
+	parsed, err := abi.JSON(bytes.NewReader(artifact.ABI))
+	if err != nil {
+		return "", "", nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, artifact.ABI, "", "  "); err != nil {
+		return "", "", nil, err
+	}
+	if strings.Contains(indented.String(), "`") {
+		return "", "", nil, fmt.Errorf("%s contains a backtick", path)
+	}
+	bytecode := strings.TrimPrefix(artifact.Bytecode.Object, "0x")
+	if strings.Contains(bytecode, "__") {
+		return "", "", nil, fmt.Errorf("%s bytecode has unlinked libraries", path)
+	}
+	return indented.String(), bytecode, &parsed, nil
+}
+
+// checkRequired reports every required signature the ABI lacks. Errors and
+// events are prefixed with "error " and "event ".
+func checkRequired(parsed *abi.ABI, required []string) error {
+	have := make(map[string]bool)
+	for _, m := range parsed.Methods {
+		have[m.Sig] = true
+	}
+	for _, e := range parsed.Errors {
+		have["error "+e.Sig] = true
+	}
+	for _, e := range parsed.Events {
+		have["event "+e.Sig] = true
+	}
+	var missing []string
+	for _, sig := range required {
+		if !have[sig] {
+			missing = append(missing, sig)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("signatures the gateway handles are missing or changed: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func writeSelectors(w *bytes.Buffer, name string, parsed *abi.ABI) {
+	var sigs []string
+	ids := make(map[string][]byte)
+	for _, m := range parsed.Methods {
+		sigs = append(sigs, m.Sig)
+		ids[m.Sig] = m.ID
+	}
+	sort.Strings(sigs)
+	fmt.Fprintf(w, "\n// %sSelectors maps %s method signatures to selectors.\nvar %sSelectors = map[string][4]byte{\n", name, name, name)
+	for _, sig := range sigs {
+		id := ids[sig]
+		fmt.Fprintf(w, "\t%q: {0x%02x, 0x%02x, 0x%02x, 0x%02x},\n", sig, id[0], id[1], id[2], id[3])
+	}
+	w.WriteString("}\n")
+
+	var errs []string
+	errIDs := make(map[string]common.Hash)
+	for _, e := range parsed.Errors {
+		errs = append(errs, e.Sig)
+		errIDs[e.Sig] = e.ID
+	}
+	if len(errs) == 0 {
+		return
+	}
+	sort.Strings(errs)
+	fmt.Fprintf(w, "\n// %sErrorSelectors maps %s custom error signatures to selectors.\nvar %sErrorSelectors = map[string][4]byte{\n", name, name, name)
+	for _, sig := range errs {
+		id := errIDs[sig]
+		fmt.Fprintf(w, "\t%q: {0x%02x, 0x%02x, 0x%02x, 0x%02x},\n", sig, id[0], id[1], id[2], id[3])
+	}
+	w.WriteString("}\n")
+}
+
+// typedArgs generates an argument struct, unpacker and Values method for each
+// of c.Calls, and a struct and unpacker for each of c.Errors. Unpacking
+// converts every value to its static Go type, so callers never type-assert.
+func typedArgs(c contract, parsed *abi.ABI) ([]byte, error) {
+	var body bytes.Buffer
+	imports := map[string]bool{"fmt": true}
+	abiVar := lowerFirst(c.Name) + "ParsedABI"
+	fmt.Fprintf(&body, "\nvar %s = mustParseABI(%sABI)\n", abiVar, c.Name)
+	for _, name := range c.Calls {
+		method, ok := parsed.Methods[name]
+		if !ok {
+			return nil, fmt.Errorf("no method %s", name)
+		}
+		typeName := c.Name + abi.ToCamelCase(name) + "Args"
+		fields, err := writeStruct(&body, typeName, fmt.Sprintf("are the arguments of %s.", method.Sig), method.Inputs, imports)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		fmt.Fprintf(&body, "\n// Unpack%s decodes %s calldata that has had its selector removed.\n", typeName, method.Sig)
+		fmt.Fprintf(&body, "func Unpack%s(data []byte) (%s, error) {\n", typeName, typeName)
+		fmt.Fprintf(&body, "\tvar args %s\n\tvalues, err := %s.Methods[%q].Inputs.Unpack(data)\n", typeName, abiVar, name)
+		fmt.Fprintf(&body, "\tif err != nil {\n\t\treturn args, fmt.Errorf(\"unpacking %s: %%w\", err)\n\t}\n", name)
+		writeConversions(&body, fields)
+		body.WriteString("\treturn args, nil\n}\n")
+		fmt.Fprintf(&body, "\n// Values returns the arguments in ABI order, as accepted by abi.Pack.\n")
+		fmt.Fprintf(&body, "func (args %s) Values() []interface{} {\n\treturn []interface{}{", typeName)
+		for i, f := range fields {
+			if i > 0 {
+				body.WriteString(", ")
+			}
+			body.WriteString("args." + f.name)
+		}
+		body.WriteString("}\n}\n")
+		fmt.Fprintf(&body, "\n// Pack encodes the call, selector included.\nfunc (args %s) Pack() ([]byte, error) {\n", typeName)
+		fmt.Fprintf(&body, "\treturn %s.Pack(%q, args.Values()...)\n}\n", abiVar, name)
+	}
+	for _, name := range c.Errors {
+		abiErr, ok := parsed.Errors[name]
+		if !ok {
+			return nil, fmt.Errorf("no error %s", name)
+		}
+		typeName := c.Name + name
+		fields, err := writeStruct(&body, typeName, fmt.Sprintf("is the %s revert.", abiErr.Sig), abiErr.Inputs, imports)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		imports["bytes"] = true
+		fmt.Fprintf(&body, "\n// Unpack%s decodes %s revert data, selector included.\n", typeName, abiErr.Sig)
+		fmt.Fprintf(&body, "func Unpack%s(data []byte) (%s, error) {\n\tvar args %s\n", typeName, typeName, typeName)
+		fmt.Fprintf(&body, "\tid := %s.Errors[%q].ID\n", abiVar, name)
+		fmt.Fprintf(&body, "\tif len(data) < 4 || !bytes.Equal(data[:4], id[:4]) {\n\t\treturn args, fmt.Errorf(\"revert data is not %s\")\n\t}\n", name)
+		fmt.Fprintf(&body, "\tvalues, err := %s.Errors[%q].Inputs.Unpack(data[4:])\n", abiVar, name)
+		fmt.Fprintf(&body, "\tif err != nil {\n\t\treturn args, fmt.Errorf(\"unpacking %s: %%w\", err)\n\t}\n", name)
+		writeConversions(&body, fields)
+		body.WriteString("\treturn args, nil\n}\n")
+	}
+
+	var src bytes.Buffer
+	src.WriteString(header)
+	imports["github.com/ethereum/go-ethereum/accounts/abi"] = true
+	var paths []string
+	for path := range imports {
+		paths = append(paths, path)
+	}
+	// Standard library imports first, as goimports groups them.
+	sort.Slice(paths, func(i, j int) bool {
+		iStd, jStd := !strings.Contains(paths[i], "."), !strings.Contains(paths[j], ".")
+		if iStd != jStd {
+			return iStd
+		}
+		return paths[i] < paths[j]
+	})
+	src.WriteString("\nimport (\n")
+	thirdParty := false
+	for _, path := range paths {
+		if strings.Contains(path, ".") && !thirdParty {
+			thirdParty = true
+			src.WriteString("\n")
+		}
+		fmt.Fprintf(&src, "\t%q\n", path)
+	}
+	src.WriteString(")\n")
+	src.Write(body.Bytes())
+	return src.Bytes(), nil
+}
+
+type field struct {
+	name   string
+	goType string
+}
+
+func writeStruct(w *bytes.Buffer, typeName, doc string, inputs abi.Arguments, imports map[string]bool) ([]field, error) {
+	fields := make([]field, len(inputs))
+	fmt.Fprintf(w, "\n// %s %s\ntype %s struct {\n", typeName, doc, typeName)
+	for i, input := range inputs {
+		name := abi.ToCamelCase(input.Name)
+		if name == "" {
+			name = fmt.Sprintf("Arg%d", i)
+		}
+		goType := input.Type.GetType().String()
+		switch {
+		case strings.Contains(goType, "big."):
+			imports["math/big"] = true
+		case strings.Contains(goType, "common."):
+			imports["github.com/ethereum/go-ethereum/common"] = true
+		case strings.Contains(goType, "struct"):
+			return nil, fmt.Errorf("tuple argument %s is not supported", input.Name)
+		}
+		goType = strings.ReplaceAll(goType, "uint8", "byte")
+		fields[i] = field{name: name, goType: goType}
+		fmt.Fprintf(w, "\t%s %s\n", name, goType)
+	}
+	w.WriteString("}\n")
+	return fields, nil
+}
+
+func writeConversions(w *bytes.Buffer, fields []field) {
+	for i, f := range fields {
+		fmt.Fprintf(w, "\targs.%s = *abi.ConvertType(values[%d], new(%s)).(*%s)\n", f.name, i, f.goType, f.goType)
+	}
+}
+
+func lowerFirst(s string) string {
+	return strings.ToLower(s[:1]) + s[1:]
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/0xpaulio/eth-sf-ens-rr/bindings"
+)
+
+// embeddedArtifacts rebuilds forge artifacts from the ABIs embedded in the
+// checked-in bindings, so generation can be checked without a forge build.
+func embeddedArtifacts(t *testing.T) fstest.MapFS {
+	t.Helper()
+	abis := map[string]string{
+		"L1ENSRegistry": bindings.L1ENSRegistryMetaData.ABI,
+		"L2ENSRegistry": bindings.L2ENSRegistryMetaData.ABI,
+		"L2Forwarder":   bindings.L2ForwarderMetaData.ABI,
+		"L2StateProof":  bindings.L2StateProofABI,
+	}
+	bins := map[string]string{
+		"L2ENSRegistry": bindings.L2ENSRegistryMetaData.Bin,
+		"L2Forwarder":   bindings.L2ForwarderMetaData.Bin,
+	}
+	fsys := make(fstest.MapFS)
+	for _, c := range contracts {
+		abiJSON, ok := abis[c.Name]
+		if !ok {
+			t.Fatalf("no embedded ABI for %s", c.Name)
+		}
+		if c.Deploy && bins[c.Name] == "" {
+			t.Fatalf("no embedded bytecode for %s", c.Name)
+		}
+		artifact, err := json.Marshal(map[string]interface{}{
+			"abi":      json.RawMessage(abiJSON),
+			"bytecode": map[string]string{"object": bins[c.Name]},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		fsys[c.Artifact] = &fstest.MapFile{Data: artifact}
+	}
+	return fsys
+}
+
+// TestGeneratedFilesUpToDate fails when a generated file was edited by hand
+// or the generator changed without regenerating.
+func TestGeneratedFilesUpToDate(t *testing.T) {
+	files, err := generate(embeddedArtifacts(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// abigen drops the internal types, and so the struct names, from the
+	// ABI it embeds. Its output is only checked against forge artifacts.
+	for _, c := range contracts {
+		if c.Bind {
+			delete(files, strings.ToLower(c.Name)+".go")
+		}
+	}
+	if stale := staleFiles("../..", files); len(stale) > 0 {
+		t.Fatalf("generated files differ from the checked-in bindings, run go generate ./bindings: %v", stale)
+	}
+}
+
+// TestBindingsMatchArtifacts compares the checked-in bindings to the forge
+// artifacts. Without them it skips, unless REQUIRE_FORGE_ARTIFACTS or
+// FORGE_OUT is set, as "make check-bindings" in contracts/ does.
+func TestBindingsMatchArtifacts(t *testing.T) {
+	dir := "../../../../contracts/out"
+	required := false
+	if env := os.Getenv("REQUIRE_FORGE_ARTIFACTS"); env != "" {
+		var err error
+		if required, err = strconv.ParseBool(env); err != nil {
+			t.Fatalf("parsing REQUIRE_FORGE_ARTIFACTS: %v", err)
+		}
+	}
+	if env := os.Getenv("FORGE_OUT"); env != "" {
+		dir, required = env, true
+	}
+	if _, err := os.Stat(dir); err != nil {
+		if required {
+			t.Fatalf("no forge artifacts in %s: %v", dir, err)
+		}
+		t.Skipf("no forge artifacts in %s, run forge build in contracts/ or set FORGE_OUT", dir)
+	}
+	files, err := generate(os.DirFS(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stale := staleFiles("../..", files); len(stale) > 0 {
+		t.Fatalf("bindings are out of date with the contracts, run go generate ./bindings: %v", stale)
+	}
+}
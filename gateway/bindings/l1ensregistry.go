@@ -0,0 +1,1597 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package bindings
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// L1ENSRegistryMetaData contains all meta data concerning the L1ENSRegistry contract.
+var L1ENSRegistryMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"_chainId\",\"type\":\"uint64\"},{\"internalType\":\"address\",\"name\":\"_l2Registrar\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_ovmAddressManager\",\"type\":\"address\"},{\"internalType\":\"string[]\",\"name\":\"_gatewayUrls\",\"type\":\"string[]\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"inputs\":[],\"name\":\"AccountDNE\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"InvalidStateRoot\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"internalType\":\"string[]\",\"name\":\"urls\",\"type\":\"string[]\"},{\"internalType\":\"bytes\",\"name\":\"callData\",\"type\":\"bytes\"},{\"internalType\":\"bytes4\",\"name\":\"callbackFunction\",\"type\":\"bytes4\"},{\"internalType\":\"bytes\",\"name\":\"extraData\",\"type\":\"bytes\"}],\"name\":\"OffchainLookup\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"StorageDNE\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"chainId\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"contractAddress\",\"type\":\"address\"}],\"name\":\"StorageHandledByL2\",\"type\":\"error\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"bool\",\"name\":\"approved\",\"type\":\"bool\"}],\"name\":\"ApprovalForAll\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"node\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"label\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"}],\"name\":\"NewOwner\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"node\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"resolver\",\"type\":\"address\"}],\"name\":\"NewResolver\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"node\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"ttl\",\"type\":\"uint64\"}],\"name\":\"NewTTL\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"node\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"}],\"name\":\"Transfer\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"L2_REGISTRY_CHAIN_ID\",\"outputs\":[{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"L2_REGISTRY_CONTRACT_ADDRESS\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"SLO__L2_REGISTRY__OPERATORS\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"SLO__L2_REGISTRY__RECORDS\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"gatewayUrls\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_owner\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_operator\",\"type\":\"address\"}],\"name\":\"isApprovedForAll\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"_stateProof\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"_extraData\",\"type\":\"bytes\"}],\"name\":\"isApprovedForAllWithProof\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"approved_\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"libAddressManager\",\"outputs\":[{\"internalType\":\"contractLib_AddressManager\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_node\",\"type\":\"bytes32\"}],\"name\":\"owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"_stateProof\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"_extraData\",\"type\":\"bytes\"}],\"name\":\"ownerWithProof\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_node\",\"type\":\"bytes32\"}],\"name\":\"recordExists\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"_stateProof\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"_extraData\",\"type\":\"bytes\"}],\"name\":\"recordExistsWithProof\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"_name\",\"type\":\"string\"}],\"name\":\"resolve\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_node\",\"type\":\"bytes32\"}],\"name\":\"resolver\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"_stateProof\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"_extraData\",\"type\":\"bytes\"}],\"name\":\"resolverWithProof\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"internalType\":\"bool\",\"name\":\"approved\",\"type\":\"bool\"}],\"name\":\"setApprovalForAll\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"node\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"}],\"name\":\"setOwner\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"node\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"resolver\",\"type\":\"address\"},{\"internalType\":\"uint64\",\"name\":\"ttl\",\"type\":\"uint64\"}],\"name\":\"setRecord\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"node\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"resolver\",\"type\":\"address\"}],\"name\":\"setResolver\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"node\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"label\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"}],\"name\":\"setSubnodeOwner\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"node\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"label\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"resolver\",\"type\":\"address\"},{\"internalType\":\"uint64\",\"name\":\"ttl\",\"type\":\"uint64\"}],\"name\":\"setSubnodeRecord\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"node\",\"type\":\"bytes32\"},{\"internalType\":\"uint64\",\"name\":\"ttl\",\"type\":\"uint64\"}],\"name\":\"setTTL\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_node\",\"type\":\"bytes32\"}],\"name\":\"ttl\",\"outputs\":[{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"_stateProof\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"_extraData\",\"type\":\"bytes\"}],\"name\":\"ttlWithProof\",\"outputs\":[{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+}
+
+// L1ENSRegistryABI is the input ABI used to generate the binding from.
+// Deprecated: Use L1ENSRegistryMetaData.ABI instead.
+var L1ENSRegistryABI = L1ENSRegistryMetaData.ABI
+
+// L1ENSRegistry is an auto generated Go binding around an Ethereum contract.
+type L1ENSRegistry struct {
+	L1ENSRegistryCaller     // Read-only binding to the contract
+	L1ENSRegistryTransactor // Write-only binding to the contract
+	L1ENSRegistryFilterer   // Log filterer for contract events
+}
+
+// L1ENSRegistryCaller is an auto generated read-only Go binding around an Ethereum contract.
+type L1ENSRegistryCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// L1ENSRegistryTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type L1ENSRegistryTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// L1ENSRegistryFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type L1ENSRegistryFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// L1ENSRegistrySession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type L1ENSRegistrySession struct {
+	Contract     *L1ENSRegistry    // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// L1ENSRegistryCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type L1ENSRegistryCallerSession struct {
+	Contract *L1ENSRegistryCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts        // Call options to use throughout this session
+}
+
+// L1ENSRegistryTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type L1ENSRegistryTransactorSession struct {
+	Contract     *L1ENSRegistryTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts        // Transaction auth options to use throughout this session
+}
+
+// L1ENSRegistryRaw is an auto generated low-level Go binding around an Ethereum contract.
+type L1ENSRegistryRaw struct {
+	Contract *L1ENSRegistry // Generic contract binding to access the raw methods on
+}
+
+// L1ENSRegistryCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type L1ENSRegistryCallerRaw struct {
+	Contract *L1ENSRegistryCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// L1ENSRegistryTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type L1ENSRegistryTransactorRaw struct {
+	Contract *L1ENSRegistryTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewL1ENSRegistry creates a new instance of L1ENSRegistry, bound to a specific deployed contract.
+func NewL1ENSRegistry(address common.Address, backend bind.ContractBackend) (*L1ENSRegistry, error) {
+	contract, err := bindL1ENSRegistry(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &L1ENSRegistry{L1ENSRegistryCaller: L1ENSRegistryCaller{contract: contract}, L1ENSRegistryTransactor: L1ENSRegistryTransactor{contract: contract}, L1ENSRegistryFilterer: L1ENSRegistryFilterer{contract: contract}}, nil
+}
+
+// NewL1ENSRegistryCaller creates a new read-only instance of L1ENSRegistry, bound to a specific deployed contract.
+func NewL1ENSRegistryCaller(address common.Address, caller bind.ContractCaller) (*L1ENSRegistryCaller, error) {
+	contract, err := bindL1ENSRegistry(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &L1ENSRegistryCaller{contract: contract}, nil
+}
+
+// NewL1ENSRegistryTransactor creates a new write-only instance of L1ENSRegistry, bound to a specific deployed contract.
+func NewL1ENSRegistryTransactor(address common.Address, transactor bind.ContractTransactor) (*L1ENSRegistryTransactor, error) {
+	contract, err := bindL1ENSRegistry(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &L1ENSRegistryTransactor{contract: contract}, nil
+}
+
+// NewL1ENSRegistryFilterer creates a new log filterer instance of L1ENSRegistry, bound to a specific deployed contract.
+func NewL1ENSRegistryFilterer(address common.Address, filterer bind.ContractFilterer) (*L1ENSRegistryFilterer, error) {
+	contract, err := bindL1ENSRegistry(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &L1ENSRegistryFilterer{contract: contract}, nil
+}
+
+// bindL1ENSRegistry binds a generic wrapper to an already deployed contract.
+func bindL1ENSRegistry(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(L1ENSRegistryABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_L1ENSRegistry *L1ENSRegistryRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _L1ENSRegistry.Contract.L1ENSRegistryCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_L1ENSRegistry *L1ENSRegistryRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _L1ENSRegistry.Contract.L1ENSRegistryTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_L1ENSRegistry *L1ENSRegistryRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _L1ENSRegistry.Contract.L1ENSRegistryTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_L1ENSRegistry *L1ENSRegistryCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _L1ENSRegistry.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_L1ENSRegistry *L1ENSRegistryTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _L1ENSRegistry.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_L1ENSRegistry *L1ENSRegistryTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _L1ENSRegistry.Contract.contract.Transact(opts, method, params...)
+}
+
+// L2REGISTRYCHAINID is a free data retrieval call binding the contract method 0xb03366b9.
+//
+// Solidity: function L2_REGISTRY_CHAIN_ID() view returns(uint64)
+func (_L1ENSRegistry *L1ENSRegistryCaller) L2REGISTRYCHAINID(opts *bind.CallOpts) (uint64, error) {
+	var out []interface{}
+	err := _L1ENSRegistry.contract.Call(opts, &out, "L2_REGISTRY_CHAIN_ID")
+
+	if err != nil {
+		return *new(uint64), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint64)).(*uint64)
+
+	return out0, err
+
+}
+
+// L2REGISTRYCHAINID is a free data retrieval call binding the contract method 0xb03366b9.
+//
+// Solidity: function L2_REGISTRY_CHAIN_ID() view returns(uint64)
+func (_L1ENSRegistry *L1ENSRegistrySession) L2REGISTRYCHAINID() (uint64, error) {
+	return _L1ENSRegistry.Contract.L2REGISTRYCHAINID(&_L1ENSRegistry.CallOpts)
+}
+
+// L2REGISTRYCHAINID is a free data retrieval call binding the contract method 0xb03366b9.
+//
+// Solidity: function L2_REGISTRY_CHAIN_ID() view returns(uint64)
+func (_L1ENSRegistry *L1ENSRegistryCallerSession) L2REGISTRYCHAINID() (uint64, error) {
+	return _L1ENSRegistry.Contract.L2REGISTRYCHAINID(&_L1ENSRegistry.CallOpts)
+}
+
+// L2REGISTRYCONTRACTADDRESS is a free data retrieval call binding the contract method 0x1443983b.
+//
+// Solidity: function L2_REGISTRY_CONTRACT_ADDRESS() view returns(address)
+func (_L1ENSRegistry *L1ENSRegistryCaller) L2REGISTRYCONTRACTADDRESS(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _L1ENSRegistry.contract.Call(opts, &out, "L2_REGISTRY_CONTRACT_ADDRESS")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// L2REGISTRYCONTRACTADDRESS is a free data retrieval call binding the contract method 0x1443983b.
+//
+// Solidity: function L2_REGISTRY_CONTRACT_ADDRESS() view returns(address)
+func (_L1ENSRegistry *L1ENSRegistrySession) L2REGISTRYCONTRACTADDRESS() (common.Address, error) {
+	return _L1ENSRegistry.Contract.L2REGISTRYCONTRACTADDRESS(&_L1ENSRegistry.CallOpts)
+}
+
+// L2REGISTRYCONTRACTADDRESS is a free data retrieval call binding the contract method 0x1443983b.
+//
+// Solidity: function L2_REGISTRY_CONTRACT_ADDRESS() view returns(address)
+func (_L1ENSRegistry *L1ENSRegistryCallerSession) L2REGISTRYCONTRACTADDRESS() (common.Address, error) {
+	return _L1ENSRegistry.Contract.L2REGISTRYCONTRACTADDRESS(&_L1ENSRegistry.CallOpts)
+}
+
+// SLOL2REGISTRYOPERATORS is a free data retrieval call binding the contract method 0xfee0d060.
+//
+// Solidity: function SLO__L2_REGISTRY__OPERATORS() view returns(uint256)
+func (_L1ENSRegistry *L1ENSRegistryCaller) SLOL2REGISTRYOPERATORS(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _L1ENSRegistry.contract.Call(opts, &out, "SLO__L2_REGISTRY__OPERATORS")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// SLOL2REGISTRYOPERATORS is a free data retrieval call binding the contract method 0xfee0d060.
+//
+// Solidity: function SLO__L2_REGISTRY__OPERATORS() view returns(uint256)
+func (_L1ENSRegistry *L1ENSRegistrySession) SLOL2REGISTRYOPERATORS() (*big.Int, error) {
+	return _L1ENSRegistry.Contract.SLOL2REGISTRYOPERATORS(&_L1ENSRegistry.CallOpts)
+}
+
+// SLOL2REGISTRYOPERATORS is a free data retrieval call binding the contract method 0xfee0d060.
+//
+// Solidity: function SLO__L2_REGISTRY__OPERATORS() view returns(uint256)
+func (_L1ENSRegistry *L1ENSRegistryCallerSession) SLOL2REGISTRYOPERATORS() (*big.Int, error) {
+	return _L1ENSRegistry.Contract.SLOL2REGISTRYOPERATORS(&_L1ENSRegistry.CallOpts)
+}
+
+// SLOL2REGISTRYRECORDS is a free data retrieval call binding the contract method 0x9c59d283.
+//
+// Solidity: function SLO__L2_REGISTRY__RECORDS() view returns(uint256)
+func (_L1ENSRegistry *L1ENSRegistryCaller) SLOL2REGISTRYRECORDS(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _L1ENSRegistry.contract.Call(opts, &out, "SLO__L2_REGISTRY__RECORDS")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// SLOL2REGISTRYRECORDS is a free data retrieval call binding the contract method 0x9c59d283.
+//
+// Solidity: function SLO__L2_REGISTRY__RECORDS() view returns(uint256)
+func (_L1ENSRegistry *L1ENSRegistrySession) SLOL2REGISTRYRECORDS() (*big.Int, error) {
+	return _L1ENSRegistry.Contract.SLOL2REGISTRYRECORDS(&_L1ENSRegistry.CallOpts)
+}
+
+// SLOL2REGISTRYRECORDS is a free data retrieval call binding the contract method 0x9c59d283.
+//
+// Solidity: function SLO__L2_REGISTRY__RECORDS() view returns(uint256)
+func (_L1ENSRegistry *L1ENSRegistryCallerSession) SLOL2REGISTRYRECORDS() (*big.Int, error) {
+	return _L1ENSRegistry.Contract.SLOL2REGISTRYRECORDS(&_L1ENSRegistry.CallOpts)
+}
+
+// GatewayUrls is a free data retrieval call binding the contract method 0xa2b8fb24.
+//
+// Solidity: function gatewayUrls(uint256 ) view returns(string)
+func (_L1ENSRegistry *L1ENSRegistryCaller) GatewayUrls(opts *bind.CallOpts, arg0 *big.Int) (string, error) {
+	var out []interface{}
+	err := _L1ENSRegistry.contract.Call(opts, &out, "gatewayUrls", arg0)
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// GatewayUrls is a free data retrieval call binding the contract method 0xa2b8fb24.
+//
+// Solidity: function gatewayUrls(uint256 ) view returns(string)
+func (_L1ENSRegistry *L1ENSRegistrySession) GatewayUrls(arg0 *big.Int) (string, error) {
+	return _L1ENSRegistry.Contract.GatewayUrls(&_L1ENSRegistry.CallOpts, arg0)
+}
+
+// GatewayUrls is a free data retrieval call binding the contract method 0xa2b8fb24.
+//
+// Solidity: function gatewayUrls(uint256 ) view returns(string)
+func (_L1ENSRegistry *L1ENSRegistryCallerSession) GatewayUrls(arg0 *big.Int) (string, error) {
+	return _L1ENSRegistry.Contract.GatewayUrls(&_L1ENSRegistry.CallOpts, arg0)
+}
+
+// IsApprovedForAll is a free data retrieval call binding the contract method 0xe985e9c5.
+//
+// Solidity: function isApprovedForAll(address _owner, address _operator) view returns(bool)
+func (_L1ENSRegistry *L1ENSRegistryCaller) IsApprovedForAll(opts *bind.CallOpts, _owner common.Address, _operator common.Address) (bool, error) {
+	var out []interface{}
+	err := _L1ENSRegistry.contract.Call(opts, &out, "isApprovedForAll", _owner, _operator)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsApprovedForAll is a free data retrieval call binding the contract method 0xe985e9c5.
+//
+// Solidity: function isApprovedForAll(address _owner, address _operator) view returns(bool)
+func (_L1ENSRegistry *L1ENSRegistrySession) IsApprovedForAll(_owner common.Address, _operator common.Address) (bool, error) {
+	return _L1ENSRegistry.Contract.IsApprovedForAll(&_L1ENSRegistry.CallOpts, _owner, _operator)
+}
+
+// IsApprovedForAll is a free data retrieval call binding the contract method 0xe985e9c5.
+//
+// Solidity: function isApprovedForAll(address _owner, address _operator) view returns(bool)
+func (_L1ENSRegistry *L1ENSRegistryCallerSession) IsApprovedForAll(_owner common.Address, _operator common.Address) (bool, error) {
+	return _L1ENSRegistry.Contract.IsApprovedForAll(&_L1ENSRegistry.CallOpts, _owner, _operator)
+}
+
+// IsApprovedForAllWithProof is a free data retrieval call binding the contract method 0xd5d1b7a3.
+//
+// Solidity: function isApprovedForAllWithProof(bytes _stateProof, bytes _extraData) view returns(bool approved_)
+func (_L1ENSRegistry *L1ENSRegistryCaller) IsApprovedForAllWithProof(opts *bind.CallOpts, _stateProof []byte, _extraData []byte) (bool, error) {
+	var out []interface{}
+	err := _L1ENSRegistry.contract.Call(opts, &out, "isApprovedForAllWithProof", _stateProof, _extraData)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsApprovedForAllWithProof is a free data retrieval call binding the contract method 0xd5d1b7a3.
+//
+// Solidity: function isApprovedForAllWithProof(bytes _stateProof, bytes _extraData) view returns(bool approved_)
+func (_L1ENSRegistry *L1ENSRegistrySession) IsApprovedForAllWithProof(_stateProof []byte, _extraData []byte) (bool, error) {
+	return _L1ENSRegistry.Contract.IsApprovedForAllWithProof(&_L1ENSRegistry.CallOpts, _stateProof, _extraData)
+}
+
+// IsApprovedForAllWithProof is a free data retrieval call binding the contract method 0xd5d1b7a3.
+//
+// Solidity: function isApprovedForAllWithProof(bytes _stateProof, bytes _extraData) view returns(bool approved_)
+func (_L1ENSRegistry *L1ENSRegistryCallerSession) IsApprovedForAllWithProof(_stateProof []byte, _extraData []byte) (bool, error) {
+	return _L1ENSRegistry.Contract.IsApprovedForAllWithProof(&_L1ENSRegistry.CallOpts, _stateProof, _extraData)
+}
+
+// LibAddressManager is a free data retrieval call binding the contract method 0x299ca478.
+//
+// Solidity: function libAddressManager() view returns(address)
+func (_L1ENSRegistry *L1ENSRegistryCaller) LibAddressManager(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _L1ENSRegistry.contract.Call(opts, &out, "libAddressManager")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// LibAddressManager is a free data retrieval call binding the contract method 0x299ca478.
+//
+// Solidity: function libAddressManager() view returns(address)
+func (_L1ENSRegistry *L1ENSRegistrySession) LibAddressManager() (common.Address, error) {
+	return _L1ENSRegistry.Contract.LibAddressManager(&_L1ENSRegistry.CallOpts)
+}
+
+// LibAddressManager is a free data retrieval call binding the contract method 0x299ca478.
+//
+// Solidity: function libAddressManager() view returns(address)
+func (_L1ENSRegistry *L1ENSRegistryCallerSession) LibAddressManager() (common.Address, error) {
+	return _L1ENSRegistry.Contract.LibAddressManager(&_L1ENSRegistry.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x02571be3.
+//
+// Solidity: function owner(bytes32 _node) view returns(address)
+func (_L1ENSRegistry *L1ENSRegistryCaller) Owner(opts *bind.CallOpts, _node [32]byte) (common.Address, error) {
+	var out []interface{}
+	err := _L1ENSRegistry.contract.Call(opts, &out, "owner", _node)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Owner is a free data retrieval call binding the contract method 0x02571be3.
+//
+// Solidity: function owner(bytes32 _node) view returns(address)
+func (_L1ENSRegistry *L1ENSRegistrySession) Owner(_node [32]byte) (common.Address, error) {
+	return _L1ENSRegistry.Contract.Owner(&_L1ENSRegistry.CallOpts, _node)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x02571be3.
+//
+// Solidity: function owner(bytes32 _node) view returns(address)
+func (_L1ENSRegistry *L1ENSRegistryCallerSession) Owner(_node [32]byte) (common.Address, error) {
+	return _L1ENSRegistry.Contract.Owner(&_L1ENSRegistry.CallOpts, _node)
+}
+
+// OwnerWithProof is a free data retrieval call binding the contract method 0x9fddfaeb.
+//
+// Solidity: function ownerWithProof(bytes _stateProof, bytes _extraData) view returns(address)
+func (_L1ENSRegistry *L1ENSRegistryCaller) OwnerWithProof(opts *bind.CallOpts, _stateProof []byte, _extraData []byte) (common.Address, error) {
+	var out []interface{}
+	err := _L1ENSRegistry.contract.Call(opts, &out, "ownerWithProof", _stateProof, _extraData)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// OwnerWithProof is a free data retrieval call binding the contract method 0x9fddfaeb.
+//
+// Solidity: function ownerWithProof(bytes _stateProof, bytes _extraData) view returns(address)
+func (_L1ENSRegistry *L1ENSRegistrySession) OwnerWithProof(_stateProof []byte, _extraData []byte) (common.Address, error) {
+	return _L1ENSRegistry.Contract.OwnerWithProof(&_L1ENSRegistry.CallOpts, _stateProof, _extraData)
+}
+
+// OwnerWithProof is a free data retrieval call binding the contract method 0x9fddfaeb.
+//
+// Solidity: function ownerWithProof(bytes _stateProof, bytes _extraData) view returns(address)
+func (_L1ENSRegistry *L1ENSRegistryCallerSession) OwnerWithProof(_stateProof []byte, _extraData []byte) (common.Address, error) {
+	return _L1ENSRegistry.Contract.OwnerWithProof(&_L1ENSRegistry.CallOpts, _stateProof, _extraData)
+}
+
+// RecordExists is a free data retrieval call binding the contract method 0xf79fe538.
+//
+// Solidity: function recordExists(bytes32 _node) view returns(bool)
+func (_L1ENSRegistry *L1ENSRegistryCaller) RecordExists(opts *bind.CallOpts, _node [32]byte) (bool, error) {
+	var out []interface{}
+	err := _L1ENSRegistry.contract.Call(opts, &out, "recordExists", _node)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// RecordExists is a free data retrieval call binding the contract method 0xf79fe538.
+//
+// Solidity: function recordExists(bytes32 _node) view returns(bool)
+func (_L1ENSRegistry *L1ENSRegistrySession) RecordExists(_node [32]byte) (bool, error) {
+	return _L1ENSRegistry.Contract.RecordExists(&_L1ENSRegistry.CallOpts, _node)
+}
+
+// RecordExists is a free data retrieval call binding the contract method 0xf79fe538.
+//
+// Solidity: function recordExists(bytes32 _node) view returns(bool)
+func (_L1ENSRegistry *L1ENSRegistryCallerSession) RecordExists(_node [32]byte) (bool, error) {
+	return _L1ENSRegistry.Contract.RecordExists(&_L1ENSRegistry.CallOpts, _node)
+}
+
+// RecordExistsWithProof is a free data retrieval call binding the contract method 0x73984847.
+//
+// Solidity: function recordExistsWithProof(bytes _stateProof, bytes _extraData) view returns(bool)
+func (_L1ENSRegistry *L1ENSRegistryCaller) RecordExistsWithProof(opts *bind.CallOpts, _stateProof []byte, _extraData []byte) (bool, error) {
+	var out []interface{}
+	err := _L1ENSRegistry.contract.Call(opts, &out, "recordExistsWithProof", _stateProof, _extraData)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// RecordExistsWithProof is a free data retrieval call binding the contract method 0x73984847.
+//
+// Solidity: function recordExistsWithProof(bytes _stateProof, bytes _extraData) view returns(bool)
+func (_L1ENSRegistry *L1ENSRegistrySession) RecordExistsWithProof(_stateProof []byte, _extraData []byte) (bool, error) {
+	return _L1ENSRegistry.Contract.RecordExistsWithProof(&_L1ENSRegistry.CallOpts, _stateProof, _extraData)
+}
+
+// RecordExistsWithProof is a free data retrieval call binding the contract method 0x73984847.
+//
+// Solidity: function recordExistsWithProof(bytes _stateProof, bytes _extraData) view returns(bool)
+func (_L1ENSRegistry *L1ENSRegistryCallerSession) RecordExistsWithProof(_stateProof []byte, _extraData []byte) (bool, error) {
+	return _L1ENSRegistry.Contract.RecordExistsWithProof(&_L1ENSRegistry.CallOpts, _stateProof, _extraData)
+}
+
+// Resolve is a free data retrieval call binding the contract method 0x461a4478.
+//
+// Solidity: function resolve(string _name) view returns(address)
+func (_L1ENSRegistry *L1ENSRegistryCaller) Resolve(opts *bind.CallOpts, _name string) (common.Address, error) {
+	var out []interface{}
+	err := _L1ENSRegistry.contract.Call(opts, &out, "resolve", _name)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Resolve is a free data retrieval call binding the contract method 0x461a4478.
+//
+// Solidity: function resolve(string _name) view returns(address)
+func (_L1ENSRegistry *L1ENSRegistrySession) Resolve(_name string) (common.Address, error) {
+	return _L1ENSRegistry.Contract.Resolve(&_L1ENSRegistry.CallOpts, _name)
+}
+
+// Resolve is a free data retrieval call binding the contract method 0x461a4478.
+//
+// Solidity: function resolve(string _name) view returns(address)
+func (_L1ENSRegistry *L1ENSRegistryCallerSession) Resolve(_name string) (common.Address, error) {
+	return _L1ENSRegistry.Contract.Resolve(&_L1ENSRegistry.CallOpts, _name)
+}
+
+// Resolver is a free data retrieval call binding the contract method 0x0178b8bf.
+//
+// Solidity: function resolver(bytes32 _node) view returns(address)
+func (_L1ENSRegistry *L1ENSRegistryCaller) Resolver(opts *bind.CallOpts, _node [32]byte) (common.Address, error) {
+	var out []interface{}
+	err := _L1ENSRegistry.contract.Call(opts, &out, "resolver", _node)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Resolver is a free data retrieval call binding the contract method 0x0178b8bf.
+//
+// Solidity: function resolver(bytes32 _node) view returns(address)
+func (_L1ENSRegistry *L1ENSRegistrySession) Resolver(_node [32]byte) (common.Address, error) {
+	return _L1ENSRegistry.Contract.Resolver(&_L1ENSRegistry.CallOpts, _node)
+}
+
+// Resolver is a free data retrieval call binding the contract method 0x0178b8bf.
+//
+// Solidity: function resolver(bytes32 _node) view returns(address)
+func (_L1ENSRegistry *L1ENSRegistryCallerSession) Resolver(_node [32]byte) (common.Address, error) {
+	return _L1ENSRegistry.Contract.Resolver(&_L1ENSRegistry.CallOpts, _node)
+}
+
+// ResolverWithProof is a free data retrieval call binding the contract method 0x2ef89dcd.
+//
+// Solidity: function resolverWithProof(bytes _stateProof, bytes _extraData) view returns(address)
+func (_L1ENSRegistry *L1ENSRegistryCaller) ResolverWithProof(opts *bind.CallOpts, _stateProof []byte, _extraData []byte) (common.Address, error) {
+	var out []interface{}
+	err := _L1ENSRegistry.contract.Call(opts, &out, "resolverWithProof", _stateProof, _extraData)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// ResolverWithProof is a free data retrieval call binding the contract method 0x2ef89dcd.
+//
+// Solidity: function resolverWithProof(bytes _stateProof, bytes _extraData) view returns(address)
+func (_L1ENSRegistry *L1ENSRegistrySession) ResolverWithProof(_stateProof []byte, _extraData []byte) (common.Address, error) {
+	return _L1ENSRegistry.Contract.ResolverWithProof(&_L1ENSRegistry.CallOpts, _stateProof, _extraData)
+}
+
+// ResolverWithProof is a free data retrieval call binding the contract method 0x2ef89dcd.
+//
+// Solidity: function resolverWithProof(bytes _stateProof, bytes _extraData) view returns(address)
+func (_L1ENSRegistry *L1ENSRegistryCallerSession) ResolverWithProof(_stateProof []byte, _extraData []byte) (common.Address, error) {
+	return _L1ENSRegistry.Contract.ResolverWithProof(&_L1ENSRegistry.CallOpts, _stateProof, _extraData)
+}
+
+// Ttl is a free data retrieval call binding the contract method 0x16a25cbd.
+//
+// Solidity: function ttl(bytes32 _node) view returns(uint64)
+func (_L1ENSRegistry *L1ENSRegistryCaller) Ttl(opts *bind.CallOpts, _node [32]byte) (uint64, error) {
+	var out []interface{}
+	err := _L1ENSRegistry.contract.Call(opts, &out, "ttl", _node)
+
+	if err != nil {
+		return *new(uint64), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint64)).(*uint64)
+
+	return out0, err
+
+}
+
+// Ttl is a free data retrieval call binding the contract method 0x16a25cbd.
+//
+// Solidity: function ttl(bytes32 _node) view returns(uint64)
+func (_L1ENSRegistry *L1ENSRegistrySession) Ttl(_node [32]byte) (uint64, error) {
+	return _L1ENSRegistry.Contract.Ttl(&_L1ENSRegistry.CallOpts, _node)
+}
+
+// Ttl is a free data retrieval call binding the contract method 0x16a25cbd.
+//
+// Solidity: function ttl(bytes32 _node) view returns(uint64)
+func (_L1ENSRegistry *L1ENSRegistryCallerSession) Ttl(_node [32]byte) (uint64, error) {
+	return _L1ENSRegistry.Contract.Ttl(&_L1ENSRegistry.CallOpts, _node)
+}
+
+// TtlWithProof is a free data retrieval call binding the contract method 0x12ceb33c.
+//
+// Solidity: function ttlWithProof(bytes _stateProof, bytes _extraData) view returns(uint64)
+func (_L1ENSRegistry *L1ENSRegistryCaller) TtlWithProof(opts *bind.CallOpts, _stateProof []byte, _extraData []byte) (uint64, error) {
+	var out []interface{}
+	err := _L1ENSRegistry.contract.Call(opts, &out, "ttlWithProof", _stateProof, _extraData)
+
+	if err != nil {
+		return *new(uint64), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint64)).(*uint64)
+
+	return out0, err
+
+}
+
+// TtlWithProof is a free data retrieval call binding the contract method 0x12ceb33c.
+//
+// Solidity: function ttlWithProof(bytes _stateProof, bytes _extraData) view returns(uint64)
+func (_L1ENSRegistry *L1ENSRegistrySession) TtlWithProof(_stateProof []byte, _extraData []byte) (uint64, error) {
+	return _L1ENSRegistry.Contract.TtlWithProof(&_L1ENSRegistry.CallOpts, _stateProof, _extraData)
+}
+
+// TtlWithProof is a free data retrieval call binding the contract method 0x12ceb33c.
+//
+// Solidity: function ttlWithProof(bytes _stateProof, bytes _extraData) view returns(uint64)
+func (_L1ENSRegistry *L1ENSRegistryCallerSession) TtlWithProof(_stateProof []byte, _extraData []byte) (uint64, error) {
+	return _L1ENSRegistry.Contract.TtlWithProof(&_L1ENSRegistry.CallOpts, _stateProof, _extraData)
+}
+
+// SetApprovalForAll is a paid mutator transaction binding the contract method 0xa22cb465.
+//
+// Solidity: function setApprovalForAll(address operator, bool approved) returns()
+func (_L1ENSRegistry *L1ENSRegistryTransactor) SetApprovalForAll(opts *bind.TransactOpts, operator common.Address, approved bool) (*types.Transaction, error) {
+	return _L1ENSRegistry.contract.Transact(opts, "setApprovalForAll", operator, approved)
+}
+
+// SetApprovalForAll is a paid mutator transaction binding the contract method 0xa22cb465.
+//
+// Solidity: function setApprovalForAll(address operator, bool approved) returns()
+func (_L1ENSRegistry *L1ENSRegistrySession) SetApprovalForAll(operator common.Address, approved bool) (*types.Transaction, error) {
+	return _L1ENSRegistry.Contract.SetApprovalForAll(&_L1ENSRegistry.TransactOpts, operator, approved)
+}
+
+// SetApprovalForAll is a paid mutator transaction binding the contract method 0xa22cb465.
+//
+// Solidity: function setApprovalForAll(address operator, bool approved) returns()
+func (_L1ENSRegistry *L1ENSRegistryTransactorSession) SetApprovalForAll(operator common.Address, approved bool) (*types.Transaction, error) {
+	return _L1ENSRegistry.Contract.SetApprovalForAll(&_L1ENSRegistry.TransactOpts, operator, approved)
+}
+
+// SetOwner is a paid mutator transaction binding the contract method 0x5b0fc9c3.
+//
+// Solidity: function setOwner(bytes32 node, address owner) returns()
+func (_L1ENSRegistry *L1ENSRegistryTransactor) SetOwner(opts *bind.TransactOpts, node [32]byte, owner common.Address) (*types.Transaction, error) {
+	return _L1ENSRegistry.contract.Transact(opts, "setOwner", node, owner)
+}
+
+// SetOwner is a paid mutator transaction binding the contract method 0x5b0fc9c3.
+//
+// Solidity: function setOwner(bytes32 node, address owner) returns()
+func (_L1ENSRegistry *L1ENSRegistrySession) SetOwner(node [32]byte, owner common.Address) (*types.Transaction, error) {
+	return _L1ENSRegistry.Contract.SetOwner(&_L1ENSRegistry.TransactOpts, node, owner)
+}
+
+// SetOwner is a paid mutator transaction binding the contract method 0x5b0fc9c3.
+//
+// Solidity: function setOwner(bytes32 node, address owner) returns()
+func (_L1ENSRegistry *L1ENSRegistryTransactorSession) SetOwner(node [32]byte, owner common.Address) (*types.Transaction, error) {
+	return _L1ENSRegistry.Contract.SetOwner(&_L1ENSRegistry.TransactOpts, node, owner)
+}
+
+// SetRecord is a paid mutator transaction binding the contract method 0xcf408823.
+//
+// Solidity: function setRecord(bytes32 node, address owner, address resolver, uint64 ttl) returns()
+func (_L1ENSRegistry *L1ENSRegistryTransactor) SetRecord(opts *bind.TransactOpts, node [32]byte, owner common.Address, resolver common.Address, ttl uint64) (*types.Transaction, error) {
+	return _L1ENSRegistry.contract.Transact(opts, "setRecord", node, owner, resolver, ttl)
+}
+
+// SetRecord is a paid mutator transaction binding the contract method 0xcf408823.
+//
+// Solidity: function setRecord(bytes32 node, address owner, address resolver, uint64 ttl) returns()
+func (_L1ENSRegistry *L1ENSRegistrySession) SetRecord(node [32]byte, owner common.Address, resolver common.Address, ttl uint64) (*types.Transaction, error) {
+	return _L1ENSRegistry.Contract.SetRecord(&_L1ENSRegistry.TransactOpts, node, owner, resolver, ttl)
+}
+
+// SetRecord is a paid mutator transaction binding the contract method 0xcf408823.
+//
+// Solidity: function setRecord(bytes32 node, address owner, address resolver, uint64 ttl) returns()
+func (_L1ENSRegistry *L1ENSRegistryTransactorSession) SetRecord(node [32]byte, owner common.Address, resolver common.Address, ttl uint64) (*types.Transaction, error) {
+	return _L1ENSRegistry.Contract.SetRecord(&_L1ENSRegistry.TransactOpts, node, owner, resolver, ttl)
+}
+
+// SetResolver is a paid mutator transaction binding the contract method 0x1896f70a.
+//
+// Solidity: function setResolver(bytes32 node, address resolver) returns()
+func (_L1ENSRegistry *L1ENSRegistryTransactor) SetResolver(opts *bind.TransactOpts, node [32]byte, resolver common.Address) (*types.Transaction, error) {
+	return _L1ENSRegistry.contract.Transact(opts, "setResolver", node, resolver)
+}
+
+// SetResolver is a paid mutator transaction binding the contract method 0x1896f70a.
+//
+// Solidity: function setResolver(bytes32 node, address resolver) returns()
+func (_L1ENSRegistry *L1ENSRegistrySession) SetResolver(node [32]byte, resolver common.Address) (*types.Transaction, error) {
+	return _L1ENSRegistry.Contract.SetResolver(&_L1ENSRegistry.TransactOpts, node, resolver)
+}
+
+// SetResolver is a paid mutator transaction binding the contract method 0x1896f70a.
+//
+// Solidity: function setResolver(bytes32 node, address resolver) returns()
+func (_L1ENSRegistry *L1ENSRegistryTransactorSession) SetResolver(node [32]byte, resolver common.Address) (*types.Transaction, error) {
+	return _L1ENSRegistry.Contract.SetResolver(&_L1ENSRegistry.TransactOpts, node, resolver)
+}
+
+// SetSubnodeOwner is a paid mutator transaction binding the contract method 0x06ab5923.
+//
+// Solidity: function setSubnodeOwner(bytes32 node, bytes32 label, address owner) returns(bytes32)
+func (_L1ENSRegistry *L1ENSRegistryTransactor) SetSubnodeOwner(opts *bind.TransactOpts, node [32]byte, label [32]byte, owner common.Address) (*types.Transaction, error) {
+	return _L1ENSRegistry.contract.Transact(opts, "setSubnodeOwner", node, label, owner)
+}
+
+// SetSubnodeOwner is a paid mutator transaction binding the contract method 0x06ab5923.
+//
+// Solidity: function setSubnodeOwner(bytes32 node, bytes32 label, address owner) returns(bytes32)
+func (_L1ENSRegistry *L1ENSRegistrySession) SetSubnodeOwner(node [32]byte, label [32]byte, owner common.Address) (*types.Transaction, error) {
+	return _L1ENSRegistry.Contract.SetSubnodeOwner(&_L1ENSRegistry.TransactOpts, node, label, owner)
+}
+
+// SetSubnodeOwner is a paid mutator transaction binding the contract method 0x06ab5923.
+//
+// Solidity: function setSubnodeOwner(bytes32 node, bytes32 label, address owner) returns(bytes32)
+func (_L1ENSRegistry *L1ENSRegistryTransactorSession) SetSubnodeOwner(node [32]byte, label [32]byte, owner common.Address) (*types.Transaction, error) {
+	return _L1ENSRegistry.Contract.SetSubnodeOwner(&_L1ENSRegistry.TransactOpts, node, label, owner)
+}
+
+// SetSubnodeRecord is a paid mutator transaction binding the contract method 0x5ef2c7f0.
+//
+// Solidity: function setSubnodeRecord(bytes32 node, bytes32 label, address owner, address resolver, uint64 ttl) returns()
+func (_L1ENSRegistry *L1ENSRegistryTransactor) SetSubnodeRecord(opts *bind.TransactOpts, node [32]byte, label [32]byte, owner common.Address, resolver common.Address, ttl uint64) (*types.Transaction, error) {
+	return _L1ENSRegistry.contract.Transact(opts, "setSubnodeRecord", node, label, owner, resolver, ttl)
+}
+
+// SetSubnodeRecord is a paid mutator transaction binding the contract method 0x5ef2c7f0.
+//
+// Solidity: function setSubnodeRecord(bytes32 node, bytes32 label, address owner, address resolver, uint64 ttl) returns()
+func (_L1ENSRegistry *L1ENSRegistrySession) SetSubnodeRecord(node [32]byte, label [32]byte, owner common.Address, resolver common.Address, ttl uint64) (*types.Transaction, error) {
+	return _L1ENSRegistry.Contract.SetSubnodeRecord(&_L1ENSRegistry.TransactOpts, node, label, owner, resolver, ttl)
+}
+
+// SetSubnodeRecord is a paid mutator transaction binding the contract method 0x5ef2c7f0.
+//
+// Solidity: function setSubnodeRecord(bytes32 node, bytes32 label, address owner, address resolver, uint64 ttl) returns()
+func (_L1ENSRegistry *L1ENSRegistryTransactorSession) SetSubnodeRecord(node [32]byte, label [32]byte, owner common.Address, resolver common.Address, ttl uint64) (*types.Transaction, error) {
+	return _L1ENSRegistry.Contract.SetSubnodeRecord(&_L1ENSRegistry.TransactOpts, node, label, owner, resolver, ttl)
+}
+
+// SetTTL is a paid mutator transaction binding the contract method 0x14ab9038.
+//
+// Solidity: function setTTL(bytes32 node, uint64 ttl) returns()
+func (_L1ENSRegistry *L1ENSRegistryTransactor) SetTTL(opts *bind.TransactOpts, node [32]byte, ttl uint64) (*types.Transaction, error) {
+	return _L1ENSRegistry.contract.Transact(opts, "setTTL", node, ttl)
+}
+
+// SetTTL is a paid mutator transaction binding the contract method 0x14ab9038.
+//
+// Solidity: function setTTL(bytes32 node, uint64 ttl) returns()
+func (_L1ENSRegistry *L1ENSRegistrySession) SetTTL(node [32]byte, ttl uint64) (*types.Transaction, error) {
+	return _L1ENSRegistry.Contract.SetTTL(&_L1ENSRegistry.TransactOpts, node, ttl)
+}
+
+// SetTTL is a paid mutator transaction binding the contract method 0x14ab9038.
+//
+// Solidity: function setTTL(bytes32 node, uint64 ttl) returns()
+func (_L1ENSRegistry *L1ENSRegistryTransactorSession) SetTTL(node [32]byte, ttl uint64) (*types.Transaction, error) {
+	return _L1ENSRegistry.Contract.SetTTL(&_L1ENSRegistry.TransactOpts, node, ttl)
+}
+
+// L1ENSRegistryApprovalForAllIterator is returned from FilterApprovalForAll and is used to iterate over the raw logs and unpacked data for ApprovalForAll events raised by the L1ENSRegistry contract.
+type L1ENSRegistryApprovalForAllIterator struct {
+	Event *L1ENSRegistryApprovalForAll // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *L1ENSRegistryApprovalForAllIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(L1ENSRegistryApprovalForAll)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(L1ENSRegistryApprovalForAll)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *L1ENSRegistryApprovalForAllIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *L1ENSRegistryApprovalForAllIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// L1ENSRegistryApprovalForAll represents a ApprovalForAll event raised by the L1ENSRegistry contract.
+type L1ENSRegistryApprovalForAll struct {
+	Owner    common.Address
+	Operator common.Address
+	Approved bool
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterApprovalForAll is a free log retrieval operation binding the contract event 0x17307eab39ab6107e8899845ad3d59bd9653f200f220920489ca2b5937696c31.
+//
+// Solidity: event ApprovalForAll(address indexed owner, address indexed operator, bool approved)
+func (_L1ENSRegistry *L1ENSRegistryFilterer) FilterApprovalForAll(opts *bind.FilterOpts, owner []common.Address, operator []common.Address) (*L1ENSRegistryApprovalForAllIterator, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+
+	logs, sub, err := _L1ENSRegistry.contract.FilterLogs(opts, "ApprovalForAll", ownerRule, operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return &L1ENSRegistryApprovalForAllIterator{contract: _L1ENSRegistry.contract, event: "ApprovalForAll", logs: logs, sub: sub}, nil
+}
+
+// WatchApprovalForAll is a free log subscription operation binding the contract event 0x17307eab39ab6107e8899845ad3d59bd9653f200f220920489ca2b5937696c31.
+//
+// Solidity: event ApprovalForAll(address indexed owner, address indexed operator, bool approved)
+func (_L1ENSRegistry *L1ENSRegistryFilterer) WatchApprovalForAll(opts *bind.WatchOpts, sink chan<- *L1ENSRegistryApprovalForAll, owner []common.Address, operator []common.Address) (event.Subscription, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+
+	logs, sub, err := _L1ENSRegistry.contract.WatchLogs(opts, "ApprovalForAll", ownerRule, operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(L1ENSRegistryApprovalForAll)
+				if err := _L1ENSRegistry.contract.UnpackLog(event, "ApprovalForAll", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseApprovalForAll is a log parse operation binding the contract event 0x17307eab39ab6107e8899845ad3d59bd9653f200f220920489ca2b5937696c31.
+//
+// Solidity: event ApprovalForAll(address indexed owner, address indexed operator, bool approved)
+func (_L1ENSRegistry *L1ENSRegistryFilterer) ParseApprovalForAll(log types.Log) (*L1ENSRegistryApprovalForAll, error) {
+	event := new(L1ENSRegistryApprovalForAll)
+	if err := _L1ENSRegistry.contract.UnpackLog(event, "ApprovalForAll", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// L1ENSRegistryNewOwnerIterator is returned from FilterNewOwner and is used to iterate over the raw logs and unpacked data for NewOwner events raised by the L1ENSRegistry contract.
+type L1ENSRegistryNewOwnerIterator struct {
+	Event *L1ENSRegistryNewOwner // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *L1ENSRegistryNewOwnerIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(L1ENSRegistryNewOwner)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(L1ENSRegistryNewOwner)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *L1ENSRegistryNewOwnerIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *L1ENSRegistryNewOwnerIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// L1ENSRegistryNewOwner represents a NewOwner event raised by the L1ENSRegistry contract.
+type L1ENSRegistryNewOwner struct {
+	Node  [32]byte
+	Label [32]byte
+	Owner common.Address
+	Raw   types.Log // Blockchain specific contextual infos
+}
+
+// FilterNewOwner is a free log retrieval operation binding the contract event 0xce0457fe73731f824cc272376169235128c118b49d344817417c6d108d155e82.
+//
+// Solidity: event NewOwner(bytes32 indexed node, bytes32 indexed label, address owner)
+func (_L1ENSRegistry *L1ENSRegistryFilterer) FilterNewOwner(opts *bind.FilterOpts, node [][32]byte, label [][32]byte) (*L1ENSRegistryNewOwnerIterator, error) {
+
+	var nodeRule []interface{}
+	for _, nodeItem := range node {
+		nodeRule = append(nodeRule, nodeItem)
+	}
+	var labelRule []interface{}
+	for _, labelItem := range label {
+		labelRule = append(labelRule, labelItem)
+	}
+
+	logs, sub, err := _L1ENSRegistry.contract.FilterLogs(opts, "NewOwner", nodeRule, labelRule)
+	if err != nil {
+		return nil, err
+	}
+	return &L1ENSRegistryNewOwnerIterator{contract: _L1ENSRegistry.contract, event: "NewOwner", logs: logs, sub: sub}, nil
+}
+
+// WatchNewOwner is a free log subscription operation binding the contract event 0xce0457fe73731f824cc272376169235128c118b49d344817417c6d108d155e82.
+//
+// Solidity: event NewOwner(bytes32 indexed node, bytes32 indexed label, address owner)
+func (_L1ENSRegistry *L1ENSRegistryFilterer) WatchNewOwner(opts *bind.WatchOpts, sink chan<- *L1ENSRegistryNewOwner, node [][32]byte, label [][32]byte) (event.Subscription, error) {
+
+	var nodeRule []interface{}
+	for _, nodeItem := range node {
+		nodeRule = append(nodeRule, nodeItem)
+	}
+	var labelRule []interface{}
+	for _, labelItem := range label {
+		labelRule = append(labelRule, labelItem)
+	}
+
+	logs, sub, err := _L1ENSRegistry.contract.WatchLogs(opts, "NewOwner", nodeRule, labelRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(L1ENSRegistryNewOwner)
+				if err := _L1ENSRegistry.contract.UnpackLog(event, "NewOwner", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseNewOwner is a log parse operation binding the contract event 0xce0457fe73731f824cc272376169235128c118b49d344817417c6d108d155e82.
+//
+// Solidity: event NewOwner(bytes32 indexed node, bytes32 indexed label, address owner)
+func (_L1ENSRegistry *L1ENSRegistryFilterer) ParseNewOwner(log types.Log) (*L1ENSRegistryNewOwner, error) {
+	event := new(L1ENSRegistryNewOwner)
+	if err := _L1ENSRegistry.contract.UnpackLog(event, "NewOwner", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// L1ENSRegistryNewResolverIterator is returned from FilterNewResolver and is used to iterate over the raw logs and unpacked data for NewResolver events raised by the L1ENSRegistry contract.
+type L1ENSRegistryNewResolverIterator struct {
+	Event *L1ENSRegistryNewResolver // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *L1ENSRegistryNewResolverIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(L1ENSRegistryNewResolver)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(L1ENSRegistryNewResolver)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *L1ENSRegistryNewResolverIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *L1ENSRegistryNewResolverIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// L1ENSRegistryNewResolver represents a NewResolver event raised by the L1ENSRegistry contract.
+type L1ENSRegistryNewResolver struct {
+	Node     [32]byte
+	Resolver common.Address
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterNewResolver is a free log retrieval operation binding the contract event 0x335721b01866dc23fbee8b6b2c7b1e14d6f05c28cd35a2c934239f94095602a0.
+//
+// Solidity: event NewResolver(bytes32 indexed node, address resolver)
+func (_L1ENSRegistry *L1ENSRegistryFilterer) FilterNewResolver(opts *bind.FilterOpts, node [][32]byte) (*L1ENSRegistryNewResolverIterator, error) {
+
+	var nodeRule []interface{}
+	for _, nodeItem := range node {
+		nodeRule = append(nodeRule, nodeItem)
+	}
+
+	logs, sub, err := _L1ENSRegistry.contract.FilterLogs(opts, "NewResolver", nodeRule)
+	if err != nil {
+		return nil, err
+	}
+	return &L1ENSRegistryNewResolverIterator{contract: _L1ENSRegistry.contract, event: "NewResolver", logs: logs, sub: sub}, nil
+}
+
+// WatchNewResolver is a free log subscription operation binding the contract event 0x335721b01866dc23fbee8b6b2c7b1e14d6f05c28cd35a2c934239f94095602a0.
+//
+// Solidity: event NewResolver(bytes32 indexed node, address resolver)
+func (_L1ENSRegistry *L1ENSRegistryFilterer) WatchNewResolver(opts *bind.WatchOpts, sink chan<- *L1ENSRegistryNewResolver, node [][32]byte) (event.Subscription, error) {
+
+	var nodeRule []interface{}
+	for _, nodeItem := range node {
+		nodeRule = append(nodeRule, nodeItem)
+	}
+
+	logs, sub, err := _L1ENSRegistry.contract.WatchLogs(opts, "NewResolver", nodeRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(L1ENSRegistryNewResolver)
+				if err := _L1ENSRegistry.contract.UnpackLog(event, "NewResolver", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseNewResolver is a log parse operation binding the contract event 0x335721b01866dc23fbee8b6b2c7b1e14d6f05c28cd35a2c934239f94095602a0.
+//
+// Solidity: event NewResolver(bytes32 indexed node, address resolver)
+func (_L1ENSRegistry *L1ENSRegistryFilterer) ParseNewResolver(log types.Log) (*L1ENSRegistryNewResolver, error) {
+	event := new(L1ENSRegistryNewResolver)
+	if err := _L1ENSRegistry.contract.UnpackLog(event, "NewResolver", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// L1ENSRegistryNewTTLIterator is returned from FilterNewTTL and is used to iterate over the raw logs and unpacked data for NewTTL events raised by the L1ENSRegistry contract.
+type L1ENSRegistryNewTTLIterator struct {
+	Event *L1ENSRegistryNewTTL // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *L1ENSRegistryNewTTLIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(L1ENSRegistryNewTTL)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(L1ENSRegistryNewTTL)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *L1ENSRegistryNewTTLIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *L1ENSRegistryNewTTLIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// L1ENSRegistryNewTTL represents a NewTTL event raised by the L1ENSRegistry contract.
+type L1ENSRegistryNewTTL struct {
+	Node [32]byte
+	Ttl  uint64
+	Raw  types.Log // Blockchain specific contextual infos
+}
+
+// FilterNewTTL is a free log retrieval operation binding the contract event 0x1d4f9bbfc9cab89d66e1a1562f2233ccbf1308cb4f63de2ead5787adddb8fa68.
+//
+// Solidity: event NewTTL(bytes32 indexed node, uint64 ttl)
+func (_L1ENSRegistry *L1ENSRegistryFilterer) FilterNewTTL(opts *bind.FilterOpts, node [][32]byte) (*L1ENSRegistryNewTTLIterator, error) {
+
+	var nodeRule []interface{}
+	for _, nodeItem := range node {
+		nodeRule = append(nodeRule, nodeItem)
+	}
+
+	logs, sub, err := _L1ENSRegistry.contract.FilterLogs(opts, "NewTTL", nodeRule)
+	if err != nil {
+		return nil, err
+	}
+	return &L1ENSRegistryNewTTLIterator{contract: _L1ENSRegistry.contract, event: "NewTTL", logs: logs, sub: sub}, nil
+}
+
+// WatchNewTTL is a free log subscription operation binding the contract event 0x1d4f9bbfc9cab89d66e1a1562f2233ccbf1308cb4f63de2ead5787adddb8fa68.
+//
+// Solidity: event NewTTL(bytes32 indexed node, uint64 ttl)
+func (_L1ENSRegistry *L1ENSRegistryFilterer) WatchNewTTL(opts *bind.WatchOpts, sink chan<- *L1ENSRegistryNewTTL, node [][32]byte) (event.Subscription, error) {
+
+	var nodeRule []interface{}
+	for _, nodeItem := range node {
+		nodeRule = append(nodeRule, nodeItem)
+	}
+
+	logs, sub, err := _L1ENSRegistry.contract.WatchLogs(opts, "NewTTL", nodeRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(L1ENSRegistryNewTTL)
+				if err := _L1ENSRegistry.contract.UnpackLog(event, "NewTTL", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseNewTTL is a log parse operation binding the contract event 0x1d4f9bbfc9cab89d66e1a1562f2233ccbf1308cb4f63de2ead5787adddb8fa68.
+//
+// Solidity: event NewTTL(bytes32 indexed node, uint64 ttl)
+func (_L1ENSRegistry *L1ENSRegistryFilterer) ParseNewTTL(log types.Log) (*L1ENSRegistryNewTTL, error) {
+	event := new(L1ENSRegistryNewTTL)
+	if err := _L1ENSRegistry.contract.UnpackLog(event, "NewTTL", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// L1ENSRegistryTransferIterator is returned from FilterTransfer and is used to iterate over the raw logs and unpacked data for Transfer events raised by the L1ENSRegistry contract.
+type L1ENSRegistryTransferIterator struct {
+	Event *L1ENSRegistryTransfer // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *L1ENSRegistryTransferIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(L1ENSRegistryTransfer)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(L1ENSRegistryTransfer)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *L1ENSRegistryTransferIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *L1ENSRegistryTransferIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// L1ENSRegistryTransfer represents a Transfer event raised by the L1ENSRegistry contract.
+type L1ENSRegistryTransfer struct {
+	Node  [32]byte
+	Owner common.Address
+	Raw   types.Log // Blockchain specific contextual infos
+}
+
+// FilterTransfer is a free log retrieval operation binding the contract event 0xd4735d920b0f87494915f556dd9b54c8f309026070caea5c737245152564d266.
+//
+// Solidity: event Transfer(bytes32 indexed node, address owner)
+func (_L1ENSRegistry *L1ENSRegistryFilterer) FilterTransfer(opts *bind.FilterOpts, node [][32]byte) (*L1ENSRegistryTransferIterator, error) {
+
+	var nodeRule []interface{}
+	for _, nodeItem := range node {
+		nodeRule = append(nodeRule, nodeItem)
+	}
+
+	logs, sub, err := _L1ENSRegistry.contract.FilterLogs(opts, "Transfer", nodeRule)
+	if err != nil {
+		return nil, err
+	}
+	return &L1ENSRegistryTransferIterator{contract: _L1ENSRegistry.contract, event: "Transfer", logs: logs, sub: sub}, nil
+}
+
+// WatchTransfer is a free log subscription operation binding the contract event 0xd4735d920b0f87494915f556dd9b54c8f309026070caea5c737245152564d266.
+//
+// Solidity: event Transfer(bytes32 indexed node, address owner)
+func (_L1ENSRegistry *L1ENSRegistryFilterer) WatchTransfer(opts *bind.WatchOpts, sink chan<- *L1ENSRegistryTransfer, node [][32]byte) (event.Subscription, error) {
+
+	var nodeRule []interface{}
+	for _, nodeItem := range node {
+		nodeRule = append(nodeRule, nodeItem)
+	}
+
+	logs, sub, err := _L1ENSRegistry.contract.WatchLogs(opts, "Transfer", nodeRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(L1ENSRegistryTransfer)
+				if err := _L1ENSRegistry.contract.UnpackLog(event, "Transfer", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseTransfer is a log parse operation binding the contract event 0xd4735d920b0f87494915f556dd9b54c8f309026070caea5c737245152564d266.
+//
+// Solidity: event Transfer(bytes32 indexed node, address owner)
+func (_L1ENSRegistry *L1ENSRegistryFilterer) ParseTransfer(log types.Log) (*L1ENSRegistryTransfer, error) {
+	event := new(L1ENSRegistryTransfer)
+	if err := _L1ENSRegistry.contract.UnpackLog(event, "Transfer", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,66 @@
+package bindings
+
+import (
+	"bytes"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// packError packs the arguments of a registry custom error behind the
+// selector listed in L1ENSRegistryErrorSelectors.
+func packError(t *testing.T, name, signature string, args ...interface{}) []byte {
+	t.Helper()
+	data, err := l1ENSRegistryParsedABI.Errors[name].Inputs.Pack(args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	selector, ok := L1ENSRegistryErrorSelectors[signature]
+	if !ok {
+		t.Fatalf("no selector for %s", signature)
+	}
+	return append(selector[:], data...)
+}
+
+func TestUnpackL1ENSRegistryOffchainLookup(t *testing.T) {
+	want := L1ENSRegistryOffchainLookup{
+		Sender:           common.HexToAddress("0x1111111111111111111111111111111111111111"),
+		Urls:             []string{"https://gateway.example/{sender}/{data}.json"},
+		CallData:         []byte{0x02, 0x57, 0x1b, 0xe3, 0x01},
+		CallbackFunction: [4]byte{0xde, 0xad, 0xbe, 0xef},
+		ExtraData:        []byte{0x42},
+	}
+	data := packError(t, "OffchainLookup", "OffchainLookup(address,string[],bytes,bytes4,bytes)",
+		want.Sender, want.Urls, want.CallData, want.CallbackFunction, want.ExtraData)
+	got, err := UnpackL1ENSRegistryOffchainLookup(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	if _, err := UnpackL1ENSRegistryOffchainLookup(data[:len(data)-32]); err == nil {
+		t.Error("unpacked truncated revert data")
+	}
+}
+
+func TestUnpackL1ENSRegistryStorageHandledByL2(t *testing.T) {
+	chainID, registry := big.NewInt(420), common.HexToAddress("0x2222222222222222222222222222222222222222")
+	data := packError(t, "StorageHandledByL2", "StorageHandledByL2(uint256,address)", chainID, registry)
+	got, err := UnpackL1ENSRegistryStorageHandledByL2(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ChainId.Cmp(chainID) != 0 || got.ContractAddress != registry {
+		t.Errorf("got %+v, want chain %s and registry %s", got, chainID, registry)
+	}
+	// Each unpacker only accepts its own error.
+	if _, err := UnpackL1ENSRegistryOffchainLookup(data); err == nil {
+		t.Error("unpacked StorageHandledByL2 as OffchainLookup")
+	}
+	if _, err := UnpackL1ENSRegistryStorageHandledByL2(bytes.Repeat([]byte{0}, 3)); err == nil {
+		t.Error("unpacked revert data shorter than a selector")
+	}
+}
@@ -0,0 +1,1464 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package bindings
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// L2ENSRegistryMetaData contains all meta data concerning the L2ENSRegistry contract.
+var L2ENSRegistryMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_trustedForwarder\",\"type\":\"address\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\",\"indexed\":true},{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\",\"indexed\":true},{\"internalType\":\"bool\",\"name\":\"approved\",\"type\":\"bool\",\"indexed\":false}],\"name\":\"ApprovalForAll\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"node\",\"type\":\"bytes32\",\"indexed\":true},{\"internalType\":\"bytes32\",\"name\":\"label\",\"type\":\"bytes32\",\"indexed\":true},{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\",\"indexed\":false}],\"name\":\"NewOwner\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"node\",\"type\":\"bytes32\",\"indexed\":true},{\"internalType\":\"address\",\"name\":\"resolver\",\"type\":\"address\",\"indexed\":false}],\"name\":\"NewResolver\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"node\",\"type\":\"bytes32\",\"indexed\":true},{\"internalType\":\"uint64\",\"name\":\"ttl\",\"type\":\"uint64\",\"indexed\":false}],\"name\":\"NewTTL\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"node\",\"type\":\"bytes32\",\"indexed\":true},{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\",\"indexed\":false}],\"name\":\"Transfer\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\",\"indexed\":false}],\"name\":\"lbs_r\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_node\",\"type\":\"bytes32\"}],\"name\":\"getRecordSLO\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_pos\",\"type\":\"bytes32\"}],\"name\":\"getSLO\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"data_\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_owner\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_operator\",\"type\":\"address\"}],\"name\":\"isApprovedForAll\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"forwarder\",\"type\":\"address\"}],\"name\":\"isTrustedForwarder\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_node\",\"type\":\"bytes32\"}],\"name\":\"owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_node\",\"type\":\"bytes32\"}],\"name\":\"recordExists\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_node\",\"type\":\"bytes32\"}],\"name\":\"resolver\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_operator\",\"type\":\"address\"},{\"internalType\":\"bool\",\"name\":\"_approved\",\"type\":\"bool\"}],\"name\":\"setApprovalForAll\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_node\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"_owner\",\"type\":\"address\"}],\"name\":\"setOwner\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_node\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"_owner\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_resolver\",\"type\":\"address\"},{\"internalType\":\"uint64\",\"name\":\"_ttl\",\"type\":\"uint64\"}],\"name\":\"setRecord\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_node\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"_resolver\",\"type\":\"address\"}],\"name\":\"setResolver\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_node\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"_label\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"_owner\",\"type\":\"address\"}],\"name\":\"setSubnodeOwner\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_node\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"_label\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"_owner\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_resolver\",\"type\":\"address\"},{\"internalType\":\"uint64\",\"name\":\"_ttl\",\"type\":\"uint64\"}],\"name\":\"setSubnodeRecord\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_node\",\"type\":\"bytes32\"},{\"internalType\":\"uint64\",\"name\":\"_ttl\",\"type\":\"uint64\"}],\"name\":\"setTTL\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_node\",\"type\":\"bytes32\"}],\"name\":\"ttl\",\"outputs\":[{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+	Bin: "0x60a06040523480156200001157600080fd5b50604051620015fa380380620015fa833981810160405281019062000037919062000135565b808073ffffffffffffffffffffffffffffffffffffffff1660808173ffffffffffffffffffffffffffffffffffffffff168152505050336000808060001b815260200190815260200160002060000160006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff1602179055505062000167565b600080fd5b600073ffffffffffffffffffffffffffffffffffffffff82169050919050565b6000620000fd82620000d0565b9050919050565b6200010f81620000f0565b81146200011b57600080fd5b50565b6000815190506200012f8162000104565b92915050565b6000602082840312156200014e576200014d620000cb565b5b60006200015e848285016200011e565b91505092915050565b60805161147762000183600039600061091301526114776000f3fe608060405234801561001057600080fd5b50600436106100f55760003560e01c8063572b6c0511610097578063a22cb46511610066578063a22cb465146102ba578063cf408823146102d6578063e985e9c5146102f2578063f79fe53814610322576100f5565b8063572b6c05146102225780635b0fc9c3146102525780635ef2c7f01461026e578063621a9c021461028a576100f5565b806314ab9038116100d357806314ab90381461018a57806316a25cbd146101a65780631896f70a146101d657806334fd0150146101f2576100f5565b80630178b8bf146100fa57806302571be31461012a57806306ab59231461015a575b600080fd5b610114600480360381019061010f9190610fe7565b610352565b6040516101219190611055565b60405180910390f35b610144600480360381019061013f9190610fe7565b610391565b6040516101519190611055565b60405180910390f35b610174600480360381019061016f919061109c565b610413565b60405161018191906110fe565b60405180910390f35b6101a4600480360381019061019f9190611159565b6105a3565b005b6101c060048036038101906101bb9190610fe7565b61072e565b6040516101cd91906111a8565b60405180910390f35b6101f060048036038101906101eb91906111c3565b610761565b005b61020c60048036038101906102079190610fe7565b610904565b60405161021991906110fe565b60405180910390f35b61023c60048036038101906102379190611203565b61090f565b604051610249919061124b565b60405180910390f35b61026c600480360381019061026791906111c3565b610967565b005b61028860048036038101906102839190611266565b610ac0565b005b6102a4600480360381019061029f9190610fe7565b610ae2565b6040516102b191906110fe565b60405180910390f35b6102d460048036038101906102cf919061130d565b610b3f565b005b6102f060048036038101906102eb919061134d565b610c4a565b005b61030c600480360381019061030791906113b4565b610c65565b604051610319919061124b565b60405180910390f35b61033c60048036038101906103379190610fe7565b610cf9565b604051610349919061124b565b60405180910390f35b600080600083815260200190815260200160002060010160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff169050919050565b60008060008084815260200190815260200160002060000160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1690503073ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff160361040957600091505061040e565b809150505b919050565b600083600080600083815260200190815260200160002060000160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff169050600061045a610d67565b90508073ffffffffffffffffffffffffffffffffffffffff168273ffffffffffffffffffffffffffffffffffffffff16148061051c5750600160008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060008273ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060009054906101000a900460ff165b61052557600080fd5b6000878760405160200161053a929190611415565b60405160208183030381529060405280519060200120905061055c8187610d99565b86887fce0457fe73731f824cc272376169235128c118b49d344817417c6d108d155e828860405161058d9190611055565b60405180910390a3809450505050509392505050565b81600080600083815260200190815260200160002060000160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff16905060006105e8610d67565b90508073ffffffffffffffffffffffffffffffffffffffff168273ffffffffffffffffffffffffffffffffffffffff1614806106aa5750600160008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060008273ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060009054906101000a900460ff165b6106b357600080fd5b847f1d4f9bbfc9cab89d66e1a1562f2233ccbf1308cb4f63de2ead5787adddb8fa68856040516106e391906111a8565b60405180910390a28360008087815260200190815260200160002060010160146101000a81548167ffffffffffffffff021916908367ffffffffffffffff1602179055505050505050565b600080600083815260200190815260200160002060010160149054906101000a900467ffffffffffffffff169050919050565b81600080600083815260200190815260200160002060000160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff16905060006107a6610d67565b90508073ffffffffffffffffffffffffffffffffffffffff168273ffffffffffffffffffffffffffffffffffffffff1614806108685750600160008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060008273ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060009054906101000a900460ff165b61087157600080fd5b847f335721b01866dc23fbee8b6b2c7b1e14d6f05c28cd35a2c934239f94095602a0856040516108a19190611055565b60405180910390a28360008087815260200190815260200160002060010160006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff1602179055505050505050565b600081549050919050565b60007f000000000000000000000000000000000000000000000000000000000000000073ffffffffffffffffffffffffffffffffffffffff168273ffffffffffffffffffffffffffffffffffffffff16149050919050565b81600080600083815260200190815260200160002060000160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff16905060006109ac610d67565b90508073ffffffffffffffffffffffffffffffffffffffff168273ffffffffffffffffffffffffffffffffffffffff161480610a6e5750600160008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060008273ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060009054906101000a900460ff165b610a7757600080fd5b610a818585610d99565b847fd4735d920b0f87494915f556dd9b54c8f309026070caea5c737245152564d26685604051610ab19190611055565b60405180910390a25050505050565b6000610acd868686610413565b9050610ada818484610df1565b505050505050565b600080600080848152602001908152602001600020905060008190507ffd6ff40b02c930b08bee5adeea4eca57778395e6d680f4ed4dfc9d308dce1f8181604051610b2d91906110fe565b60405180910390a18092505050919050565b8060016000610b4c610d67565b73ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060008473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060006101000a81548160ff0219169083151502179055508173ffffffffffffffffffffffffffffffffffffffff16610bf9610d67565b73ffffffffffffffffffffffffffffffffffffffff167f17307eab39ab6107e8899845ad3d59bd9653f200f220920489ca2b5937696c3183604051610c3e919061124b565b60405180910390a35050565b610c548484610967565b610c5f848383610df1565b50505050565b6000600160008473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060009054906101000a900460ff16905092915050565b60008073ffffffffffffffffffffffffffffffffffffffff1660008084815260200190815260200160002060000160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1614159050919050565b6000610d723361090f565b15610d8657601436033560601c9050610d95565b610d8e610fa4565b9050610d96565b5b90565b8060008084815260200190815260200160002060000160006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff1602179055505050565b60008084815260200190815260200160002060010160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168273ffffffffffffffffffffffffffffffffffffffff1614610ee6578160008085815260200190815260200160002060010160006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff160217905550827f335721b01866dc23fbee8b6b2c7b1e14d6f05c28cd35a2c934239f94095602a083604051610edd9190611055565b60405180910390a25b60008084815260200190815260200160002060010160149054906101000a900467ffffffffffffffff1667ffffffffffffffff168167ffffffffffffffff1614610f9f578060008085815260200190815260200160002060010160146101000a81548167ffffffffffffffff021916908367ffffffffffffffff160217905550827f1d4f9bbfc9cab89d66e1a1562f2233ccbf1308cb4f63de2ead5787adddb8fa6882604051610f9691906111a8565b60405180910390a25b505050565b600033905090565b600080fd5b6000819050919050565b610fc481610fb1565b8114610fcf57600080fd5b50565b600081359050610fe181610fbb565b92915050565b600060208284031215610ffd57610ffc610fac565b5b600061100b84828501610fd2565b91505092915050565b600073ffffffffffffffffffffffffffffffffffffffff82169050919050565b600061103f82611014565b9050919050565b61104f81611034565b82525050565b600060208201905061106a6000830184611046565b92915050565b61107981611034565b811461108457600080fd5b50565b60008135905061109681611070565b92915050565b6000806000606084860312156110b5576110b4610fac565b5b60006110c386828701610fd2565b93505060206110d486828701610fd2565b92505060406110e586828701611087565b9150509250925092565b6110f881610fb1565b82525050565b600060208201905061111360008301846110ef565b92915050565b600067ffffffffffffffff82169050919050565b61113681611119565b811461114157600080fd5b50565b6000813590506111538161112d565b92915050565b600080604083850312156111705761116f610fac565b5b600061117e85828601610fd2565b925050602061118f85828601611144565b9150509250929050565b6111a281611119565b82525050565b60006020820190506111bd6000830184611199565b92915050565b600080604083850312156111da576111d9610fac565b5b60006111e885828601610fd2565b92505060206111f985828601611087565b9150509250929050565b60006020828403121561121957611218610fac565b5b600061122784828501611087565b91505092915050565b60008115159050919050565b61124581611230565b82525050565b6000602082019050611260600083018461123c565b92915050565b600080600080600060a0868803121561128257611281610fac565b5b600061129088828901610fd2565b95505060206112a188828901610fd2565b94505060406112b288828901611087565b93505060606112c388828901611087565b92505060806112d488828901611144565b9150509295509295909350565b6112ea81611230565b81146112f557600080fd5b50565b600081359050611307816112e1565b92915050565b6000806040838503121561132457611323610fac565b5b600061133285828601611087565b9250506020611343858286016112f8565b9150509250929050565b6000806000806080858703121561136757611366610fac565b5b600061137587828801610fd2565b945050602061138687828801611087565b935050604061139787828801611087565b92505060606113a887828801611144565b91505092959194509250565b600080604083850312156113cb576113ca610fac565b5b60006113d985828601611087565b92505060206113ea85828601611087565b9150509250929050565b6000819050919050565b61140f61140a82610fb1565b6113f4565b82525050565b600061142182856113fe565b60208201915061143182846113fe565b602082019150819050939250505056fea26469706673582212208fc525adb1d5e0e6c2c98286d543619cb54e62aa1263329ada7dab2bb4b64d9164736f6c63430008150033",
+}
+
+// L2ENSRegistryABI is the input ABI used to generate the binding from.
+// Deprecated: Use L2ENSRegistryMetaData.ABI instead.
+var L2ENSRegistryABI = L2ENSRegistryMetaData.ABI
+
+// L2ENSRegistryBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use L2ENSRegistryMetaData.Bin instead.
+var L2ENSRegistryBin = L2ENSRegistryMetaData.Bin
+
+// DeployL2ENSRegistry deploys a new Ethereum contract, binding an instance of L2ENSRegistry to it.
+func DeployL2ENSRegistry(auth *bind.TransactOpts, backend bind.ContractBackend, _trustedForwarder common.Address) (common.Address, *types.Transaction, *L2ENSRegistry, error) {
+	parsed, err := L2ENSRegistryMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(L2ENSRegistryBin), backend, _trustedForwarder)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &L2ENSRegistry{L2ENSRegistryCaller: L2ENSRegistryCaller{contract: contract}, L2ENSRegistryTransactor: L2ENSRegistryTransactor{contract: contract}, L2ENSRegistryFilterer: L2ENSRegistryFilterer{contract: contract}}, nil
+}
+
+// L2ENSRegistry is an auto generated Go binding around an Ethereum contract.
+type L2ENSRegistry struct {
+	L2ENSRegistryCaller     // Read-only binding to the contract
+	L2ENSRegistryTransactor // Write-only binding to the contract
+	L2ENSRegistryFilterer   // Log filterer for contract events
+}
+
+// L2ENSRegistryCaller is an auto generated read-only Go binding around an Ethereum contract.
+type L2ENSRegistryCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// L2ENSRegistryTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type L2ENSRegistryTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// L2ENSRegistryFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type L2ENSRegistryFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// L2ENSRegistrySession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type L2ENSRegistrySession struct {
+	Contract     *L2ENSRegistry    // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// L2ENSRegistryCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type L2ENSRegistryCallerSession struct {
+	Contract *L2ENSRegistryCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts        // Call options to use throughout this session
+}
+
+// L2ENSRegistryTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type L2ENSRegistryTransactorSession struct {
+	Contract     *L2ENSRegistryTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts        // Transaction auth options to use throughout this session
+}
+
+// L2ENSRegistryRaw is an auto generated low-level Go binding around an Ethereum contract.
+type L2ENSRegistryRaw struct {
+	Contract *L2ENSRegistry // Generic contract binding to access the raw methods on
+}
+
+// L2ENSRegistryCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type L2ENSRegistryCallerRaw struct {
+	Contract *L2ENSRegistryCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// L2ENSRegistryTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type L2ENSRegistryTransactorRaw struct {
+	Contract *L2ENSRegistryTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewL2ENSRegistry creates a new instance of L2ENSRegistry, bound to a specific deployed contract.
+func NewL2ENSRegistry(address common.Address, backend bind.ContractBackend) (*L2ENSRegistry, error) {
+	contract, err := bindL2ENSRegistry(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &L2ENSRegistry{L2ENSRegistryCaller: L2ENSRegistryCaller{contract: contract}, L2ENSRegistryTransactor: L2ENSRegistryTransactor{contract: contract}, L2ENSRegistryFilterer: L2ENSRegistryFilterer{contract: contract}}, nil
+}
+
+// NewL2ENSRegistryCaller creates a new read-only instance of L2ENSRegistry, bound to a specific deployed contract.
+func NewL2ENSRegistryCaller(address common.Address, caller bind.ContractCaller) (*L2ENSRegistryCaller, error) {
+	contract, err := bindL2ENSRegistry(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &L2ENSRegistryCaller{contract: contract}, nil
+}
+
+// NewL2ENSRegistryTransactor creates a new write-only instance of L2ENSRegistry, bound to a specific deployed contract.
+func NewL2ENSRegistryTransactor(address common.Address, transactor bind.ContractTransactor) (*L2ENSRegistryTransactor, error) {
+	contract, err := bindL2ENSRegistry(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &L2ENSRegistryTransactor{contract: contract}, nil
+}
+
+// NewL2ENSRegistryFilterer creates a new log filterer instance of L2ENSRegistry, bound to a specific deployed contract.
+func NewL2ENSRegistryFilterer(address common.Address, filterer bind.ContractFilterer) (*L2ENSRegistryFilterer, error) {
+	contract, err := bindL2ENSRegistry(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &L2ENSRegistryFilterer{contract: contract}, nil
+}
+
+// bindL2ENSRegistry binds a generic wrapper to an already deployed contract.
+func bindL2ENSRegistry(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(L2ENSRegistryABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_L2ENSRegistry *L2ENSRegistryRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _L2ENSRegistry.Contract.L2ENSRegistryCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_L2ENSRegistry *L2ENSRegistryRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _L2ENSRegistry.Contract.L2ENSRegistryTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_L2ENSRegistry *L2ENSRegistryRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _L2ENSRegistry.Contract.L2ENSRegistryTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_L2ENSRegistry *L2ENSRegistryCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _L2ENSRegistry.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_L2ENSRegistry *L2ENSRegistryTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _L2ENSRegistry.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_L2ENSRegistry *L2ENSRegistryTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _L2ENSRegistry.Contract.contract.Transact(opts, method, params...)
+}
+
+// GetSLO is a free data retrieval call binding the contract method 0x34fd0150.
+//
+// Solidity: function getSLO(bytes32 _pos) view returns(bytes32 data_)
+func (_L2ENSRegistry *L2ENSRegistryCaller) GetSLO(opts *bind.CallOpts, _pos [32]byte) ([32]byte, error) {
+	var out []interface{}
+	err := _L2ENSRegistry.contract.Call(opts, &out, "getSLO", _pos)
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// GetSLO is a free data retrieval call binding the contract method 0x34fd0150.
+//
+// Solidity: function getSLO(bytes32 _pos) view returns(bytes32 data_)
+func (_L2ENSRegistry *L2ENSRegistrySession) GetSLO(_pos [32]byte) ([32]byte, error) {
+	return _L2ENSRegistry.Contract.GetSLO(&_L2ENSRegistry.CallOpts, _pos)
+}
+
+// GetSLO is a free data retrieval call binding the contract method 0x34fd0150.
+//
+// Solidity: function getSLO(bytes32 _pos) view returns(bytes32 data_)
+func (_L2ENSRegistry *L2ENSRegistryCallerSession) GetSLO(_pos [32]byte) ([32]byte, error) {
+	return _L2ENSRegistry.Contract.GetSLO(&_L2ENSRegistry.CallOpts, _pos)
+}
+
+// IsApprovedForAll is a free data retrieval call binding the contract method 0xe985e9c5.
+//
+// Solidity: function isApprovedForAll(address _owner, address _operator) view returns(bool)
+func (_L2ENSRegistry *L2ENSRegistryCaller) IsApprovedForAll(opts *bind.CallOpts, _owner common.Address, _operator common.Address) (bool, error) {
+	var out []interface{}
+	err := _L2ENSRegistry.contract.Call(opts, &out, "isApprovedForAll", _owner, _operator)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsApprovedForAll is a free data retrieval call binding the contract method 0xe985e9c5.
+//
+// Solidity: function isApprovedForAll(address _owner, address _operator) view returns(bool)
+func (_L2ENSRegistry *L2ENSRegistrySession) IsApprovedForAll(_owner common.Address, _operator common.Address) (bool, error) {
+	return _L2ENSRegistry.Contract.IsApprovedForAll(&_L2ENSRegistry.CallOpts, _owner, _operator)
+}
+
+// IsApprovedForAll is a free data retrieval call binding the contract method 0xe985e9c5.
+//
+// Solidity: function isApprovedForAll(address _owner, address _operator) view returns(bool)
+func (_L2ENSRegistry *L2ENSRegistryCallerSession) IsApprovedForAll(_owner common.Address, _operator common.Address) (bool, error) {
+	return _L2ENSRegistry.Contract.IsApprovedForAll(&_L2ENSRegistry.CallOpts, _owner, _operator)
+}
+
+// IsTrustedForwarder is a free data retrieval call binding the contract method 0x572b6c05.
+//
+// Solidity: function isTrustedForwarder(address forwarder) view returns(bool)
+func (_L2ENSRegistry *L2ENSRegistryCaller) IsTrustedForwarder(opts *bind.CallOpts, forwarder common.Address) (bool, error) {
+	var out []interface{}
+	err := _L2ENSRegistry.contract.Call(opts, &out, "isTrustedForwarder", forwarder)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsTrustedForwarder is a free data retrieval call binding the contract method 0x572b6c05.
+//
+// Solidity: function isTrustedForwarder(address forwarder) view returns(bool)
+func (_L2ENSRegistry *L2ENSRegistrySession) IsTrustedForwarder(forwarder common.Address) (bool, error) {
+	return _L2ENSRegistry.Contract.IsTrustedForwarder(&_L2ENSRegistry.CallOpts, forwarder)
+}
+
+// IsTrustedForwarder is a free data retrieval call binding the contract method 0x572b6c05.
+//
+// Solidity: function isTrustedForwarder(address forwarder) view returns(bool)
+func (_L2ENSRegistry *L2ENSRegistryCallerSession) IsTrustedForwarder(forwarder common.Address) (bool, error) {
+	return _L2ENSRegistry.Contract.IsTrustedForwarder(&_L2ENSRegistry.CallOpts, forwarder)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x02571be3.
+//
+// Solidity: function owner(bytes32 _node) view returns(address)
+func (_L2ENSRegistry *L2ENSRegistryCaller) Owner(opts *bind.CallOpts, _node [32]byte) (common.Address, error) {
+	var out []interface{}
+	err := _L2ENSRegistry.contract.Call(opts, &out, "owner", _node)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Owner is a free data retrieval call binding the contract method 0x02571be3.
+//
+// Solidity: function owner(bytes32 _node) view returns(address)
+func (_L2ENSRegistry *L2ENSRegistrySession) Owner(_node [32]byte) (common.Address, error) {
+	return _L2ENSRegistry.Contract.Owner(&_L2ENSRegistry.CallOpts, _node)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x02571be3.
+//
+// Solidity: function owner(bytes32 _node) view returns(address)
+func (_L2ENSRegistry *L2ENSRegistryCallerSession) Owner(_node [32]byte) (common.Address, error) {
+	return _L2ENSRegistry.Contract.Owner(&_L2ENSRegistry.CallOpts, _node)
+}
+
+// RecordExists is a free data retrieval call binding the contract method 0xf79fe538.
+//
+// Solidity: function recordExists(bytes32 _node) view returns(bool)
+func (_L2ENSRegistry *L2ENSRegistryCaller) RecordExists(opts *bind.CallOpts, _node [32]byte) (bool, error) {
+	var out []interface{}
+	err := _L2ENSRegistry.contract.Call(opts, &out, "recordExists", _node)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// RecordExists is a free data retrieval call binding the contract method 0xf79fe538.
+//
+// Solidity: function recordExists(bytes32 _node) view returns(bool)
+func (_L2ENSRegistry *L2ENSRegistrySession) RecordExists(_node [32]byte) (bool, error) {
+	return _L2ENSRegistry.Contract.RecordExists(&_L2ENSRegistry.CallOpts, _node)
+}
+
+// RecordExists is a free data retrieval call binding the contract method 0xf79fe538.
+//
+// Solidity: function recordExists(bytes32 _node) view returns(bool)
+func (_L2ENSRegistry *L2ENSRegistryCallerSession) RecordExists(_node [32]byte) (bool, error) {
+	return _L2ENSRegistry.Contract.RecordExists(&_L2ENSRegistry.CallOpts, _node)
+}
+
+// Resolver is a free data retrieval call binding the contract method 0x0178b8bf.
+//
+// Solidity: function resolver(bytes32 _node) view returns(address)
+func (_L2ENSRegistry *L2ENSRegistryCaller) Resolver(opts *bind.CallOpts, _node [32]byte) (common.Address, error) {
+	var out []interface{}
+	err := _L2ENSRegistry.contract.Call(opts, &out, "resolver", _node)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Resolver is a free data retrieval call binding the contract method 0x0178b8bf.
+//
+// Solidity: function resolver(bytes32 _node) view returns(address)
+func (_L2ENSRegistry *L2ENSRegistrySession) Resolver(_node [32]byte) (common.Address, error) {
+	return _L2ENSRegistry.Contract.Resolver(&_L2ENSRegistry.CallOpts, _node)
+}
+
+// Resolver is a free data retrieval call binding the contract method 0x0178b8bf.
+//
+// Solidity: function resolver(bytes32 _node) view returns(address)
+func (_L2ENSRegistry *L2ENSRegistryCallerSession) Resolver(_node [32]byte) (common.Address, error) {
+	return _L2ENSRegistry.Contract.Resolver(&_L2ENSRegistry.CallOpts, _node)
+}
+
+// Ttl is a free data retrieval call binding the contract method 0x16a25cbd.
+//
+// Solidity: function ttl(bytes32 _node) view returns(uint64)
+func (_L2ENSRegistry *L2ENSRegistryCaller) Ttl(opts *bind.CallOpts, _node [32]byte) (uint64, error) {
+	var out []interface{}
+	err := _L2ENSRegistry.contract.Call(opts, &out, "ttl", _node)
+
+	if err != nil {
+		return *new(uint64), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint64)).(*uint64)
+
+	return out0, err
+
+}
+
+// Ttl is a free data retrieval call binding the contract method 0x16a25cbd.
+//
+// Solidity: function ttl(bytes32 _node) view returns(uint64)
+func (_L2ENSRegistry *L2ENSRegistrySession) Ttl(_node [32]byte) (uint64, error) {
+	return _L2ENSRegistry.Contract.Ttl(&_L2ENSRegistry.CallOpts, _node)
+}
+
+// Ttl is a free data retrieval call binding the contract method 0x16a25cbd.
+//
+// Solidity: function ttl(bytes32 _node) view returns(uint64)
+func (_L2ENSRegistry *L2ENSRegistryCallerSession) Ttl(_node [32]byte) (uint64, error) {
+	return _L2ENSRegistry.Contract.Ttl(&_L2ENSRegistry.CallOpts, _node)
+}
+
+// GetRecordSLO is a paid mutator transaction binding the contract method 0x621a9c02.
+//
+// Solidity: function getRecordSLO(bytes32 _node) returns(bytes32)
+func (_L2ENSRegistry *L2ENSRegistryTransactor) GetRecordSLO(opts *bind.TransactOpts, _node [32]byte) (*types.Transaction, error) {
+	return _L2ENSRegistry.contract.Transact(opts, "getRecordSLO", _node)
+}
+
+// GetRecordSLO is a paid mutator transaction binding the contract method 0x621a9c02.
+//
+// Solidity: function getRecordSLO(bytes32 _node) returns(bytes32)
+func (_L2ENSRegistry *L2ENSRegistrySession) GetRecordSLO(_node [32]byte) (*types.Transaction, error) {
+	return _L2ENSRegistry.Contract.GetRecordSLO(&_L2ENSRegistry.TransactOpts, _node)
+}
+
+// GetRecordSLO is a paid mutator transaction binding the contract method 0x621a9c02.
+//
+// Solidity: function getRecordSLO(bytes32 _node) returns(bytes32)
+func (_L2ENSRegistry *L2ENSRegistryTransactorSession) GetRecordSLO(_node [32]byte) (*types.Transaction, error) {
+	return _L2ENSRegistry.Contract.GetRecordSLO(&_L2ENSRegistry.TransactOpts, _node)
+}
+
+// SetApprovalForAll is a paid mutator transaction binding the contract method 0xa22cb465.
+//
+// Solidity: function setApprovalForAll(address _operator, bool _approved) returns()
+func (_L2ENSRegistry *L2ENSRegistryTransactor) SetApprovalForAll(opts *bind.TransactOpts, _operator common.Address, _approved bool) (*types.Transaction, error) {
+	return _L2ENSRegistry.contract.Transact(opts, "setApprovalForAll", _operator, _approved)
+}
+
+// SetApprovalForAll is a paid mutator transaction binding the contract method 0xa22cb465.
+//
+// Solidity: function setApprovalForAll(address _operator, bool _approved) returns()
+func (_L2ENSRegistry *L2ENSRegistrySession) SetApprovalForAll(_operator common.Address, _approved bool) (*types.Transaction, error) {
+	return _L2ENSRegistry.Contract.SetApprovalForAll(&_L2ENSRegistry.TransactOpts, _operator, _approved)
+}
+
+// SetApprovalForAll is a paid mutator transaction binding the contract method 0xa22cb465.
+//
+// Solidity: function setApprovalForAll(address _operator, bool _approved) returns()
+func (_L2ENSRegistry *L2ENSRegistryTransactorSession) SetApprovalForAll(_operator common.Address, _approved bool) (*types.Transaction, error) {
+	return _L2ENSRegistry.Contract.SetApprovalForAll(&_L2ENSRegistry.TransactOpts, _operator, _approved)
+}
+
+// SetOwner is a paid mutator transaction binding the contract method 0x5b0fc9c3.
+//
+// Solidity: function setOwner(bytes32 _node, address _owner) returns()
+func (_L2ENSRegistry *L2ENSRegistryTransactor) SetOwner(opts *bind.TransactOpts, _node [32]byte, _owner common.Address) (*types.Transaction, error) {
+	return _L2ENSRegistry.contract.Transact(opts, "setOwner", _node, _owner)
+}
+
+// SetOwner is a paid mutator transaction binding the contract method 0x5b0fc9c3.
+//
+// Solidity: function setOwner(bytes32 _node, address _owner) returns()
+func (_L2ENSRegistry *L2ENSRegistrySession) SetOwner(_node [32]byte, _owner common.Address) (*types.Transaction, error) {
+	return _L2ENSRegistry.Contract.SetOwner(&_L2ENSRegistry.TransactOpts, _node, _owner)
+}
+
+// SetOwner is a paid mutator transaction binding the contract method 0x5b0fc9c3.
+//
+// Solidity: function setOwner(bytes32 _node, address _owner) returns()
+func (_L2ENSRegistry *L2ENSRegistryTransactorSession) SetOwner(_node [32]byte, _owner common.Address) (*types.Transaction, error) {
+	return _L2ENSRegistry.Contract.SetOwner(&_L2ENSRegistry.TransactOpts, _node, _owner)
+}
+
+// SetRecord is a paid mutator transaction binding the contract method 0xcf408823.
+//
+// Solidity: function setRecord(bytes32 _node, address _owner, address _resolver, uint64 _ttl) returns()
+func (_L2ENSRegistry *L2ENSRegistryTransactor) SetRecord(opts *bind.TransactOpts, _node [32]byte, _owner common.Address, _resolver common.Address, _ttl uint64) (*types.Transaction, error) {
+	return _L2ENSRegistry.contract.Transact(opts, "setRecord", _node, _owner, _resolver, _ttl)
+}
+
+// SetRecord is a paid mutator transaction binding the contract method 0xcf408823.
+//
+// Solidity: function setRecord(bytes32 _node, address _owner, address _resolver, uint64 _ttl) returns()
+func (_L2ENSRegistry *L2ENSRegistrySession) SetRecord(_node [32]byte, _owner common.Address, _resolver common.Address, _ttl uint64) (*types.Transaction, error) {
+	return _L2ENSRegistry.Contract.SetRecord(&_L2ENSRegistry.TransactOpts, _node, _owner, _resolver, _ttl)
+}
+
+// SetRecord is a paid mutator transaction binding the contract method 0xcf408823.
+//
+// Solidity: function setRecord(bytes32 _node, address _owner, address _resolver, uint64 _ttl) returns()
+func (_L2ENSRegistry *L2ENSRegistryTransactorSession) SetRecord(_node [32]byte, _owner common.Address, _resolver common.Address, _ttl uint64) (*types.Transaction, error) {
+	return _L2ENSRegistry.Contract.SetRecord(&_L2ENSRegistry.TransactOpts, _node, _owner, _resolver, _ttl)
+}
+
+// SetResolver is a paid mutator transaction binding the contract method 0x1896f70a.
+//
+// Solidity: function setResolver(bytes32 _node, address _resolver) returns()
+func (_L2ENSRegistry *L2ENSRegistryTransactor) SetResolver(opts *bind.TransactOpts, _node [32]byte, _resolver common.Address) (*types.Transaction, error) {
+	return _L2ENSRegistry.contract.Transact(opts, "setResolver", _node, _resolver)
+}
+
+// SetResolver is a paid mutator transaction binding the contract method 0x1896f70a.
+//
+// Solidity: function setResolver(bytes32 _node, address _resolver) returns()
+func (_L2ENSRegistry *L2ENSRegistrySession) SetResolver(_node [32]byte, _resolver common.Address) (*types.Transaction, error) {
+	return _L2ENSRegistry.Contract.SetResolver(&_L2ENSRegistry.TransactOpts, _node, _resolver)
+}
+
+// SetResolver is a paid mutator transaction binding the contract method 0x1896f70a.
+//
+// Solidity: function setResolver(bytes32 _node, address _resolver) returns()
+func (_L2ENSRegistry *L2ENSRegistryTransactorSession) SetResolver(_node [32]byte, _resolver common.Address) (*types.Transaction, error) {
+	return _L2ENSRegistry.Contract.SetResolver(&_L2ENSRegistry.TransactOpts, _node, _resolver)
+}
+
+// SetSubnodeOwner is a paid mutator transaction binding the contract method 0x06ab5923.
+//
+// Solidity: function setSubnodeOwner(bytes32 _node, bytes32 _label, address _owner) returns(bytes32)
+func (_L2ENSRegistry *L2ENSRegistryTransactor) SetSubnodeOwner(opts *bind.TransactOpts, _node [32]byte, _label [32]byte, _owner common.Address) (*types.Transaction, error) {
+	return _L2ENSRegistry.contract.Transact(opts, "setSubnodeOwner", _node, _label, _owner)
+}
+
+// SetSubnodeOwner is a paid mutator transaction binding the contract method 0x06ab5923.
+//
+// Solidity: function setSubnodeOwner(bytes32 _node, bytes32 _label, address _owner) returns(bytes32)
+func (_L2ENSRegistry *L2ENSRegistrySession) SetSubnodeOwner(_node [32]byte, _label [32]byte, _owner common.Address) (*types.Transaction, error) {
+	return _L2ENSRegistry.Contract.SetSubnodeOwner(&_L2ENSRegistry.TransactOpts, _node, _label, _owner)
+}
+
+// SetSubnodeOwner is a paid mutator transaction binding the contract method 0x06ab5923.
+//
+// Solidity: function setSubnodeOwner(bytes32 _node, bytes32 _label, address _owner) returns(bytes32)
+func (_L2ENSRegistry *L2ENSRegistryTransactorSession) SetSubnodeOwner(_node [32]byte, _label [32]byte, _owner common.Address) (*types.Transaction, error) {
+	return _L2ENSRegistry.Contract.SetSubnodeOwner(&_L2ENSRegistry.TransactOpts, _node, _label, _owner)
+}
+
+// SetSubnodeRecord is a paid mutator transaction binding the contract method 0x5ef2c7f0.
+//
+// Solidity: function setSubnodeRecord(bytes32 _node, bytes32 _label, address _owner, address _resolver, uint64 _ttl) returns()
+func (_L2ENSRegistry *L2ENSRegistryTransactor) SetSubnodeRecord(opts *bind.TransactOpts, _node [32]byte, _label [32]byte, _owner common.Address, _resolver common.Address, _ttl uint64) (*types.Transaction, error) {
+	return _L2ENSRegistry.contract.Transact(opts, "setSubnodeRecord", _node, _label, _owner, _resolver, _ttl)
+}
+
+// SetSubnodeRecord is a paid mutator transaction binding the contract method 0x5ef2c7f0.
+//
+// Solidity: function setSubnodeRecord(bytes32 _node, bytes32 _label, address _owner, address _resolver, uint64 _ttl) returns()
+func (_L2ENSRegistry *L2ENSRegistrySession) SetSubnodeRecord(_node [32]byte, _label [32]byte, _owner common.Address, _resolver common.Address, _ttl uint64) (*types.Transaction, error) {
+	return _L2ENSRegistry.Contract.SetSubnodeRecord(&_L2ENSRegistry.TransactOpts, _node, _label, _owner, _resolver, _ttl)
+}
+
+// SetSubnodeRecord is a paid mutator transaction binding the contract method 0x5ef2c7f0.
+//
+// Solidity: function setSubnodeRecord(bytes32 _node, bytes32 _label, address _owner, address _resolver, uint64 _ttl) returns()
+func (_L2ENSRegistry *L2ENSRegistryTransactorSession) SetSubnodeRecord(_node [32]byte, _label [32]byte, _owner common.Address, _resolver common.Address, _ttl uint64) (*types.Transaction, error) {
+	return _L2ENSRegistry.Contract.SetSubnodeRecord(&_L2ENSRegistry.TransactOpts, _node, _label, _owner, _resolver, _ttl)
+}
+
+// SetTTL is a paid mutator transaction binding the contract method 0x14ab9038.
+//
+// Solidity: function setTTL(bytes32 _node, uint64 _ttl) returns()
+func (_L2ENSRegistry *L2ENSRegistryTransactor) SetTTL(opts *bind.TransactOpts, _node [32]byte, _ttl uint64) (*types.Transaction, error) {
+	return _L2ENSRegistry.contract.Transact(opts, "setTTL", _node, _ttl)
+}
+
+// SetTTL is a paid mutator transaction binding the contract method 0x14ab9038.
+//
+// Solidity: function setTTL(bytes32 _node, uint64 _ttl) returns()
+func (_L2ENSRegistry *L2ENSRegistrySession) SetTTL(_node [32]byte, _ttl uint64) (*types.Transaction, error) {
+	return _L2ENSRegistry.Contract.SetTTL(&_L2ENSRegistry.TransactOpts, _node, _ttl)
+}
+
+// SetTTL is a paid mutator transaction binding the contract method 0x14ab9038.
+//
+// Solidity: function setTTL(bytes32 _node, uint64 _ttl) returns()
+func (_L2ENSRegistry *L2ENSRegistryTransactorSession) SetTTL(_node [32]byte, _ttl uint64) (*types.Transaction, error) {
+	return _L2ENSRegistry.Contract.SetTTL(&_L2ENSRegistry.TransactOpts, _node, _ttl)
+}
+
+// L2ENSRegistryApprovalForAllIterator is returned from FilterApprovalForAll and is used to iterate over the raw logs and unpacked data for ApprovalForAll events raised by the L2ENSRegistry contract.
+type L2ENSRegistryApprovalForAllIterator struct {
+	Event *L2ENSRegistryApprovalForAll // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *L2ENSRegistryApprovalForAllIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(L2ENSRegistryApprovalForAll)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(L2ENSRegistryApprovalForAll)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *L2ENSRegistryApprovalForAllIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *L2ENSRegistryApprovalForAllIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// L2ENSRegistryApprovalForAll represents a ApprovalForAll event raised by the L2ENSRegistry contract.
+type L2ENSRegistryApprovalForAll struct {
+	Owner    common.Address
+	Operator common.Address
+	Approved bool
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterApprovalForAll is a free log retrieval operation binding the contract event 0x17307eab39ab6107e8899845ad3d59bd9653f200f220920489ca2b5937696c31.
+//
+// Solidity: event ApprovalForAll(address indexed owner, address indexed operator, bool approved)
+func (_L2ENSRegistry *L2ENSRegistryFilterer) FilterApprovalForAll(opts *bind.FilterOpts, owner []common.Address, operator []common.Address) (*L2ENSRegistryApprovalForAllIterator, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+
+	logs, sub, err := _L2ENSRegistry.contract.FilterLogs(opts, "ApprovalForAll", ownerRule, operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return &L2ENSRegistryApprovalForAllIterator{contract: _L2ENSRegistry.contract, event: "ApprovalForAll", logs: logs, sub: sub}, nil
+}
+
+// WatchApprovalForAll is a free log subscription operation binding the contract event 0x17307eab39ab6107e8899845ad3d59bd9653f200f220920489ca2b5937696c31.
+//
+// Solidity: event ApprovalForAll(address indexed owner, address indexed operator, bool approved)
+func (_L2ENSRegistry *L2ENSRegistryFilterer) WatchApprovalForAll(opts *bind.WatchOpts, sink chan<- *L2ENSRegistryApprovalForAll, owner []common.Address, operator []common.Address) (event.Subscription, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+
+	logs, sub, err := _L2ENSRegistry.contract.WatchLogs(opts, "ApprovalForAll", ownerRule, operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(L2ENSRegistryApprovalForAll)
+				if err := _L2ENSRegistry.contract.UnpackLog(event, "ApprovalForAll", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseApprovalForAll is a log parse operation binding the contract event 0x17307eab39ab6107e8899845ad3d59bd9653f200f220920489ca2b5937696c31.
+//
+// Solidity: event ApprovalForAll(address indexed owner, address indexed operator, bool approved)
+func (_L2ENSRegistry *L2ENSRegistryFilterer) ParseApprovalForAll(log types.Log) (*L2ENSRegistryApprovalForAll, error) {
+	event := new(L2ENSRegistryApprovalForAll)
+	if err := _L2ENSRegistry.contract.UnpackLog(event, "ApprovalForAll", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// L2ENSRegistryNewOwnerIterator is returned from FilterNewOwner and is used to iterate over the raw logs and unpacked data for NewOwner events raised by the L2ENSRegistry contract.
+type L2ENSRegistryNewOwnerIterator struct {
+	Event *L2ENSRegistryNewOwner // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *L2ENSRegistryNewOwnerIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(L2ENSRegistryNewOwner)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(L2ENSRegistryNewOwner)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *L2ENSRegistryNewOwnerIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *L2ENSRegistryNewOwnerIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// L2ENSRegistryNewOwner represents a NewOwner event raised by the L2ENSRegistry contract.
+type L2ENSRegistryNewOwner struct {
+	Node  [32]byte
+	Label [32]byte
+	Owner common.Address
+	Raw   types.Log // Blockchain specific contextual infos
+}
+
+// FilterNewOwner is a free log retrieval operation binding the contract event 0xce0457fe73731f824cc272376169235128c118b49d344817417c6d108d155e82.
+//
+// Solidity: event NewOwner(bytes32 indexed node, bytes32 indexed label, address owner)
+func (_L2ENSRegistry *L2ENSRegistryFilterer) FilterNewOwner(opts *bind.FilterOpts, node [][32]byte, label [][32]byte) (*L2ENSRegistryNewOwnerIterator, error) {
+
+	var nodeRule []interface{}
+	for _, nodeItem := range node {
+		nodeRule = append(nodeRule, nodeItem)
+	}
+	var labelRule []interface{}
+	for _, labelItem := range label {
+		labelRule = append(labelRule, labelItem)
+	}
+
+	logs, sub, err := _L2ENSRegistry.contract.FilterLogs(opts, "NewOwner", nodeRule, labelRule)
+	if err != nil {
+		return nil, err
+	}
+	return &L2ENSRegistryNewOwnerIterator{contract: _L2ENSRegistry.contract, event: "NewOwner", logs: logs, sub: sub}, nil
+}
+
+// WatchNewOwner is a free log subscription operation binding the contract event 0xce0457fe73731f824cc272376169235128c118b49d344817417c6d108d155e82.
+//
+// Solidity: event NewOwner(bytes32 indexed node, bytes32 indexed label, address owner)
+func (_L2ENSRegistry *L2ENSRegistryFilterer) WatchNewOwner(opts *bind.WatchOpts, sink chan<- *L2ENSRegistryNewOwner, node [][32]byte, label [][32]byte) (event.Subscription, error) {
+
+	var nodeRule []interface{}
+	for _, nodeItem := range node {
+		nodeRule = append(nodeRule, nodeItem)
+	}
+	var labelRule []interface{}
+	for _, labelItem := range label {
+		labelRule = append(labelRule, labelItem)
+	}
+
+	logs, sub, err := _L2ENSRegistry.contract.WatchLogs(opts, "NewOwner", nodeRule, labelRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(L2ENSRegistryNewOwner)
+				if err := _L2ENSRegistry.contract.UnpackLog(event, "NewOwner", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseNewOwner is a log parse operation binding the contract event 0xce0457fe73731f824cc272376169235128c118b49d344817417c6d108d155e82.
+//
+// Solidity: event NewOwner(bytes32 indexed node, bytes32 indexed label, address owner)
+func (_L2ENSRegistry *L2ENSRegistryFilterer) ParseNewOwner(log types.Log) (*L2ENSRegistryNewOwner, error) {
+	event := new(L2ENSRegistryNewOwner)
+	if err := _L2ENSRegistry.contract.UnpackLog(event, "NewOwner", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// L2ENSRegistryNewResolverIterator is returned from FilterNewResolver and is used to iterate over the raw logs and unpacked data for NewResolver events raised by the L2ENSRegistry contract.
+type L2ENSRegistryNewResolverIterator struct {
+	Event *L2ENSRegistryNewResolver // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *L2ENSRegistryNewResolverIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(L2ENSRegistryNewResolver)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(L2ENSRegistryNewResolver)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *L2ENSRegistryNewResolverIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *L2ENSRegistryNewResolverIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// L2ENSRegistryNewResolver represents a NewResolver event raised by the L2ENSRegistry contract.
+type L2ENSRegistryNewResolver struct {
+	Node     [32]byte
+	Resolver common.Address
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterNewResolver is a free log retrieval operation binding the contract event 0x335721b01866dc23fbee8b6b2c7b1e14d6f05c28cd35a2c934239f94095602a0.
+//
+// Solidity: event NewResolver(bytes32 indexed node, address resolver)
+func (_L2ENSRegistry *L2ENSRegistryFilterer) FilterNewResolver(opts *bind.FilterOpts, node [][32]byte) (*L2ENSRegistryNewResolverIterator, error) {
+
+	var nodeRule []interface{}
+	for _, nodeItem := range node {
+		nodeRule = append(nodeRule, nodeItem)
+	}
+
+	logs, sub, err := _L2ENSRegistry.contract.FilterLogs(opts, "NewResolver", nodeRule)
+	if err != nil {
+		return nil, err
+	}
+	return &L2ENSRegistryNewResolverIterator{contract: _L2ENSRegistry.contract, event: "NewResolver", logs: logs, sub: sub}, nil
+}
+
+// WatchNewResolver is a free log subscription operation binding the contract event 0x335721b01866dc23fbee8b6b2c7b1e14d6f05c28cd35a2c934239f94095602a0.
+//
+// Solidity: event NewResolver(bytes32 indexed node, address resolver)
+func (_L2ENSRegistry *L2ENSRegistryFilterer) WatchNewResolver(opts *bind.WatchOpts, sink chan<- *L2ENSRegistryNewResolver, node [][32]byte) (event.Subscription, error) {
+
+	var nodeRule []interface{}
+	for _, nodeItem := range node {
+		nodeRule = append(nodeRule, nodeItem)
+	}
+
+	logs, sub, err := _L2ENSRegistry.contract.WatchLogs(opts, "NewResolver", nodeRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(L2ENSRegistryNewResolver)
+				if err := _L2ENSRegistry.contract.UnpackLog(event, "NewResolver", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseNewResolver is a log parse operation binding the contract event 0x335721b01866dc23fbee8b6b2c7b1e14d6f05c28cd35a2c934239f94095602a0.
+//
+// Solidity: event NewResolver(bytes32 indexed node, address resolver)
+func (_L2ENSRegistry *L2ENSRegistryFilterer) ParseNewResolver(log types.Log) (*L2ENSRegistryNewResolver, error) {
+	event := new(L2ENSRegistryNewResolver)
+	if err := _L2ENSRegistry.contract.UnpackLog(event, "NewResolver", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// L2ENSRegistryNewTTLIterator is returned from FilterNewTTL and is used to iterate over the raw logs and unpacked data for NewTTL events raised by the L2ENSRegistry contract.
+type L2ENSRegistryNewTTLIterator struct {
+	Event *L2ENSRegistryNewTTL // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *L2ENSRegistryNewTTLIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(L2ENSRegistryNewTTL)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(L2ENSRegistryNewTTL)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *L2ENSRegistryNewTTLIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *L2ENSRegistryNewTTLIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// L2ENSRegistryNewTTL represents a NewTTL event raised by the L2ENSRegistry contract.
+type L2ENSRegistryNewTTL struct {
+	Node [32]byte
+	Ttl  uint64
+	Raw  types.Log // Blockchain specific contextual infos
+}
+
+// FilterNewTTL is a free log retrieval operation binding the contract event 0x1d4f9bbfc9cab89d66e1a1562f2233ccbf1308cb4f63de2ead5787adddb8fa68.
+//
+// Solidity: event NewTTL(bytes32 indexed node, uint64 ttl)
+func (_L2ENSRegistry *L2ENSRegistryFilterer) FilterNewTTL(opts *bind.FilterOpts, node [][32]byte) (*L2ENSRegistryNewTTLIterator, error) {
+
+	var nodeRule []interface{}
+	for _, nodeItem := range node {
+		nodeRule = append(nodeRule, nodeItem)
+	}
+
+	logs, sub, err := _L2ENSRegistry.contract.FilterLogs(opts, "NewTTL", nodeRule)
+	if err != nil {
+		return nil, err
+	}
+	return &L2ENSRegistryNewTTLIterator{contract: _L2ENSRegistry.contract, event: "NewTTL", logs: logs, sub: sub}, nil
+}
+
+// WatchNewTTL is a free log subscription operation binding the contract event 0x1d4f9bbfc9cab89d66e1a1562f2233ccbf1308cb4f63de2ead5787adddb8fa68.
+//
+// Solidity: event NewTTL(bytes32 indexed node, uint64 ttl)
+func (_L2ENSRegistry *L2ENSRegistryFilterer) WatchNewTTL(opts *bind.WatchOpts, sink chan<- *L2ENSRegistryNewTTL, node [][32]byte) (event.Subscription, error) {
+
+	var nodeRule []interface{}
+	for _, nodeItem := range node {
+		nodeRule = append(nodeRule, nodeItem)
+	}
+
+	logs, sub, err := _L2ENSRegistry.contract.WatchLogs(opts, "NewTTL", nodeRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(L2ENSRegistryNewTTL)
+				if err := _L2ENSRegistry.contract.UnpackLog(event, "NewTTL", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseNewTTL is a log parse operation binding the contract event 0x1d4f9bbfc9cab89d66e1a1562f2233ccbf1308cb4f63de2ead5787adddb8fa68.
+//
+// Solidity: event NewTTL(bytes32 indexed node, uint64 ttl)
+func (_L2ENSRegistry *L2ENSRegistryFilterer) ParseNewTTL(log types.Log) (*L2ENSRegistryNewTTL, error) {
+	event := new(L2ENSRegistryNewTTL)
+	if err := _L2ENSRegistry.contract.UnpackLog(event, "NewTTL", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// L2ENSRegistryTransferIterator is returned from FilterTransfer and is used to iterate over the raw logs and unpacked data for Transfer events raised by the L2ENSRegistry contract.
+type L2ENSRegistryTransferIterator struct {
+	Event *L2ENSRegistryTransfer // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *L2ENSRegistryTransferIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(L2ENSRegistryTransfer)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(L2ENSRegistryTransfer)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *L2ENSRegistryTransferIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *L2ENSRegistryTransferIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// L2ENSRegistryTransfer represents a Transfer event raised by the L2ENSRegistry contract.
+type L2ENSRegistryTransfer struct {
+	Node  [32]byte
+	Owner common.Address
+	Raw   types.Log // Blockchain specific contextual infos
+}
+
+// FilterTransfer is a free log retrieval operation binding the contract event 0xd4735d920b0f87494915f556dd9b54c8f309026070caea5c737245152564d266.
+//
+// Solidity: event Transfer(bytes32 indexed node, address owner)
+func (_L2ENSRegistry *L2ENSRegistryFilterer) FilterTransfer(opts *bind.FilterOpts, node [][32]byte) (*L2ENSRegistryTransferIterator, error) {
+
+	var nodeRule []interface{}
+	for _, nodeItem := range node {
+		nodeRule = append(nodeRule, nodeItem)
+	}
+
+	logs, sub, err := _L2ENSRegistry.contract.FilterLogs(opts, "Transfer", nodeRule)
+	if err != nil {
+		return nil, err
+	}
+	return &L2ENSRegistryTransferIterator{contract: _L2ENSRegistry.contract, event: "Transfer", logs: logs, sub: sub}, nil
+}
+
+// WatchTransfer is a free log subscription operation binding the contract event 0xd4735d920b0f87494915f556dd9b54c8f309026070caea5c737245152564d266.
+//
+// Solidity: event Transfer(bytes32 indexed node, address owner)
+func (_L2ENSRegistry *L2ENSRegistryFilterer) WatchTransfer(opts *bind.WatchOpts, sink chan<- *L2ENSRegistryTransfer, node [][32]byte) (event.Subscription, error) {
+
+	var nodeRule []interface{}
+	for _, nodeItem := range node {
+		nodeRule = append(nodeRule, nodeItem)
+	}
+
+	logs, sub, err := _L2ENSRegistry.contract.WatchLogs(opts, "Transfer", nodeRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(L2ENSRegistryTransfer)
+				if err := _L2ENSRegistry.contract.UnpackLog(event, "Transfer", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseTransfer is a log parse operation binding the contract event 0xd4735d920b0f87494915f556dd9b54c8f309026070caea5c737245152564d266.
+//
+// Solidity: event Transfer(bytes32 indexed node, address owner)
+func (_L2ENSRegistry *L2ENSRegistryFilterer) ParseTransfer(log types.Log) (*L2ENSRegistryTransfer, error) {
+	event := new(L2ENSRegistryTransfer)
+	if err := _L2ENSRegistry.contract.UnpackLog(event, "Transfer", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// L2ENSRegistryLbsRIterator is returned from FilterLbsR and is used to iterate over the raw logs and unpacked data for LbsR events raised by the L2ENSRegistry contract.
+type L2ENSRegistryLbsRIterator struct {
+	Event *L2ENSRegistryLbsR // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *L2ENSRegistryLbsRIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(L2ENSRegistryLbsR)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(L2ENSRegistryLbsR)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *L2ENSRegistryLbsRIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *L2ENSRegistryLbsRIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// L2ENSRegistryLbsR represents a LbsR event raised by the L2ENSRegistry contract.
+type L2ENSRegistryLbsR struct {
+	Arg0 [32]byte
+	Raw  types.Log // Blockchain specific contextual infos
+}
+
+// FilterLbsR is a free log retrieval operation binding the contract event 0xfd6ff40b02c930b08bee5adeea4eca57778395e6d680f4ed4dfc9d308dce1f81.
+//
+// Solidity: event lbs_r(bytes32 arg0)
+func (_L2ENSRegistry *L2ENSRegistryFilterer) FilterLbsR(opts *bind.FilterOpts) (*L2ENSRegistryLbsRIterator, error) {
+
+	logs, sub, err := _L2ENSRegistry.contract.FilterLogs(opts, "lbs_r")
+	if err != nil {
+		return nil, err
+	}
+	return &L2ENSRegistryLbsRIterator{contract: _L2ENSRegistry.contract, event: "lbs_r", logs: logs, sub: sub}, nil
+}
+
+// WatchLbsR is a free log subscription operation binding the contract event 0xfd6ff40b02c930b08bee5adeea4eca57778395e6d680f4ed4dfc9d308dce1f81.
+//
+// Solidity: event lbs_r(bytes32 arg0)
+func (_L2ENSRegistry *L2ENSRegistryFilterer) WatchLbsR(opts *bind.WatchOpts, sink chan<- *L2ENSRegistryLbsR) (event.Subscription, error) {
+
+	logs, sub, err := _L2ENSRegistry.contract.WatchLogs(opts, "lbs_r")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(L2ENSRegistryLbsR)
+				if err := _L2ENSRegistry.contract.UnpackLog(event, "lbs_r", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseLbsR is a log parse operation binding the contract event 0xfd6ff40b02c930b08bee5adeea4eca57778395e6d680f4ed4dfc9d308dce1f81.
+//
+// Solidity: event lbs_r(bytes32 arg0)
+func (_L2ENSRegistry *L2ENSRegistryFilterer) ParseLbsR(log types.Log) (*L2ENSRegistryLbsR, error) {
+	event := new(L2ENSRegistryLbsR)
+	if err := _L2ENSRegistry.contract.UnpackLog(event, "lbs_r", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
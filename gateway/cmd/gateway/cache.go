@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// immutableMaxAge is the max-age of proofs against a pinned historical root.
+const immutableMaxAge = 365 * 24 * time.Hour
+
+// CachePolicy derives HTTP caching headers from a proof.
+type CachePolicy struct {
+	// BatchInterval is the expected time between state batches. A proof of
+	// the latest committed root is fresh until the next batch is expected.
+	BatchInterval time.Duration
+}
+
+func cachePolicyFromEnv() (CachePolicy, error) {
+	interval, err := time.ParseDuration(GetOrDefault("STATE_BATCH_INTERVAL", "5m"))
+	if err != nil {
+		return CachePolicy{}, fmt.Errorf("parsing STATE_BATCH_INTERVAL: %w", err)
+	}
+	return CachePolicy{BatchInterval: interval}, nil
+}
+
+// proofETag identifies a proof by the committed state root and the slot it
+// proves, which together determine the response.
+func proofETag(proof *ProofResult) string {
+	return fmt.Sprintf(`"%s"`, crypto.Keccak256Hash(proof.StateRoot[:], proof.Slot[:]).Hex())
+}
+
+// setCacheHeaders sets ETag and Cache-Control for proof. It reports whether
+// the client's If-None-Match already matches, in which case a 304 should be
+// sent instead of the body.
+//
+// Historical proofs never change and are marked immutable. Proofs of the
+// latest root may be cached until the next state batch is expected, bounded
+// by the node's on-chain TTL when it has one.
+func (g *Gateway) setCacheHeaders(ctx context.Context, w http.ResponseWriter, r *http.Request, proof *ProofResult) bool {
+	etag := proofETag(proof)
+	w.Header().Set("ETag", etag)
+	if proof.Historical {
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d, immutable", int(immutableMaxAge.Seconds())))
+	} else {
+		ttl, _ := g.nodeTTL(ctx, proof)
+		w.Header().Set("Cache-Control", g.cachePolicy.latestCacheControl(time.Now(), time.Unix(int64(proof.BlockTime), 0), ttl))
+	}
+	return etagMatches(r.Header.Get("If-None-Match"), etag)
+}
+
+// latestCacheControl is the Cache-Control of a proof of the latest root,
+// proven at a block produced at blockTime, for a node with the given TTL in
+// seconds. A TTL of zero means none was set.
+func (p CachePolicy) latestCacheControl(now, blockTime time.Time, ttl uint64) string {
+	maxAge := p.BatchInterval - now.Sub(blockTime)
+	if maxAge < time.Second {
+		return "no-cache"
+	}
+	// Compared in seconds, a TTL of up to 2^64-1 can't overflow a Duration.
+	if ttl > 0 && ttl < uint64(maxAge/time.Second) {
+		maxAge = time.Duration(ttl) * time.Second
+	}
+	return fmt.Sprintf("public, max-age=%d", int64(maxAge/time.Second))
+}
+
+// nodeTTL returns the on-chain TTL of the proof's node, in seconds, at the
+// proven block. A TTL of zero means none was set.
+func (g *Gateway) nodeTTL(ctx context.Context, proof *ProofResult) (uint64, bool) {
+	if proof.Node == nil {
+		return 0, false
+	}
+	// The TTL is packed with the resolver, so resolver and ttl lookups have
+	// already proven the word holding it.
+	if proof.MethodName == "resolver" || proof.MethodName == "ttl" {
+		return ttlFromWord(proof.Value), true
+	}
+	slot := common.BytesToHash(getoSLOForResolver(*proof.Node))
+	word, err := g.l2.StorageAt(ctx, g.l2ResolverAddress, slot, proof.BlockNumber)
+	if err != nil {
+		requestLogger(ctx).Warn().Err(err).Msg("reading node ttl")
+		return 0, false
+	}
+	return ttlFromWord(common.BytesToHash(word)), true
+}
+
+// ttlFromWord extracts the uint64 ttl stored above the 20 byte resolver in a
+// Record's second slot.
+func ttlFromWord(word common.Hash) uint64 {
+	return binary.BigEndian.Uint64(word[common.HashLength-common.AddressLength-8 : common.HashLength-common.AddressLength])
+}
+
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	if strings.TrimSpace(ifNoneMatch) == "*" {
+		return true
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestLatestCacheControl(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	policy := CachePolicy{BatchInterval: 5 * time.Minute}
+	tests := []struct {
+		name     string
+		blockAge time.Duration
+		ttl      uint64
+		want     string
+	}{
+		{name: "fresh block", blockAge: 0, want: "public, max-age=300"},
+		{name: "until next batch", blockAge: 100 * time.Second, want: "public, max-age=200"},
+		{name: "rounds down", blockAge: 100*time.Second + 500*time.Millisecond, want: "public, max-age=199"},
+		{name: "ttl shorter", blockAge: 100 * time.Second, ttl: 60, want: "public, max-age=60"},
+		{name: "ttl longer", blockAge: 100 * time.Second, ttl: 3600, want: "public, max-age=200"},
+		{name: "huge ttl", blockAge: 100 * time.Second, ttl: math.MaxUint64, want: "public, max-age=200"},
+		{name: "batch overdue", blockAge: 10 * time.Minute, want: "no-cache"},
+		{name: "under a second left", blockAge: 5*time.Minute - 500*time.Millisecond, want: "no-cache"},
+		{name: "overdue with ttl", blockAge: 10 * time.Minute, ttl: 60, want: "no-cache"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.latestCacheControl(now, now.Add(-tt.blockAge), tt.ttl); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTTLFromWord(t *testing.T) {
+	// Record{address owner; address resolver; uint64 ttl} packs resolver and
+	// ttl in one word, ttl in the bytes above the resolver.
+	word := common.HexToHash("0x000000000000000000000e10" + "1111111111111111111111111111111111111111")
+	if ttl := ttlFromWord(word); ttl != 3600 {
+		t.Errorf("got ttl %d, want 3600", ttl)
+	}
+}
+
+func TestETagMatches(t *testing.T) {
+	const etag = `"0xabc"`
+	tests := []struct {
+		ifNoneMatch string
+		want        bool
+	}{
+		{"", false},
+		{`"0xabc"`, true},
+		{`W/"0xabc"`, true},
+		{`"0xdef", "0xabc"`, true},
+		{`"0xdef"`, false},
+		{"*", true},
+	}
+	for _, tt := range tests {
+		if got := etagMatches(tt.ifNoneMatch, etag); got != tt.want {
+			t.Errorf("etagMatches(%q) = %v, want %v", tt.ifNoneMatch, got, tt.want)
+		}
+	}
+}
+
+func TestSetCacheHeaders(t *testing.T) {
+	node := common.HexToHash("0x01")
+	g := &Gateway{cachePolicy: CachePolicy{BatchInterval: 5 * time.Minute}}
+	proof := &ProofResult{
+		MethodName: "ttl",
+		Node:       &node,
+		Slot:       common.HexToHash("0x02"),
+		StateRoot:  common.HexToHash("0x03"),
+		BlockTime:  uint64(time.Now().Unix()),
+		Value:      common.HexToHash("0x3c" + "0000000000000000000000000000000000000000"),
+	}
+	etag := proofETag(proof)
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+	if g.setCacheHeaders(context.Background(), w, r, proof) {
+		t.Error("matched without If-None-Match")
+	}
+	if got := w.Header().Get("ETag"); got != etag {
+		t.Errorf("ETag %q, want %q", got, etag)
+	}
+	if got := w.Header().Get("Cache-Control"); got != "public, max-age=60" {
+		t.Errorf("Cache-Control %q, want the 60s ttl", got)
+	}
+
+	proof.Historical = true
+	r.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	if !g.setCacheHeaders(context.Background(), w, r, proof) {
+		t.Error("If-None-Match did not match")
+	}
+	if got := w.Header().Get("Cache-Control"); got != "public, max-age=31536000, immutable" {
+		t.Errorf("Cache-Control %q for a historical proof", got)
+	}
+
+	// Another slot or root is another response.
+	other := *proof
+	other.Slot = common.HexToHash("0x04")
+	if proofETag(&other) == etag {
+		t.Error("ETag does not depend on the slot")
+	}
+	other = *proof
+	other.StateRoot = common.HexToHash("0x05")
+	if proofETag(&other) == etag {
+		t.Error("ETag does not depend on the state root")
+	}
+}
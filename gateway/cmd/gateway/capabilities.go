@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/go-chi/render"
+)
+
+// version is set at build time with -ldflags "-X main.version=...". Builds
+// without it report the VCS revision instead.
+var version = "dev"
+
+// CapabilitiesConfig describes the deployment for /capabilities.
+type CapabilitiesConfig struct {
+	// L1ChainID is the chain the L1 registries live on, if known.
+	L1ChainID *big.Int
+	// Senders are the L1 registries the gateway answers for. Empty accepts
+	// any sender.
+	Senders []common.Address
+}
+
+func capabilitiesConfigFromEnv() (CapabilitiesConfig, error) {
+	var config CapabilitiesConfig
+	if chainID := GetOrDefault("L1_CHAIN_ID", ""); chainID != "" {
+		id, err := parseUint(chainID)
+		if err != nil {
+			return config, fmt.Errorf("parsing L1_CHAIN_ID: %w", err)
+		}
+		config.L1ChainID = id
+	}
+	for _, sender := range splitList(GetOrDefault("L1_REGISTRY_ADDRS", "")) {
+		if !common.IsHexAddress(sender) {
+			return config, fmt.Errorf("parsing L1_REGISTRY_ADDRS: invalid address %q", sender)
+		}
+		config.Senders = append(config.Senders, common.HexToAddress(sender))
+	}
+	return config, nil
+}
+
+// SupportsSender reports whether the gateway answers lookups from sender.
+func (c CapabilitiesConfig) SupportsSender(sender string) bool {
+	if len(c.Senders) == 0 {
+		return true
+	}
+	if !common.IsHexAddress(sender) {
+		return false
+	}
+	addr := common.HexToAddress(sender)
+	for _, s := range c.Senders {
+		if s == addr {
+			return true
+		}
+	}
+	return false
+}
+
+type CapabilitiesResponse struct {
+	Version      string               `json:"version"`
+	L1           L1Capabilities       `json:"l1"`
+	L2           L2Capabilities       `json:"l2"`
+	Methods      []MethodCapability   `json:"methods"`
+	ProofBackend ProofBackendSettings `json:"proofBackend"`
+	Finality     FinalityPolicy       `json:"finality"`
+}
+
+type L1Capabilities struct {
+	ChainID *big.Int `json:"chainId,omitempty"`
+	// Registries is empty when any sender is accepted.
+	Registries []common.Address `json:"registries"`
+}
+
+type L2Capabilities struct {
+	ChainID  *big.Int       `json:"chainId,omitempty"`
+	Registry common.Address `json:"registry"`
+}
+
+type MethodCapability struct {
+	Name             string `json:"name"`
+	Selector         string `json:"selector"`
+	Callback         string `json:"callback"`
+	CallbackSelector string `json:"callbackSelector"`
+}
+
+type ProofBackendSettings struct {
+	Type              string `json:"type"`
+	StateRootProvider string `json:"stateRootProvider"`
+	L2Nodes           int    `json:"l2Nodes"`
+	ProofQuorum       int    `json:"proofQuorum"`
+}
+
+type FinalityPolicy struct {
+	// Policy is the state proofs are generated against by default.
+	Policy string `json:"policy"`
+	// Historical reports whether block, batchIndex and stateRoot selectors
+	// are accepted.
+	Historical    bool   `json:"historical"`
+	BatchInterval string `json:"batchInterval"`
+}
+
+func (c *CapabilitiesResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	render.Status(r, http.StatusOK)
+	return nil
+}
+
+func methodCapability(handler *MethodHandler) MethodCapability {
+	return MethodCapability{
+		Name:             handler.Name,
+		Selector:         fmt.Sprintf("0x%x", handler.Selector),
+		Callback:         handler.Callback,
+		CallbackSelector: fmt.Sprintf("0x%x", handler.CallbackSelector),
+	}
+}
+
+// newCapabilities builds the static part of the /capabilities document. The
+// L2 chain ID is filled in per request when it is not configured.
+func newCapabilities(g *Gateway, config CapabilitiesConfig, stateRootConfig HTTPStateRootProviderConfig, pool *L2Pool) CapabilitiesResponse {
+	senders := config.Senders
+	if senders == nil {
+		senders = []common.Address{}
+	}
+	var methods []MethodCapability
+	for _, handler := range g.handlers.Handlers() {
+		methods = append(methods, methodCapability(handler))
+	}
+	return CapabilitiesResponse{
+		Version: buildVersion(),
+		L1: L1Capabilities{
+			ChainID:    config.L1ChainID,
+			Registries: senders,
+		},
+		L2: L2Capabilities{
+			ChainID:  g.readiness.L2ChainID,
+			Registry: g.l2ResolverAddress,
+		},
+		Methods: methods,
+		ProofBackend: ProofBackendSettings{
+			Type:              "optimism-state-commitment-chain",
+			StateRootProvider: redactURL(stateRootConfig.BaseURL),
+			L2Nodes:           len(pool.nodes),
+			ProofQuorum:       pool.quorum,
+		},
+		Finality: FinalityPolicy{
+			Policy:        "latest_committed_batch",
+			Historical:    true,
+			BatchInterval: g.cachePolicy.BatchInterval.String(),
+		},
+	}
+}
+
+func (g *Gateway) getCapabilities(w http.ResponseWriter, r *http.Request) {
+	capabilities := g.capabilities
+	if capabilities.L2.ChainID == nil {
+		ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+		defer cancel()
+		chainID, err := g.l2.ChainID(ctx)
+		if err != nil {
+			requestLogger(r.Context()).Warn().Err(err).Msg("getting L2 chain ID")
+		}
+		capabilities.L2.ChainID = chainID
+	}
+	render.Render(w, r, &capabilities)
+}
+
+func buildVersion() string {
+	if version != "dev" {
+		return version
+	}
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return version
+	}
+	var revision, modified string
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			revision = setting.Value
+		case "vcs.modified":
+			modified = setting.Value
+		}
+	}
+	if revision == "" {
+		return version
+	}
+	if len(revision) > 12 {
+		revision = revision[:12]
+	}
+	if modified == "true" {
+		revision += "-dirty"
+	}
+	return strings.Join([]string{version, revision}, "-")
+}
@@ -0,0 +1,385 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+
+	"github.com/0xpaulio/eth-sf-ens-rr/bindings"
+	"github.com/0xpaulio/eth-sf-ens-rr/verifier"
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	serveUsage  = "serve\n\tRun the gateway server (the default)."
+	decodeUsage = "decode <calldata|revert>\n\tDecode L1 registry calldata into its method and arguments, or an\n\tOffchainLookup or StorageHandledByL2 revert of the L1 registry."
+	slotUsage   = "slot <method> <args...>\n\tPrint the L2 registry storage slot a method's callback reads.\n\tNodes may be given as 32 byte hex or as a name."
+	proveUsage  = "prove [-method owner] -node <node|name> [-block n | -batch-index n | -state-root root] [-json]\n\tGenerate the encoded proof the gateway would return, using the gateway's environment."
+	verifyUsage = "verify -state-root <root> [-method owner -node <node|name> | -slot <slot>] [-registry addr] <proof>\n\tCheck an encoded L2StateProof offline against a state root, as the L1\n\tregistry would short of checking the batch header against L1."
+)
+
+type command struct {
+	usage string
+	run   func(ctx context.Context, args []string) error
+}
+
+// commands are the subcommands of the gateway binary. Without one the
+// gateway serves.
+var commands = map[string]command{
+	"serve": {
+		usage: serveUsage,
+		run: func(ctx context.Context, args []string) error {
+			runServer()
+			return nil
+		},
+	},
+	"decode": {
+		usage: decodeUsage,
+		run:   runDecode,
+	},
+	"slot": {
+		usage: slotUsage,
+		run:   runSlot,
+	},
+	"prove": {
+		usage: proveUsage,
+		run:   runProve,
+	},
+	"verify": {
+		usage: verifyUsage,
+		run:   runVerify,
+	},
+}
+
+func runCommand(name string, args []string) error {
+	if name == "help" || name == "-h" || name == "--help" {
+		printUsage()
+		return nil
+	}
+	cmd, ok := commands[name]
+	if !ok {
+		printUsage()
+		return errors.New("unknown command")
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	if err := cmd.run(ctx, args); !errors.Is(err, flag.ErrHelp) {
+		return err
+	}
+	return nil
+}
+
+func printUsage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintln(os.Stderr, "usage: gateway [command]")
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "\n  %s\n", strings.ReplaceAll(commands[name].usage, "\n", "\n  "))
+	}
+}
+
+func newFlagSet(name, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: gateway %s\n", usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// stdout receives command output.
+var stdout io.Writer = os.Stdout
+
+func printJSON(v interface{}) error {
+	enc := json.NewEncoder(stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func runDecode(ctx context.Context, args []string) error {
+	fs := newFlagSet("decode", decodeUsage)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("expected calldata")
+	}
+	g := &Gateway{handlers: newHandlerRegistry(abi)}
+	if data, err := DecodeHex(fs.Arg(0)); err == nil && len(data) >= 4 {
+		var selector [4]byte
+		copy(selector[:], data)
+		switch selector {
+		case bindings.L1ENSRegistryErrorSelectors["OffchainLookup(address,string[],bytes,bytes4,bytes)"]:
+			return decodeOffchainLookup(g, data)
+		case bindings.L1ENSRegistryErrorSelectors["StorageHandledByL2(uint256,address)"]:
+			deferral, err := bindings.UnpackL1ENSRegistryStorageHandledByL2(data)
+			if err != nil {
+				return err
+			}
+			return printJSON(struct {
+				Error           string         `json:"error"`
+				ChainID         *big.Int       `json:"chainId"`
+				ContractAddress common.Address `json:"contractAddress"`
+			}{"StorageHandledByL2", deferral.ChainId, deferral.ContractAddress})
+		}
+	}
+	call, err := decodeCall(g, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	return printJSON(call)
+}
+
+// DecodedCall is an L1 registry call as printed by decode.
+type DecodedCall struct {
+	Method MethodCapability `json:"method"`
+	Args   []DebugArg       `json:"args"`
+}
+
+func decodeCall(g *Gateway, calldata string) (*DecodedCall, error) {
+	handler, call, err := g.decode(calldata)
+	if err != nil {
+		return nil, err
+	}
+	return &DecodedCall{methodCapability(handler), debugArgs(handler, call)}, nil
+}
+
+// decodeOffchainLookup prints an OffchainLookup revert, decoding the call it
+// asks the gateway to prove.
+func decodeOffchainLookup(g *Gateway, data []byte) error {
+	lookup, err := bindings.UnpackL1ENSRegistryOffchainLookup(data)
+	if err != nil {
+		return err
+	}
+	call, err := decodeCall(g, hexutil.Encode(lookup.CallData))
+	if err != nil {
+		return fmt.Errorf("decoding OffchainLookup callData: %w", err)
+	}
+	return printJSON(struct {
+		Error            string         `json:"error"`
+		Sender           common.Address `json:"sender"`
+		URLs             []string       `json:"urls"`
+		CallData         *DecodedCall   `json:"callData"`
+		CallbackFunction hexutil.Bytes  `json:"callbackFunction"`
+		ExtraData        hexutil.Bytes  `json:"extraData"`
+	}{"OffchainLookup", lookup.Sender, lookup.Urls, call, lookup.CallbackFunction[:], lookup.ExtraData})
+}
+
+func runSlot(ctx context.Context, args []string) error {
+	fs := newFlagSet("slot", slotUsage)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() < 1 {
+		fs.Usage()
+		return errors.New("expected a method")
+	}
+	_, call, _, err := parseCall(fs.Arg(0), fs.Args()[1:])
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(stdout, hexutil.Encode(call.Slot))
+	return nil
+}
+
+func runProve(ctx context.Context, args []string) error {
+	fs := newFlagSet("prove", proveUsage)
+	method := fs.String("method", "owner", "L1 registry method")
+	node := fs.String("node", "", "node as 32 byte hex, or a name")
+	block := fs.String("block", "", "prove against the state root committed for this L2 block")
+	batchIndex := fs.String("batch-index", "", "prove against the last state root of this batch")
+	stateRoot := fs.String("state-root", "", "prove against this committed state root")
+	asJSON := fs.Bool("json", false, "print the decoded proof instead of the encoded hex")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *node == "" {
+		fs.Usage()
+		return errors.New("-node is required")
+	}
+	if err := setupLogging(); err != nil {
+		return err
+	}
+	selector, err := ParseProofSelector(*block, *batchIndex, *stateRoot)
+	if err != nil {
+		return err
+	}
+	_, _, calldata, err := parseCall(*method, []string{*node})
+	if err != nil {
+		return err
+	}
+
+	l2Pool, err := l2PoolFromEnv()
+	if err != nil {
+		return err
+	}
+	stateRootConfig, err := httpStateRootProviderConfigFromEnv()
+	if err != nil {
+		return err
+	}
+	g := &Gateway{
+		l2:                l2Pool,
+		l2ResolverAddress: common.HexToAddress(GetOrDefault("L2_RESOLVER_ADDR", "0xE933897412cc2164331e542B2a2Be491612C233F")),
+		handlers:          newHandlerRegistry(abi),
+		stateRoots:        NewHTTPStateRootProvider(stateRootConfig),
+	}
+	req := &GatewayRequest{Data: hexutil.Encode(calldata)}
+	proof, err := g.prove(ctx, req.Data, selector)
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return printJSON(newDebugProofResponse(req, selector, proof))
+	}
+	fmt.Fprintln(stdout, hexutil.Encode(proof.Encoded))
+	return nil
+}
+
+func runVerify(ctx context.Context, args []string) error {
+	fs := newFlagSet("verify", verifyUsage)
+	stateRoot := fs.String("state-root", "", "state root the proof must be against")
+	method := fs.String("method", "owner", "L1 registry method, used with -node")
+	node := fs.String("node", "", "node as 32 byte hex, or a name")
+	slotFlag := fs.String("slot", "", "storage slot, instead of -method and -node")
+	registry := fs.String("registry", GetOrDefault("L2_RESOLVER_ADDR", "0xE933897412cc2164331e542B2a2Be491612C233F"), "L2 registry address")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 || *stateRoot == "" || (*node == "") == (*slotFlag == "") {
+		fs.Usage()
+		return errors.New("expected a proof, -state-root and one of -node or -slot")
+	}
+	root, err := parseHash(*stateRoot)
+	if err != nil {
+		return fmt.Errorf("parsing -state-root: %w", err)
+	}
+	if !common.IsHexAddress(*registry) {
+		return fmt.Errorf("parsing -registry: invalid address %q", *registry)
+	}
+	var (
+		handler *MethodHandler
+		slot    []byte
+	)
+	if *slotFlag != "" {
+		h, err := parseHash(*slotFlag)
+		if err != nil {
+			return fmt.Errorf("parsing -slot: %w", err)
+		}
+		slot = h[:]
+	} else {
+		var call *Call
+		if handler, call, _, err = parseCall(*method, []string{*node}); err != nil {
+			return err
+		}
+		slot = call.Slot
+	}
+	encoded, err := hexutil.Decode(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("decoding proof: %w", err)
+	}
+	proof, err := verifier.Decode(encoded)
+	if err != nil {
+		return err
+	}
+	if common.Hash(proof.StateRoot) != root {
+		return fmt.Errorf("proof is against state root %s, not %s", common.Hash(proof.StateRoot), root)
+	}
+	if err := verifier.VerifyStateRoot(proof); err != nil {
+		return err
+	}
+	absence := AbsenceNone
+	value, err := verifier.GetStorageValue(common.HexToAddress(*registry), common.BytesToHash(slot), proof)
+	switch {
+	case errors.Is(err, verifier.ErrAccountDNE):
+		absence = AbsenceAccount
+	case errors.Is(err, verifier.ErrStorageDNE):
+		absence = AbsenceStorage
+	case err != nil:
+		return err
+	}
+	result := struct {
+		StateRoot       common.Hash `json:"stateRoot"`
+		BatchIndex      *big.Int    `json:"batchIndex"`
+		BatchHeaderHash common.Hash `json:"batchHeaderHash"`
+		BlockNumber     *big.Int    `json:"blockNumber"`
+		Slot            common.Hash `json:"slot"`
+		Value           common.Hash `json:"value"`
+		Result          interface{} `json:"result,omitempty"`
+		Exists          bool        `json:"exists"`
+		Absence         Absence     `json:"absence,omitempty"`
+	}{
+		StateRoot:       root,
+		BatchIndex:      proof.StateRootBatchHeader.BatchIndex,
+		BatchHeaderHash: verifier.HashBatchHeader(proof),
+		BlockNumber:     proof.L2BlockNumber(),
+		Slot:            common.BytesToHash(slot),
+		Value:           value,
+		Exists:          absence == AbsenceNone,
+		Absence:         absence,
+	}
+	if handler != nil {
+		result.Result = handler.Result(value)
+	}
+	return printJSON(result)
+}
+
+// parseCall looks up a handled method by name, parses its arguments and
+// returns the call as the gateway would decode it along with its calldata.
+// bytes32 arguments may be given as a name, which is namehashed.
+func parseCall(method string, args []string) (*MethodHandler, *Call, []byte, error) {
+	handler, ok := newHandlerRegistry(abi).ByName(method)
+	if !ok {
+		return nil, nil, nil, fmt.Errorf("unknown method %q", method)
+	}
+	inputs := abi.Methods[handler.Name].Inputs
+	if len(args) != len(inputs) {
+		return nil, nil, nil, fmt.Errorf("%s takes %d arguments, got %d", method, len(inputs), len(args))
+	}
+	callArgs := make([]interface{}, len(inputs))
+	for i, input := range inputs {
+		arg, err := parseArg(input.Type, args[i])
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("parsing %s: %w", input.Name, err)
+		}
+		callArgs[i] = arg
+	}
+	calldata, err := abi.Pack(handler.Name, callArgs...)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("packing %s calldata: %w", handler.Name, err)
+	}
+	call, err := handler.Decode(calldata[4:])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return handler, call, calldata, nil
+}
+
+func parseArg(t ethabi.Type, arg string) (interface{}, error) {
+	switch {
+	case t.T == ethabi.FixedBytesTy && t.Size == 32:
+		node, err := parseNode(arg)
+		return [32]byte(node), err
+	case t.T == ethabi.AddressTy:
+		if !common.IsHexAddress(arg) {
+			return nil, fmt.Errorf("invalid address %q", arg)
+		}
+		return common.HexToAddress(arg), nil
+	default:
+		return nil, fmt.Errorf("unsupported argument type %s", t)
+	}
+}
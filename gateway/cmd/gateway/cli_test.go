@@ -0,0 +1,361 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+	"os"
+	"strings"
+	"testing"
+
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var (
+	// ethNode is namehash("eth").
+	ethNode = common.HexToHash("0x93cdeb708b7545dc668eb9280176169d1c33cfd8ed6f04690a0bcc88a93fc4ae")
+	// ethOwnerSlot is keccak256(abi.encode(ethNode, 0)), the owner word of
+	// records[ethNode] in the L2 registry.
+	ethOwnerSlot = common.HexToHash("0x859ecef2e168dc10231b000bd53493b42bc9d944cac29d94582c1e1d43592131")
+	// ethResolverSlot is the word after ethOwnerSlot.
+	ethResolverSlot = common.HexToHash("0x859ecef2e168dc10231b000bd53493b42bc9d944cac29d94582c1e1d43592132")
+)
+
+// runCLI runs a command and returns what it printed.
+func runCLI(t *testing.T, run func(context.Context, []string) error, args ...string) (string, error) {
+	t.Helper()
+	var out bytes.Buffer
+	stdout = &out
+	t.Cleanup(func() { stdout = os.Stdout })
+	err := run(context.Background(), args)
+	return out.String(), err
+}
+
+// packL1Error encodes an L1 registry custom error as revert data.
+func packL1Error(t *testing.T, name string, args ...interface{}) string {
+	t.Helper()
+	abiError := abi.Errors[name]
+	data, err := abiError.Inputs.Pack(args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hexutil.Encode(append(abiError.ID[:4], data...))
+}
+
+func TestParseArg(t *testing.T) {
+	mustType := func(s string) ethabi.Type {
+		typ, err := ethabi.NewType(s, "", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return typ
+	}
+	tests := []struct {
+		name    string
+		typ     string
+		arg     string
+		want    interface{}
+		wantErr bool
+	}{
+		{name: "node hex", typ: "bytes32", arg: ethNode.Hex(), want: [32]byte(ethNode)},
+		{name: "name", typ: "bytes32", arg: "eth", want: [32]byte(ethNode)},
+		{name: "unnormalized name", typ: "bytes32", arg: "ETH", want: [32]byte(ethNode)},
+		{name: "invalid name", typ: "bytes32", arg: "bad name.eth", wantErr: true},
+		{name: "address", typ: "address", arg: registryAddr.Hex(), want: registryAddr},
+		{name: "short address", typ: "address", arg: "0x1234", wantErr: true},
+		{name: "unsupported type", typ: "uint256", arg: "1", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseArg(mustType(tt.typ), tt.arg)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parsed %v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCall(t *testing.T) {
+	tests := []struct {
+		method  string
+		args    []string
+		slot    common.Hash
+		wantErr bool
+	}{
+		{method: "owner", args: []string{"eth"}, slot: ethOwnerSlot},
+		{method: "recordExists", args: []string{"eth"}, slot: ethOwnerSlot},
+		{method: "resolver", args: []string{ethNode.Hex()}, slot: ethResolverSlot},
+		{method: "ttl", args: []string{"eth"}, slot: ethResolverSlot},
+		{method: "owner", args: []string{"eth", "eth"}, wantErr: true},
+		{method: "owner", wantErr: true},
+		{method: "isApprovedForAll", args: []string{registryAddr.Hex(), registryAddr.Hex()}, wantErr: true},
+		{method: "transfer", args: []string{"eth"}, wantErr: true},
+	}
+	g := &Gateway{handlers: newHandlerRegistry(abi)}
+	for _, tt := range tests {
+		t.Run(tt.method+"("+strings.Join(tt.args, ",")+")", func(t *testing.T) {
+			handler, call, calldata, err := parseCall(tt.method, tt.args)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("parsed the call, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if handler.Name != tt.method {
+				t.Fatalf("got handler %s, want %s", handler.Name, tt.method)
+			}
+			if got := common.BytesToHash(call.Slot); got != tt.slot {
+				t.Fatalf("got slot %s, want %s", got, tt.slot)
+			}
+			// The calldata decodes to the same call through the gateway.
+			decoded, decodedCall, err := g.decode(hexutil.Encode(calldata))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if decoded.Name != handler.Name || !bytes.Equal(decodedCall.Slot, call.Slot) {
+				t.Fatalf("calldata decodes to %s with slot %x", decoded.Name, decodedCall.Slot)
+			}
+		})
+	}
+}
+
+func TestRunSlot(t *testing.T) {
+	tests := []struct {
+		args    []string
+		want    common.Hash
+		wantErr bool
+	}{
+		{args: []string{"owner", "eth"}, want: ethOwnerSlot},
+		{args: []string{"resolver", "eth"}, want: ethResolverSlot},
+		{args: []string{"owner"}, wantErr: true},
+		{args: nil, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			out, err := runCLI(t, runSlot, tt.args...)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("printed %q, want an error", out)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := strings.TrimSpace(out); got != tt.want.Hex() {
+				t.Fatalf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunDecode(t *testing.T) {
+	ownerCall, err := abi.Pack("owner", [32]byte(ethNode))
+	if err != nil {
+		t.Fatal(err)
+	}
+	urls := []string{"https://gateway.example/{sender}/{data}.json"}
+	callback := [4]byte(abi.Methods["ownerWithProof"].ID)
+	tests := []struct {
+		name    string
+		data    string
+		error   string
+		method  string
+		chainID int64
+		wantErr bool
+	}{
+		{name: "call", data: hexutil.Encode(ownerCall), method: "owner"},
+		{
+			name:   "OffchainLookup",
+			data:   packL1Error(t, "OffchainLookup", registryAddr, urls, ownerCall, callback, ownerCall),
+			error:  "OffchainLookup",
+			method: "owner",
+		},
+		{
+			name:    "StorageHandledByL2",
+			data:    packL1Error(t, "StorageHandledByL2", big.NewInt(420), registryAddr),
+			error:   "StorageHandledByL2",
+			chainID: 420,
+		},
+		{name: "OffchainLookup for an unserved call", data: packL1Error(t, "OffchainLookup", registryAddr, urls, []byte{1, 2, 3, 4}, callback, []byte{}), wantErr: true},
+		{name: "unknown selector", data: "0xdeadbeef", wantErr: true},
+		{name: "not hex", data: "owner", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := runCLI(t, runDecode, tt.data)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("printed %q, want an error", out)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got struct {
+				DecodedCall
+				Error           string         `json:"error"`
+				Sender          common.Address `json:"sender"`
+				URLs            []string       `json:"urls"`
+				CallData        *DecodedCall   `json:"callData"`
+				ChainID         *big.Int       `json:"chainId"`
+				ContractAddress common.Address `json:"contractAddress"`
+			}
+			if err := json.Unmarshal([]byte(out), &got); err != nil {
+				t.Fatalf("decoding %q: %v", out, err)
+			}
+			if got.Error != tt.error {
+				t.Fatalf("got error %q, want %q", got.Error, tt.error)
+			}
+			call := &got.DecodedCall
+			switch tt.error {
+			case "OffchainLookup":
+				if got.Sender != registryAddr || len(got.URLs) != 1 || got.URLs[0] != urls[0] || got.CallData == nil {
+					t.Fatalf("got %s, want the lookup's sender, URLs and call", out)
+				}
+				call = got.CallData
+			case "StorageHandledByL2":
+				if got.ChainID.Int64() != tt.chainID || got.ContractAddress != registryAddr {
+					t.Fatalf("got chain %s and contract %s, want %d and %s", got.ChainID, got.ContractAddress, tt.chainID, registryAddr)
+				}
+				return
+			}
+			if call.Method.Name != tt.method {
+				t.Fatalf("got method %q, want %q", call.Method.Name, tt.method)
+			}
+			if len(call.Args) != 1 || call.Args[0].Name != "_node" || call.Args[0].Value != ethNode.Hex() {
+				t.Fatalf("got args %+v, want node %s", call.Args, ethNode)
+			}
+		})
+	}
+}
+
+func TestRunVerify(t *testing.T) {
+	root, statedb := newTestState(t)
+	stateRootProof := newTestStateRootProof(root)
+	encode := func(addr common.Address, slot common.Hash) string {
+		encoded, err := (&Gateway{}).encodeWitnesses(stateRootProof, getProof(t, statedb, addr, slot))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return hexutil.Encode(encoded)
+	}
+	otherSlot := common.BytesToHash(getoSLOForOwner(common.HexToHash("0x02")))
+	other := common.HexToAddress("0x02")
+	node := common.HexToHash("0x01").Hex()
+
+	tests := []struct {
+		name    string
+		args    []string
+		slot    common.Hash
+		value   common.Hash
+		result  interface{}
+		absence Absence
+		wantErr bool
+	}{
+		{
+			name:   "owner",
+			args:   []string{"-state-root", root.Hex(), "-node", node, encode(registryAddr, ownerSlot)},
+			slot:   ownerSlot,
+			value:  ownerValue,
+			result: hexutil.Encode(ownerValue[12:]),
+		},
+		{
+			name:   "recordExists",
+			args:   []string{"-state-root", root.Hex(), "-method", "recordExists", "-node", node, encode(registryAddr, ownerSlot)},
+			slot:   ownerSlot,
+			value:  ownerValue,
+			result: true,
+		},
+		{
+			name:  "slot",
+			args:  []string{"-state-root", root.Hex(), "-slot", ownerSlot.Hex(), encode(registryAddr, ownerSlot)},
+			slot:  ownerSlot,
+			value: ownerValue,
+		},
+		{
+			name:    "absent slot",
+			args:    []string{"-state-root", root.Hex(), "-slot", otherSlot.Hex(), encode(registryAddr, otherSlot)},
+			slot:    otherSlot,
+			absence: AbsenceStorage,
+		},
+		{
+			name:    "absent account",
+			args:    []string{"-state-root", root.Hex(), "-registry", other.Hex(), "-slot", ownerSlot.Hex(), encode(other, ownerSlot)},
+			slot:    ownerSlot,
+			absence: AbsenceAccount,
+		},
+		{
+			name:    "other state root",
+			args:    []string{"-state-root", ownerValue.Hex(), "-node", node, encode(registryAddr, ownerSlot)},
+			wantErr: true,
+		},
+		{
+			name:    "truncated proof",
+			args:    []string{"-state-root", root.Hex(), "-node", node, encode(registryAddr, ownerSlot)[:200]},
+			wantErr: true,
+		},
+		{
+			name:    "node and slot",
+			args:    []string{"-state-root", root.Hex(), "-node", node, "-slot", ownerSlot.Hex(), encode(registryAddr, ownerSlot)},
+			wantErr: true,
+		},
+		{
+			name:    "no proof",
+			args:    []string{"-state-root", root.Hex(), "-node", node},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := runCLI(t, runVerify, tt.args...)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("printed %q, want an error", out)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got struct {
+				StateRoot   common.Hash `json:"stateRoot"`
+				BatchIndex  *big.Int    `json:"batchIndex"`
+				BlockNumber *big.Int    `json:"blockNumber"`
+				Slot        common.Hash `json:"slot"`
+				Value       common.Hash `json:"value"`
+				Result      interface{} `json:"result"`
+				Exists      bool        `json:"exists"`
+				Absence     Absence     `json:"absence"`
+			}
+			if err := json.Unmarshal([]byte(out), &got); err != nil {
+				t.Fatalf("decoding %q: %v", out, err)
+			}
+			if got.StateRoot != root || got.BatchIndex.Int64() != 7 || got.BlockNumber.Int64() != 0x10+1+1 {
+				t.Fatalf("got state root %s in batch %s for block %s, want %s in batch 7 for block 18", got.StateRoot, got.BatchIndex, got.BlockNumber, root)
+			}
+			if got.Slot != tt.slot || got.Value != tt.value || got.Result != tt.result {
+				t.Fatalf("got slot %s = %s (result %v), want %s = %s (result %v)", got.Slot, got.Value, got.Result, tt.slot, tt.value, tt.result)
+			}
+			if got.Exists != (tt.absence == AbsenceNone) || got.Absence != tt.absence {
+				t.Fatalf("got exists %v absence %q, want absence %q", got.Exists, got.Absence, tt.absence)
+			}
+		})
+	}
+}
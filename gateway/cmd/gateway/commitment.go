@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+const (
+	// commitmentMaxWait caps the wait parameter, keeping long polls inside
+	// the default HTTP_WRITE_TIMEOUT.
+	commitmentMaxWait = 30 * time.Second
+	// commitmentPollInterval is the time between checks while waiting.
+	commitmentPollInterval = 2 * time.Second
+)
+
+// Commitment statuses, in the order an L2 transaction goes through them.
+const (
+	// CommitmentUnknown transactions are neither mined nor in the L2's
+	// transaction pool.
+	CommitmentUnknown = "unknown"
+	// CommitmentPending transactions are in the L2's transaction pool.
+	CommitmentPending = "pending"
+	// CommitmentIncluded transactions are mined on L2, but no state batch
+	// committed to L1 covers their block yet, so gateway reads do not see
+	// them.
+	CommitmentIncluded = "included"
+	// CommitmentCommitted transactions are in a state batch committed to L1.
+	CommitmentCommitted = "committed"
+	// CommitmentProvable transactions are committed and the state root
+	// provider proves their block, so gateway reads see them.
+	CommitmentProvable = "provable"
+)
+
+var commitmentRank = map[string]int{
+	CommitmentUnknown:   0,
+	CommitmentPending:   1,
+	CommitmentIncluded:  2,
+	CommitmentCommitted: 3,
+	CommitmentProvable:  4,
+}
+
+// CommitmentStatus reports how far an L2 transaction is from being readable
+// through the gateway.
+type CommitmentStatus struct {
+	TxHash common.Hash `json:"txHash"`
+	Status string      `json:"status"`
+	// Reverted is set for included transactions that reverted, whose writes
+	// will never be readable.
+	Reverted    bool         `json:"reverted,omitempty"`
+	BlockNumber *big.Int     `json:"blockNumber,omitempty"`
+	BlockHash   *common.Hash `json:"blockHash,omitempty"`
+	// BatchIndex and BatchRoot identify the state batch committing the
+	// block, and StateRoot its state root, once provable.
+	BatchIndex *big.Int `json:"batchIndex,omitempty"`
+	BatchRoot  string   `json:"batchRoot,omitempty"`
+	StateRoot  string   `json:"stateRoot,omitempty"`
+	// LatestCommittedBlock is the last L2 block committed to L1.
+	LatestCommittedBlock *big.Int `json:"latestCommittedBlock,omitempty"`
+	// Error explains why a committed transaction is not provable yet.
+	Error string `json:"error,omitempty"`
+}
+
+func (CommitmentStatus) Render(w http.ResponseWriter, r *http.Request) error {
+	w.Header().Set("Cache-Control", "no-store")
+	return nil
+}
+
+// reached reports whether the status is at or past until.
+func (s *CommitmentStatus) reached(until string) bool {
+	return s.Reverted || commitmentRank[s.Status] >= commitmentRank[until]
+}
+
+// commitment reports the commitment status of the L2 transaction hash.
+func (g *Gateway) commitment(ctx context.Context, hash common.Hash) (*CommitmentStatus, error) {
+	status := &CommitmentStatus{TxHash: hash, Status: CommitmentUnknown}
+	receipt, err := g.l2.TransactionReceipt(ctx, hash)
+	if errors.Is(err, ethereum.NotFound) {
+		if _, _, err := g.l2.TransactionByHash(ctx, hash); err == nil {
+			status.Status = CommitmentPending
+		} else if !errors.Is(err, ethereum.NotFound) {
+			return nil, badGateway(fmt.Errorf("getting transaction: %w", err))
+		}
+		return status, nil
+	}
+	if err != nil {
+		return nil, badGateway(fmt.Errorf("getting receipt: %w", err))
+	}
+	blockHash := receipt.BlockHash
+	status.Status = CommitmentIncluded
+	status.Reverted = receipt.Status != types.ReceiptStatusSuccessful
+	status.BlockNumber, status.BlockHash = receipt.BlockNumber, &blockHash
+
+	batch, err := g.stateRoots.LatestStateBatch(ctx)
+	if err != nil {
+		return nil, badGateway(fmt.Errorf("getting latest state batch: %w", err))
+	}
+	status.LatestCommittedBlock = batch.LastL2BlockNumber()
+	if receipt.BlockNumber.Cmp(status.LatestCommittedBlock) > 0 {
+		return status, nil
+	}
+	status.Status = CommitmentCommitted
+	if receipt.BlockNumber.Cmp(batch.PrevTotalElements.Int()) > 0 {
+		status.BatchIndex, status.BatchRoot = batch.BatchIndex.Int(), batch.BatchRoot
+	}
+
+	// Any slot proves the block's state root; the registry's first is as
+	// good as another.
+	selector := ProofSelector{Block: receipt.BlockNumber}
+	proof, err := g.stateRoots.StateRootProof(ctx, selector.stateRootProofInput(g.l2ResolverAddress.Hex(), common.Hash{}.Hex()))
+	if err == nil {
+		err = g.checkCommitmentProof(ctx, proof, receipt.BlockNumber)
+	}
+	if err != nil {
+		status.Error = err.Error()
+		return status, nil
+	}
+	status.Status = CommitmentProvable
+	status.BatchIndex, status.BatchRoot = proof.BatchIndex(), proof.StateRootBatchHeader.BatchRoot
+	status.StateRoot = proof.StateRoot
+	return status, nil
+}
+
+// checkCommitmentProof checks that proof is for block and commits to the
+// state root the L2 reports for it.
+func (g *Gateway) checkCommitmentProof(ctx context.Context, proof *StateRootProof, block *big.Int) error {
+	if err := proof.Validate(); err != nil {
+		return fmt.Errorf("invalid state root proof: %w", err)
+	}
+	if proven := proof.L2BlockNumber(); proven.Cmp(block) != 0 {
+		return fmt.Errorf("state root proof is for block %s, not %s", proven, block)
+	}
+	header, err := g.l2.HeaderByNumber(ctx, block)
+	if err != nil {
+		return fmt.Errorf("getting L2 block %s: %w", block, err)
+	}
+	if root := common.HexToHash(proof.StateRoot); root != header.Root {
+		return fmt.Errorf("committed state root %s, L2 block %s has %s", root, block, header.Root)
+	}
+	return nil
+}
+
+// getCommitment reports whether writes made by an L2 transaction are
+// readable through the gateway yet. With wait, it long-polls until the
+// transaction reaches the until status (provable by default), reverts, or
+// wait passes.
+func (g *Gateway) getCommitment(w http.ResponseWriter, r *http.Request) {
+	hash, err := parseHash(chi.URLParam(r, "txHash"))
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(fmt.Errorf("parsing txHash: %w", err)))
+		return
+	}
+	query := r.URL.Query()
+	var wait time.Duration
+	if s := query.Get("wait"); s != "" {
+		if wait, err = time.ParseDuration(s); err != nil || wait < 0 {
+			render.Render(w, r, ErrInvalidRequest(fmt.Errorf("invalid wait %q", s)))
+			return
+		}
+		if wait > commitmentMaxWait {
+			wait = commitmentMaxWait
+		}
+	}
+	until := CommitmentProvable
+	if s := query.Get("until"); s != "" {
+		if _, ok := commitmentRank[s]; !ok {
+			render.Render(w, r, ErrInvalidRequest(fmt.Errorf("invalid until %q", s)))
+			return
+		}
+		until = s
+	}
+	setLogFields(r.Context(), map[string]interface{}{"tx": hash.Hex()})
+
+	ctx, cancel := context.WithTimeout(r.Context(), wait)
+	defer cancel()
+	ticker := time.NewTicker(commitmentPollInterval)
+	defer ticker.Stop()
+	for {
+		// Checks use the request's context so the last one is not cut short
+		// by the wait.
+		status, err := g.commitment(r.Context(), hash)
+		if err != nil {
+			errResponse := ErrRender(err)
+			requestLogger(r.Context()).WithLevel(statusLevel(errResponse.HTTPStatusCode)).Err(err).Msg("checking commitment failed")
+			render.Render(w, r, errResponse)
+			return
+		}
+		if status.reached(until) {
+			render.Render(w, r, status)
+			return
+		}
+		select {
+		case <-ctx.Done():
+			render.Render(w, r, status)
+			return
+		case <-ticker.C:
+		}
+	}
+}
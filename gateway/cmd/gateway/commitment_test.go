@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// fakeL2 mines one transaction in block 5, whose state root is root.
+type fakeL2 struct {
+	L2Client
+	tx   common.Hash
+	root common.Hash
+}
+
+func (l2 *fakeL2) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	if hash != l2.tx {
+		return nil, ethereum.NotFound
+	}
+	return &types.Receipt{Status: types.ReceiptStatusSuccessful, BlockNumber: big.NewInt(5)}, nil
+}
+
+func (l2 *fakeL2) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	return nil, false, ethereum.NotFound
+}
+
+func (l2 *fakeL2) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return &types.Header{Number: number, Root: l2.root}, nil
+}
+
+// fakeStateRoots has committed batches of two blocks up to lastBlock, and
+// proves any committed block with root.
+type fakeStateRoots struct {
+	lastBlock int64
+	root      common.Hash
+}
+
+func bigNumber(n int64) BigNumber {
+	return BigNumber{Type: "BigNumber", Hex: fmt.Sprintf("0x%02x", n)}
+}
+
+func (s *fakeStateRoots) header(batch int64) StateRootBatchHeader {
+	return StateRootBatchHeader{
+		BatchIndex:        bigNumber(batch),
+		BatchRoot:         common.Hash{byte(batch)}.Hex(),
+		BatchSize:         bigNumber(2),
+		PrevTotalElements: bigNumber(batch * 2),
+		ExtraData:         "0x",
+	}
+}
+
+func (s *fakeStateRoots) LatestStateBatch(ctx context.Context) (*StateRootBatchHeader, error) {
+	h := s.header(s.lastBlock/2 - 1)
+	return &h, nil
+}
+
+func (s *fakeStateRoots) StateRootProof(ctx context.Context, input StateRootProofInput) (*StateRootProof, error) {
+	block, _ := new(big.Int).SetString(input.BlockNumber, 10)
+	if block.Int64() > s.lastBlock {
+		return nil, fmt.Errorf("block %s not committed", block)
+	}
+	proof := &StateRootProof{StateRoot: s.root.Hex()}
+	proof.StateRootBatchHeader = s.header((block.Int64() - 1) / 2)
+	proof.StateRootProof.Index = int((block.Int64() - 1) % 2)
+	return proof, nil
+}
+
+func TestCommitment(t *testing.T) {
+	tx, root := common.HexToHash("0x01"), common.HexToHash("0x02")
+	stateRoots := &fakeStateRoots{lastBlock: 4, root: root}
+	g := &Gateway{l2: &fakeL2{tx: tx, root: root}, stateRoots: stateRoots}
+	ctx := context.Background()
+
+	status, err := g.commitment(ctx, common.HexToHash("0x03"))
+	if err != nil || status.Status != CommitmentUnknown {
+		t.Fatalf("unmined transaction: %+v, %v; want unknown", status, err)
+	}
+	status, err = g.commitment(ctx, tx)
+	if err != nil || status.Status != CommitmentIncluded || status.LatestCommittedBlock.Int64() != 4 {
+		t.Fatalf("before its batch: %+v, %v; want included with block 4 committed", status, err)
+	}
+
+	// Block 5 is the first of batch 2.
+	stateRoots.lastBlock = 6
+	status, err = g.commitment(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Status != CommitmentProvable || status.BatchIndex.Int64() != 2 || status.StateRoot != root.Hex() {
+		t.Errorf("after its batch: %+v; want provable in batch 2 with root %s", status, root)
+	}
+
+	g.l2 = &fakeL2{tx: tx, root: common.HexToHash("0x04")}
+	status, err = g.commitment(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Status != CommitmentCommitted || status.Error == "" {
+		t.Errorf("with a mismatched state root: %+v; want committed with an error", status)
+	}
+}
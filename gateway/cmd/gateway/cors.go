@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/cors"
+)
+
+// corsOptionsFromEnv reads the CORS policy for the gateway routes:
+//
+//	CORS_ALLOWED_ORIGINS=*                         comma separated, * allows any
+//	CORS_ALLOWED_METHODS=GET,POST,OPTIONS
+//	CORS_ALLOWED_HEADERS=Accept,Content-Type,X-API-Key
+//	CORS_MAX_AGE=300                               preflight cache in seconds
+func corsOptionsFromEnv() (cors.Options, error) {
+	maxAge, err := strconv.Atoi(GetOrDefault("CORS_MAX_AGE", "300"))
+	if err != nil {
+		return cors.Options{}, fmt.Errorf("parsing CORS_MAX_AGE: %w", err)
+	}
+	return cors.Options{
+		AllowedOrigins: splitList(GetOrDefault("CORS_ALLOWED_ORIGINS", "*")),
+		AllowedMethods: splitList(GetOrDefault("CORS_ALLOWED_METHODS", "GET,POST,OPTIONS")),
+		AllowedHeaders: splitList(GetOrDefault("CORS_ALLOWED_HEADERS", "Accept,Content-Type,X-API-Key")),
+		ExposedHeaders: []string{"Retry-After"},
+		MaxAge:         maxAge,
+	}, nil
+}
+
+// corsHandler answers preflight requests and sets CORS headers. Mount it on a
+// sub-router so it runs before routing, otherwise OPTIONS requests never reach
+// it.
+func corsHandler(options cors.Options) func(http.Handler) http.Handler {
+	return cors.Handler(options)
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/cors"
+)
+
+func newCORSTestRouter(t *testing.T, origins ...string) http.Handler {
+	t.Helper()
+	rateLimiter, err := NewRateLimiter(RateLimitConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	options := cors.Options{
+		AllowedOrigins: origins,
+		AllowedMethods: []string{"GET", "POST", "OPTIONS"},
+		AllowedHeaders: []string{"Accept", "Content-Type", "X-API-Key"},
+		ExposedHeaders: []string{"Retry-After"},
+		MaxAge:         300,
+	}
+	g := &Gateway{}
+	// A known chain ID keeps /capabilities from asking the L2 RPC.
+	g.capabilities.L2.ChainID = big.NewInt(420)
+	return g.routes(rateLimiter, options, false)
+}
+
+func TestCORSPreflight(t *testing.T) {
+	r := newCORSTestRouter(t, "*")
+	for _, path := range []string{
+		"/gateway/0x1111111111111111111111111111111111111111/0x.json",
+		"/gateway/",
+		"/v1/name/alice.eth/owner",
+		"/capabilities",
+	} {
+		t.Run(path, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodOptions, path, nil)
+			req.Header.Set("Origin", "https://app.example")
+			req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+			req.Header.Set("Access-Control-Request-Headers", "X-API-Key")
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			if rec.Code >= 300 {
+				t.Fatalf("preflight status %d", rec.Code)
+			}
+			if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+				t.Errorf("Access-Control-Allow-Origin %q, want *", got)
+			}
+			if got := rec.Header().Get("Access-Control-Allow-Methods"); !strings.Contains(got, http.MethodGet) {
+				t.Errorf("Access-Control-Allow-Methods %q, want GET", got)
+			}
+			if got := rec.Header().Get("Access-Control-Allow-Headers"); !strings.Contains(got, "X-Api-Key") {
+				t.Errorf("Access-Control-Allow-Headers %q, want X-API-Key", got)
+			}
+			if got := rec.Header().Get("Access-Control-Max-Age"); got != "300" {
+				t.Errorf("Access-Control-Max-Age %q, want 300", got)
+			}
+		})
+	}
+}
+
+func TestCORSDisallowedOrigin(t *testing.T) {
+	r := newCORSTestRouter(t, "https://app.example")
+	req := httptest.NewRequest(http.MethodOptions, "/v1/name/alice.eth/owner", nil)
+	req.Header.Set("Origin", "https://evil.example")
+	req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("Access-Control-Allow-Origin %q for a disallowed origin", got)
+	}
+}
+
+// Rate limiter rejections must carry CORS headers, and be JSON, or browsers
+// can't read them.
+func TestCORSOnRejection(t *testing.T) {
+	r := newCORSTestRouter(t, "*")
+	req := httptest.NewRequest(http.MethodGet, "/gateway/0x1111111111111111111111111111111111111111/0x.json", nil)
+	req.Header.Set("Origin", "https://app.example")
+	req.Header.Set("X-API-Key", "unknown")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("status %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Errorf("Access-Control-Allow-Origin %q, want *", got)
+	}
+	if got := rec.Header().Get("Access-Control-Expose-Headers"); got != "Retry-After" {
+		t.Errorf("Access-Control-Expose-Headers %q, want Retry-After", got)
+	}
+	if got := rec.Header().Get("Content-Type"); !strings.HasPrefix(got, "application/json") {
+		t.Errorf("Content-Type %q, want JSON", got)
+	}
+}
+
+func TestCORSCapabilities(t *testing.T) {
+	r := newCORSTestRouter(t, "*")
+	req := httptest.NewRequest(http.MethodGet, "/capabilities", nil)
+	req.Header.Set("Origin", "https://app.example")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d, want %d", rec.Code, http.StatusOK)
+	}
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Errorf("Access-Control-Allow-Origin %q, want *", got)
+	}
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+
+	"github.com/0xpaulio/eth-sf-ens-rr/verifier"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/render"
+)
+
+// debugEndpointsFromEnv reports whether DEBUG_ENDPOINTS enables the /debug
+// routes. They expose full proofs and bypass the proof cache, so they are
+// off by default.
+func debugEndpointsFromEnv() (bool, error) {
+	enabled, err := strconv.ParseBool(GetOrDefault("DEBUG_ENDPOINTS", "false"))
+	if err != nil {
+		return false, fmt.Errorf("parsing DEBUG_ENDPOINTS: %w", err)
+	}
+	return enabled, nil
+}
+
+// DebugProofResponse is a gateway response together with everything that
+// went into it.
+type DebugProofResponse struct {
+	Sender   string           `json:"sender"`
+	Calldata string           `json:"calldata"`
+	Selector string           `json:"selector"`
+	Method   MethodCapability `json:"method"`
+	Args     []DebugArg       `json:"args"`
+	Name     string           `json:"name,omitempty"`
+	Slot     common.Hash      `json:"slot"`
+
+	StateRoot   common.Hash `json:"stateRoot"`
+	BlockNumber *big.Int    `json:"blockNumber"`
+	BlockTime   uint64      `json:"blockTime"`
+
+	AccountProof DebugAccountProof `json:"accountProof"`
+	StateProof   DebugStateProof   `json:"stateProof"`
+	Proven       DebugProven       `json:"proven"`
+
+	// Data is the ABI-encoded L2StateProof the gateway would return.
+	Data string `json:"data"`
+}
+
+type DebugArg struct {
+	Name  string      `json:"name"`
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+// DebugAccountProof is the eth_getProof (EIP-1186) result.
+type DebugAccountProof struct {
+	Address      common.Address      `json:"address"`
+	AccountProof []string            `json:"accountProof"`
+	Balance      *hexutil.Big        `json:"balance"`
+	CodeHash     common.Hash         `json:"codeHash"`
+	Nonce        hexutil.Uint64      `json:"nonce"`
+	StorageHash  common.Hash         `json:"storageHash"`
+	StorageProof []DebugStorageProof `json:"storageProof"`
+}
+
+type DebugStorageProof struct {
+	Key   string       `json:"key"`
+	Value *hexutil.Big `json:"value"`
+	Proof []string     `json:"proof"`
+}
+
+// DebugStateProof is the L2StateProof passed to the L1 callback, with hashes and bytes
+// rendered as hex.
+type DebugStateProof struct {
+	StateRoot            common.Hash `json:"stateRoot"`
+	StateRootBatchHeader struct {
+		BatchIndex        *big.Int      `json:"batchIndex"`
+		BatchRoot         common.Hash   `json:"batchRoot"`
+		BatchSize         *big.Int      `json:"batchSize"`
+		PrevTotalElements *big.Int      `json:"prevTotalElements"`
+		ExtraData         hexutil.Bytes `json:"extraData"`
+	} `json:"stateRootBatchHeader"`
+	StateRootProof struct {
+		Index    *big.Int      `json:"index"`
+		Siblings []common.Hash `json:"siblings"`
+	} `json:"stateRootProof"`
+	StateTrieWitness   hexutil.Bytes `json:"stateTrieWitness"`
+	StorageTrieWitness hexutil.Bytes `json:"storageTrieWitness"`
+}
+
+// DebugProven is the proven storage word and what the callback decodes from
+// it.
+type DebugProven struct {
+	Value   common.Hash `json:"value"`
+	Result  interface{} `json:"result"`
+	Exists  bool        `json:"exists"`
+	Absence Absence     `json:"absence,omitempty"`
+}
+
+func (d *DebugProofResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	w.Header().Set("Cache-Control", "no-store")
+	render.Status(r, http.StatusOK)
+	return nil
+}
+
+func (g *Gateway) getDebugProof(w http.ResponseWriter, r *http.Request) {
+	g.serveDebugProof(w, r, gatewayRequestFromURL(r))
+}
+
+func (g *Gateway) postDebugProof(w http.ResponseWriter, r *http.Request) {
+	req, err := gatewayRequestFromBody(r)
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	g.serveDebugProof(w, r, req)
+}
+
+// serveDebugProof proves req like serveGateway, always generating a fresh
+// proof, and returns its decoded form.
+func (g *Gateway) serveDebugProof(w http.ResponseWriter, r *http.Request, req *GatewayRequest) {
+	setLogFields(r.Context(), map[string]interface{}{
+		"sender": req.Sender,
+		"method": g.methodName(req.Data),
+	})
+	if !g.senders.SupportsSender(req.Sender) {
+		render.Render(w, r, ErrInvalidRequest(fmt.Errorf("unsupported sender %s", req.Sender)))
+		return
+	}
+	selector, err := ParseProofSelector(req.Block, req.BatchIndex, req.StateRoot)
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	proof, err := g.prove(r.Context(), req.Data, selector)
+	if err != nil {
+		render.Render(w, r, ErrRender(err))
+		return
+	}
+	render.Render(w, r, newDebugProofResponse(req, selector, proof))
+}
+
+func newDebugProofResponse(req *GatewayRequest, selector ProofSelector, proof *ProofResult) *DebugProofResponse {
+	debug := proof.Debug
+	resp := &DebugProofResponse{
+		Sender:       req.Sender,
+		Calldata:     req.Data,
+		Selector:     selector.String(),
+		Method:       methodCapability(debug.Handler),
+		Name:         proof.Name,
+		Slot:         proof.Slot,
+		StateRoot:    proof.StateRoot,
+		BlockNumber:  proof.BlockNumber,
+		BlockTime:    proof.BlockTime,
+		AccountProof: newDebugAccountProof(debug),
+		StateProof:   newDebugStateProof(convertToContractProof(debug.StateRootProof)),
+		Proven: DebugProven{
+			Value:   proof.Value,
+			Result:  debug.Handler.Result(proof.Value),
+			Exists:  proof.Exists(),
+			Absence: proof.Absence,
+		},
+		Data: hexutil.Encode(proof.Encoded),
+	}
+	resp.Args = debugArgs(debug.Handler, debug.Call)
+	return resp
+}
+
+// debugArgs pairs decoded call arguments with their ABI names and types.
+func debugArgs(handler *MethodHandler, call *Call) []DebugArg {
+	var debugArgs []DebugArg
+	args := call.Args.Values()
+	for i, input := range abi.Methods[handler.Name].Inputs {
+		if i >= len(args) {
+			break
+		}
+		debugArgs = append(debugArgs, DebugArg{
+			Name:  input.Name,
+			Type:  input.Type.String(),
+			Value: debugArgValue(args[i]),
+		})
+	}
+	return debugArgs
+}
+
+// debugArgValue renders fixed size byte arrays as hex rather than as JSON
+// number arrays.
+func debugArgValue(arg interface{}) interface{} {
+	if b, ok := arg.([32]byte); ok {
+		return common.Hash(b)
+	}
+	return arg
+}
+
+func newDebugAccountProof(debug *ProofDebug) DebugAccountProof {
+	res := debug.Account
+	proof := DebugAccountProof{
+		Address:      res.Address,
+		AccountProof: res.AccountProof,
+		Balance:      (*hexutil.Big)(res.Balance),
+		CodeHash:     res.CodeHash,
+		Nonce:        hexutil.Uint64(res.Nonce),
+		StorageHash:  res.StorageHash,
+	}
+	for _, s := range res.StorageProof {
+		proof.StorageProof = append(proof.StorageProof, DebugStorageProof{
+			Key:   s.Key,
+			Value: (*hexutil.Big)(s.Value),
+			Proof: s.Proof,
+		})
+	}
+	return proof
+}
+
+func newDebugStateProof(sp *verifier.L2StateProof) DebugStateProof {
+	var proof DebugStateProof
+	proof.StateRoot = sp.StateRoot
+	proof.StateRootBatchHeader.BatchIndex = sp.StateRootBatchHeader.BatchIndex
+	proof.StateRootBatchHeader.BatchRoot = sp.StateRootBatchHeader.BatchRoot
+	proof.StateRootBatchHeader.BatchSize = sp.StateRootBatchHeader.BatchSize
+	proof.StateRootBatchHeader.PrevTotalElements = sp.StateRootBatchHeader.PrevTotalElements
+	proof.StateRootBatchHeader.ExtraData = sp.StateRootBatchHeader.ExtraData
+	proof.StateRootProof.Index = sp.StateRootProof.Index
+	for _, sibling := range sp.StateRootProof.Siblings {
+		proof.StateRootProof.Siblings = append(proof.StateRootProof.Siblings, sibling)
+	}
+	proof.StateTrieWitness = sp.StateTrieWitness
+	proof.StorageTrieWitness = sp.StorageTrieWitness
+	return proof
+}
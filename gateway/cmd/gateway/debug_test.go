@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bytes"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/0xpaulio/eth-sf-ens-rr/verifier"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/cors"
+)
+
+func TestDebugEndpointsFromEnv(t *testing.T) {
+	t.Setenv("DEBUG_ENDPOINTS", "")
+	os.Unsetenv("DEBUG_ENDPOINTS")
+	if enabled, err := debugEndpointsFromEnv(); err != nil || enabled {
+		t.Fatalf("got %v, %v with DEBUG_ENDPOINTS unset, want the routes off", enabled, err)
+	}
+	for value, want := range map[string]bool{"true": true, "1": true, "false": false} {
+		t.Setenv("DEBUG_ENDPOINTS", value)
+		if enabled, err := debugEndpointsFromEnv(); err != nil || enabled != want {
+			t.Errorf("DEBUG_ENDPOINTS=%s: got %v, %v, want %v", value, enabled, err, want)
+		}
+	}
+	t.Setenv("DEBUG_ENDPOINTS", "maybe")
+	if _, err := debugEndpointsFromEnv(); err == nil {
+		t.Error("DEBUG_ENDPOINTS=maybe accepted")
+	}
+}
+
+func TestDebugRoutes(t *testing.T) {
+	rateLimiter, err := NewRateLimiter(RateLimitConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, debugEndpoints := range []bool{false, true} {
+		g := &Gateway{}
+		r := g.routes(rateLimiter, cors.Options{}, debugEndpoints)
+		for _, req := range []*http.Request{
+			httptest.NewRequest(http.MethodGet, "/debug/proof/0x1111111111111111111111111111111111111111/0x.json", nil),
+			httptest.NewRequest(http.MethodPost, "/debug/proof", bytes.NewBufferString("{")),
+		} {
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+			// Enabled routes reject the malformed request before proving it.
+			want := http.StatusNotFound
+			if debugEndpoints {
+				want = http.StatusBadRequest
+			}
+			if rec.Code != want {
+				t.Errorf("debug endpoints %v: %s %s got status %d, want %d", debugEndpoints, req.Method, req.URL.Path, rec.Code, want)
+			}
+		}
+	}
+}
+
+func TestNewDebugProofResponse(t *testing.T) {
+	root, statedb := newTestState(t)
+	res := getProof(t, statedb, registryAddr, ownerSlot)
+	stateRootProof := newTestStateRootProof(root)
+	encoded, err := (&Gateway{}).encodeWitnesses(stateRootProof, res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	node := common.HexToHash("0x01")
+	calldata, err := abi.Pack("owner", [32]byte(node))
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler, ok := newHandlerRegistry(abi).ByName("owner")
+	if !ok {
+		t.Fatal("owner is not handled")
+	}
+	call, err := handler.Decode(calldata[4:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := &GatewayRequest{Sender: registryAddr.Hex(), Data: hexutil.Encode(calldata)}
+	proof := &ProofResult{
+		MethodName:  "owner",
+		Node:        &node,
+		Slot:        ownerSlot,
+		StateRoot:   root,
+		BlockNumber: big.NewInt(18),
+		BlockTime:   1_700_000_000,
+		Encoded:     encoded,
+		Value:       ownerValue,
+		Debug: &ProofDebug{
+			Handler:        handler,
+			Call:           call,
+			StateRootProof: stateRootProof,
+			Account:        res,
+		},
+	}
+
+	resp := newDebugProofResponse(req, ProofSelector{}, proof)
+	if resp.Method.Name != "owner" || resp.Method.Callback != "ownerWithProof" || resp.Selector != "latest" {
+		t.Errorf("got method %+v for selector %s, want owner for latest", resp.Method, resp.Selector)
+	}
+	if len(resp.Args) != 1 || resp.Args[0] != (DebugArg{Name: "_node", Type: "bytes32", Value: node}) {
+		t.Errorf("got args %+v, want the node", resp.Args)
+	}
+	if resp.Slot != ownerSlot || resp.StateRoot != root || resp.BlockNumber.Int64() != 18 {
+		t.Errorf("got slot %s at root %s block %s", resp.Slot, resp.StateRoot, resp.BlockNumber)
+	}
+	if resp.Proven.Value != ownerValue || resp.Proven.Result != common.BytesToAddress(ownerValue[:]) || !resp.Proven.Exists {
+		t.Errorf("got proven %+v, want owner %s", resp.Proven, common.BytesToAddress(ownerValue[:]))
+	}
+	if resp.AccountProof.Address != registryAddr || len(resp.AccountProof.StorageProof) != 1 || resp.AccountProof.StorageProof[0].Key != ownerSlot.Hex() {
+		t.Errorf("got account proof for %s with storage proof %+v", resp.AccountProof.Address, resp.AccountProof.StorageProof)
+	}
+	if resp.Data != hexutil.Encode(encoded) {
+		t.Errorf("got data %s, want the encoded proof", resp.Data)
+	}
+
+	// The state proof renders the same L2StateProof the data encodes.
+	sp := resp.StateProof
+	want, err := verifier.Decode(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sp.StateRoot != root || sp.StateRoot != want.StateRoot {
+		t.Errorf("got state proof root %s, want %s", sp.StateRoot, root)
+	}
+	header := sp.StateRootBatchHeader
+	if header.BatchIndex.Int64() != 7 || header.BatchSize.Int64() != 2 || header.PrevTotalElements.Int64() != 0x10 ||
+		header.BatchRoot != want.StateRootBatchHeader.BatchRoot || len(header.ExtraData) != 0 {
+		t.Errorf("got batch header %+v", header)
+	}
+	if sp.StateRootProof.Index.Int64() != 1 || len(sp.StateRootProof.Siblings) != 1 || sp.StateRootProof.Siblings[0] != common.HexToHash("0x02") {
+		t.Errorf("got state root proof %+v", sp.StateRootProof)
+	}
+	if !bytes.Equal(sp.StateTrieWitness, want.StateTrieWitness) || !bytes.Equal(sp.StorageTrieWitness, want.StorageTrieWitness) || len(sp.StorageTrieWitness) == 0 {
+		t.Errorf("state proof witnesses differ from the encoded proof")
+	}
+}
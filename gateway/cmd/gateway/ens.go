@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// namehash computes the EIP-137 node of an already normalised name.
+func namehash(name string) common.Hash {
+	var node common.Hash
+	if name == "" {
+		return node
+	}
+	labels := strings.Split(name, ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		label := crypto.Keccak256Hash([]byte(labels[i]))
+		node = crypto.Keccak256Hash(node[:], label[:])
+	}
+	return node
+}
+
+// parseNode parses a node given as 32 byte hex, or as a name to normalise
+// and namehash.
+func parseNode(s string) (common.Hash, error) {
+	if len(s) == 2+2*common.HashLength && strings.HasPrefix(s, "0x") {
+		return parseHash(s)
+	}
+	name, err := normalizeName(s)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return namehash(name), nil
+}
+
+func parseHash(s string) (common.Hash, error) {
+	b, err := hexutil.Decode(s)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if len(b) != common.HashLength {
+		return common.Hash{}, fmt.Errorf("expected 32 bytes, got %d", len(b))
+	}
+	return common.BytesToHash(b), nil
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/render"
+)
+
+// ErrHistoricalStateUnavailable is returned when a historical proof is
+// requested from an L2 RPC that does not keep archival state.
+var ErrHistoricalStateUnavailable = errors.New("historical state unavailable: L2 RPC is not an archive node")
+
+// gatewayError carries the HTTP status a pipeline failure should surface as.
+type gatewayError struct {
+	status int
+	err    error
+}
+
+func (e *gatewayError) Error() string {
+	return e.err.Error()
+}
+
+func (e *gatewayError) Unwrap() error {
+	return e.err
+}
+
+func badRequest(err error) error {
+	return &gatewayError{status: http.StatusBadRequest, err: err}
+}
+
+func badGateway(err error) error {
+	return &gatewayError{status: http.StatusBadGateway, err: err}
+}
+
+type ErrResponse struct {
+	Err            error `json:"-"`
+	HTTPStatusCode int   `json:"-"`
+
+	StatusText string `json:"status"`
+	ErrorText  string `json:"error,omitempty"`
+}
+
+func (e *ErrResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	w.Header().Set("Cache-Control", "no-store")
+	render.Status(r, e.HTTPStatusCode)
+	return nil
+}
+
+func ErrInvalidRequest(err error) render.Renderer {
+	return &ErrResponse{
+		Err:            err,
+		HTTPStatusCode: http.StatusBadRequest,
+		StatusText:     http.StatusText(http.StatusBadRequest),
+		ErrorText:      err.Error(),
+	}
+}
+
+// ErrRender maps a pipeline error to its response. Errors without an explicit
+// status are reported as internal errors.
+func ErrRender(err error) *ErrResponse {
+	status := http.StatusInternalServerError
+	var gwErr *gatewayError
+	if errors.As(err, &gwErr) {
+		status = gwErr.status
+	}
+	return &ErrResponse{
+		Err:            err,
+		HTTPStatusCode: status,
+		StatusText:     http.StatusText(status),
+		ErrorText:      err.Error(),
+	}
+}
+
+func ErrUnauthorized(err error) render.Renderer {
+	return &ErrResponse{
+		Err:            err,
+		HTTPStatusCode: http.StatusUnauthorized,
+		StatusText:     http.StatusText(http.StatusUnauthorized),
+		ErrorText:      err.Error(),
+	}
+}
+
+func ErrTooManyRequests(err error) render.Renderer {
+	return &ErrResponse{
+		Err:            err,
+		HTTPStatusCode: http.StatusTooManyRequests,
+		StatusText:     http.StatusText(http.StatusTooManyRequests),
+		ErrorText:      err.Error(),
+	}
+}
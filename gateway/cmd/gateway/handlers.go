@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/0xpaulio/eth-sf-ens-rr/bindings"
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// MethodHandler serves one L1 registry method. The L1 registry reverts the
+// method with OffchainLookup naming Callback, which verifies the gateway's
+// proof of the storage slot Decode derives from the call arguments.
+type MethodHandler struct {
+	Name             string
+	Callback         string
+	Selector         [4]byte
+	CallbackSelector [4]byte
+	// Decode unpacks calldata, selector removed, into the method's typed
+	// arguments and the storage slot the callback reads.
+	Decode func(params []byte) (*Call, error)
+	// Result decodes the proven storage word as the callback returns it.
+	Result func(word common.Hash) interface{}
+}
+
+// Call is a decoded call to a handled method.
+type Call struct {
+	// Args are the method's typed arguments from the bindings package.
+	Args CallArgs
+	// Node is the node the call reads, if the method takes one.
+	Node *common.Hash
+	// Slot is the L2 registry storage slot the callback reads.
+	Slot []byte
+}
+
+// CallArgs is implemented by the generated argument structs.
+type CallArgs interface {
+	Values() []interface{}
+}
+
+// HandlerRegistry maps function selectors to the methods the gateway serves.
+type HandlerRegistry struct {
+	handlers map[[4]byte]*MethodHandler
+}
+
+// newHandlerRegistry registers the L1 registry methods the gateway can
+// prove. isApprovedForAll is not served: the L1 contract derives the
+// operator slot from packed addresses, which does not match the L2 mapping.
+func newHandlerRegistry(parsedABI *ethabi.ABI) *HandlerRegistry {
+	r := &HandlerRegistry{handlers: make(map[[4]byte]*MethodHandler)}
+	r.register(parsedABI, "owner", "ownerWithProof",
+		nodeMethod(bindings.UnpackL1ENSRegistryOwnerArgs, func(args bindings.L1ENSRegistryOwnerArgs) [32]byte { return args.Node }, getoSLOForOwner),
+		addressResult)
+	r.register(parsedABI, "resolver", "resolverWithProof",
+		nodeMethod(bindings.UnpackL1ENSRegistryResolverArgs, func(args bindings.L1ENSRegistryResolverArgs) [32]byte { return args.Node }, getoSLOForResolver),
+		addressResult)
+	// The TTL is packed with the resolver and a record exists once it has an
+	// owner, so both callbacks read the same words as above.
+	r.register(parsedABI, "ttl", "ttlWithProof",
+		nodeMethod(bindings.UnpackL1ENSRegistryTtlArgs, func(args bindings.L1ENSRegistryTtlArgs) [32]byte { return args.Node }, getoSLOForResolver),
+		func(word common.Hash) interface{} {
+			return ttlFromWord(word)
+		})
+	r.register(parsedABI, "recordExists", "recordExistsWithProof",
+		nodeMethod(bindings.UnpackL1ENSRegistryRecordExistsArgs, func(args bindings.L1ENSRegistryRecordExistsArgs) [32]byte { return args.Node }, getoSLOForOwner),
+		func(word common.Hash) interface{} {
+			return common.BytesToAddress(word[:]) != (common.Address{})
+		})
+	return r
+}
+
+func (r *HandlerRegistry) register(parsedABI *ethabi.ABI, name, callback string, decode func([]byte) (*Call, error), result func(common.Hash) interface{}) {
+	handler := &MethodHandler{
+		Name:             name,
+		Callback:         callback,
+		Selector:         mustGetSelector(parsedABI, name),
+		CallbackSelector: mustGetSelector(parsedABI, callback),
+		Decode:           decode,
+		Result:           result,
+	}
+	if existing, ok := r.handlers[handler.Selector]; ok {
+		panic(fmt.Sprintf("selector 0x%x registered for both %s and %s", handler.Selector, existing.Name, name))
+	}
+	r.handlers[handler.Selector] = handler
+}
+
+// Lookup returns the handler for a function selector.
+func (r *HandlerRegistry) Lookup(selector [4]byte) (*MethodHandler, bool) {
+	handler, ok := r.handlers[selector]
+	return handler, ok
+}
+
+// ByName returns the handler for an L1 registry method name.
+func (r *HandlerRegistry) ByName(name string) (*MethodHandler, bool) {
+	for _, handler := range r.handlers {
+		if handler.Name == name {
+			return handler, true
+		}
+	}
+	return nil, false
+}
+
+// Handlers returns the registered handlers ordered by name.
+func (r *HandlerRegistry) Handlers() []*MethodHandler {
+	handlers := make([]*MethodHandler, 0, len(r.handlers))
+	for _, handler := range r.handlers {
+		handlers = append(handlers, handler)
+	}
+	sort.Slice(handlers, func(i, j int) bool { return handlers[i].Name < handlers[j].Name })
+	return handlers
+}
+
+// addressResult decodes an address stored in the low bytes of a word.
+func addressResult(word common.Hash) interface{} {
+	return common.BytesToAddress(word[:])
+}
+
+// nodeMethod adapts a generated unpacker for methods reading a single node's
+// record.
+func nodeMethod[T CallArgs](unpack func([]byte) (T, error), node func(T) [32]byte, slot func(node [32]byte) []byte) func([]byte) (*Call, error) {
+	return func(params []byte) (*Call, error) {
+		args, err := unpack(params)
+		if err != nil {
+			return nil, err
+		}
+		n := node(args)
+		h := common.Hash(n)
+		return &Call{Args: args, Node: &h, Slot: slot(n)}, nil
+	}
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/go-chi/render"
+)
+
+const readinessTimeout = 5 * time.Second
+
+// ReadinessConfig controls the optional /readyz checks.
+type ReadinessConfig struct {
+	// L2ChainID is the chain ID the L2 RPC must report, if set.
+	L2ChainID *big.Int
+	// MaxBatchLag is the number of L2 blocks the latest committed state batch
+	// may trail the L2 head by. Zero disables the check.
+	MaxBatchLag uint64
+	// CanaryNode, if set, is proven end to end through owner(node).
+	CanaryNode *common.Hash
+	// CanaryInterval is how long a canary result is served before the canary
+	// is proven again.
+	CanaryInterval time.Duration
+}
+
+func readinessConfigFromEnv() (ReadinessConfig, error) {
+	var config ReadinessConfig
+	if chainID := GetOrDefault("L2_CHAIN_ID", ""); chainID != "" {
+		id, err := parseUint(chainID)
+		if err != nil {
+			return config, fmt.Errorf("parsing L2_CHAIN_ID: %w", err)
+		}
+		config.L2ChainID = id
+	}
+	maxLag, err := parseUint(GetOrDefault("READY_MAX_BATCH_LAG", "0"))
+	if err != nil {
+		return config, fmt.Errorf("parsing READY_MAX_BATCH_LAG: %w", err)
+	}
+	config.MaxBatchLag = maxLag.Uint64()
+	if node := GetOrDefault("READY_CANARY_NODE", ""); node != "" {
+		b, err := DecodeHex(node)
+		if err != nil || len(b) != common.HashLength {
+			return config, fmt.Errorf("parsing READY_CANARY_NODE: invalid 32 byte hex %q", node)
+		}
+		hash := common.BytesToHash(b)
+		config.CanaryNode = &hash
+	}
+	if config.CanaryInterval, err = time.ParseDuration(GetOrDefault("READY_CANARY_INTERVAL", "30s")); err != nil {
+		return config, fmt.Errorf("parsing READY_CANARY_INTERVAL: %w", err)
+	}
+	if config.CanaryInterval <= 0 {
+		return config, fmt.Errorf("READY_CANARY_INTERVAL must be positive, got %s", config.CanaryInterval)
+	}
+	return config, nil
+}
+
+type CheckResult struct {
+	OK     bool   `json:"ok"`
+	Error  string `json:"error,omitempty"`
+	Detail string `json:"detail,omitempty"`
+}
+
+type HealthResponse struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks,omitempty"`
+}
+
+func (h HealthResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	if h.Status != "ok" {
+		render.Status(r, http.StatusServiceUnavailable)
+		return nil
+	}
+	render.Status(r, http.StatusOK)
+	return nil
+}
+
+// getHealthz reports that the process is up. It does not touch upstreams.
+func (g *Gateway) getHealthz(w http.ResponseWriter, r *http.Request) {
+	render.Render(w, r, HealthResponse{Status: "ok"})
+}
+
+// getReadyz reports whether this instance can currently serve proofs.
+func (g *Gateway) getReadyz(w http.ResponseWriter, r *http.Request) {
+	if atomic.LoadInt32(&g.draining) == 1 {
+		render.Render(w, r, HealthResponse{Status: "draining"})
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+	defer cancel()
+
+	checks := map[string]func(context.Context) (string, error){
+		"l2_rpc":         g.checkL2RPC,
+		"state_root_lag": g.checkStateRootLag,
+	}
+	if g.readiness.CanaryNode != nil {
+		checks["canary_proof"] = g.checkCanary
+	}
+
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		resp = HealthResponse{Status: "ok", Checks: make(map[string]CheckResult, len(checks))}
+	)
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check func(context.Context) (string, error)) {
+			defer wg.Done()
+			detail, err := check(ctx)
+			result := CheckResult{OK: err == nil, Detail: detail}
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				result.Error = err.Error()
+				resp.Status = "unavailable"
+			}
+			resp.Checks[name] = result
+		}(name, check)
+	}
+	wg.Wait()
+	render.Render(w, r, resp)
+}
+
+func (g *Gateway) checkL2RPC(ctx context.Context) (string, error) {
+	chainID, err := g.l2.ChainID(ctx)
+	if err != nil {
+		return "", fmt.Errorf("getting chain ID: %w", err)
+	}
+	if g.readiness.L2ChainID != nil && g.readiness.L2ChainID.Cmp(chainID) != 0 {
+		return "", fmt.Errorf("chain ID %s, want %s", chainID, g.readiness.L2ChainID)
+	}
+	return fmt.Sprintf("chain ID %s", chainID), nil
+}
+
+// checkStateRootLag checks the state root source is up and that the latest
+// committed batch is close enough to the L2 head for proofs to be useful.
+func (g *Gateway) checkStateRootLag(ctx context.Context) (string, error) {
+	batch, err := g.stateRoots.LatestStateBatch(ctx)
+	if err != nil {
+		return "", err
+	}
+	head, err := g.l2.BlockNumber(ctx)
+	if err != nil {
+		return "", fmt.Errorf("getting L2 block number: %w", err)
+	}
+	committed := batch.LastL2BlockNumber()
+	lag := new(big.Int).Sub(new(big.Int).SetUint64(head), committed)
+	detail := fmt.Sprintf("batch %s commits block %s, head %d, lag %s", batch.BatchIndex.Int(), committed, head, lag)
+	if g.readiness.MaxBatchLag > 0 && lag.Cmp(new(big.Int).SetUint64(g.readiness.MaxBatchLag)) > 0 {
+		return detail, fmt.Errorf("latest batch lags head by %s blocks, max %d", lag, g.readiness.MaxBatchLag)
+	}
+	return detail, nil
+}
+
+// canaryCache holds the last canary result so /readyz does not build a full
+// proof on every probe.
+type canaryCache struct {
+	mu      sync.Mutex
+	checked time.Time
+	detail  string
+	err     error
+}
+
+// checkCanary serves the cached canary result, proving the canary again once
+// it is older than CanaryInterval. Concurrent probes wait for a single proof.
+func (g *Gateway) checkCanary(ctx context.Context) (string, error) {
+	g.canary.mu.Lock()
+	defer g.canary.mu.Unlock()
+	if !g.canary.checked.IsZero() && time.Since(g.canary.checked) < g.readiness.CanaryInterval {
+		return g.canary.detail, g.canary.err
+	}
+	detail, err := g.proveCanary(ctx)
+	if ctx.Err() != nil {
+		// A probe that gave up says nothing about the canary, so don't cache it.
+		return detail, err
+	}
+	g.canary.detail, g.canary.err, g.canary.checked = detail, err, time.Now()
+	return detail, err
+}
+
+func (g *Gateway) proveCanary(ctx context.Context) (string, error) {
+	calldata, err := abi.Pack("owner", *g.readiness.CanaryNode)
+	if err != nil {
+		return "", fmt.Errorf("packing canary calldata: %w", err)
+	}
+	proof, err := g.prove(ctx, "0x"+hex.EncodeToString(calldata), ProofSelector{})
+	if err != nil {
+		return "", fmt.Errorf("proving canary: %w", err)
+	}
+	return fmt.Sprintf("owner %s", common.BytesToAddress(proof.Value.Bytes())), nil
+}
@@ -0,0 +1,260 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/0xpaulio/eth-sf-ens-rr/indexer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+const (
+	defaultIndexPageSize = 100
+	maxIndexPageSize     = 1000
+)
+
+// IndexConfig configures the L2 registry event indexer.
+type IndexConfig struct {
+	Enabled bool
+	// DBPath is the LevelDB directory. Empty keeps the index in memory, so
+	// it is rebuilt from StartBlock on every start.
+	DBPath string
+	// DictionaryPath is an optional file of known labels, one per line.
+	DictionaryPath string
+	indexer.Config
+}
+
+func indexConfigFromEnv(registry common.Address) (IndexConfig, error) {
+	config := IndexConfig{
+		DBPath:         GetOrDefault("INDEXER_DB_PATH", ""),
+		DictionaryPath: GetOrDefault("INDEXER_LABEL_DICTIONARY", ""),
+		Config:         indexer.Config{Registry: registry},
+	}
+	var err error
+	if config.Enabled, err = strconv.ParseBool(GetOrDefault("INDEXER_ENABLED", "false")); err != nil {
+		return config, fmt.Errorf("parsing INDEXER_ENABLED: %w", err)
+	}
+	if config.CalldataLabels, err = strconv.ParseBool(GetOrDefault("INDEXER_CALLDATA_LABELS", "true")); err != nil {
+		return config, fmt.Errorf("parsing INDEXER_CALLDATA_LABELS: %w", err)
+	}
+	for _, n := range []struct {
+		env string
+		def string
+		dst *uint64
+	}{
+		{"INDEXER_START_BLOCK", "0", &config.StartBlock},
+		{"INDEXER_CONFIRMATIONS", "10", &config.Confirmations},
+		{"INDEXER_BLOCK_RANGE", "2000", &config.BlockRange},
+		{"INDEXER_REORG_DEPTH", "256", &config.ReorgDepth},
+	} {
+		if *n.dst, err = strconv.ParseUint(GetOrDefault(n.env, n.def), 10, 64); err != nil {
+			return config, fmt.Errorf("parsing %s: %w", n.env, err)
+		}
+	}
+	if config.PollInterval, err = time.ParseDuration(GetOrDefault("INDEXER_POLL_INTERVAL", "5s")); err != nil {
+		return config, fmt.Errorf("parsing INDEXER_POLL_INTERVAL: %w", err)
+	}
+	if config.BlockRange == 0 {
+		return config, errors.New("INDEXER_BLOCK_RANGE must be positive")
+	}
+	if config.PollInterval <= 0 {
+		return config, errors.New("INDEXER_POLL_INTERVAL must be positive")
+	}
+	if config.Enabled && config.DictionaryPath != "" {
+		if config.Dictionary, err = indexer.LoadDictionary(config.DictionaryPath); err != nil {
+			return config, fmt.Errorf("loading INDEXER_LABEL_DICTIONARY: %w", err)
+		}
+	}
+	return config, nil
+}
+
+// IndexedNode is a node's record as seen by the indexer.
+type IndexedNode struct {
+	Node common.Hash `json:"node"`
+	// Name is the node's name as far as its labels are known, with unknown
+	// labels written as [labelhash].
+	Name      string         `json:"name,omitempty"`
+	Parent    *common.Hash   `json:"parent,omitempty"`
+	LabelHash *common.Hash   `json:"labelhash,omitempty"`
+	Owner     common.Address `json:"owner"`
+	Resolver  common.Address `json:"resolver"`
+	TTL       uint64         `json:"ttl"`
+}
+
+func (g *Gateway) newIndexedNode(node indexer.Node) IndexedNode {
+	n := IndexedNode{
+		Node:     node.Node,
+		Owner:    node.Owner,
+		Resolver: node.Resolver,
+		TTL:      node.TTL,
+	}
+	if node.Label != (common.Hash{}) {
+		parent, label := node.Parent, node.Label
+		n.Parent, n.LabelHash = &parent, &label
+	}
+	n.Name, _ = g.nodeName(node.Node)
+	return n
+}
+
+// IndexedNodesResponse is a page of indexed nodes. Next is the cursor for
+// the following page, absent on the last one.
+type IndexedNodesResponse struct {
+	Block uint64        `json:"block"`
+	Nodes []IndexedNode `json:"nodes"`
+	Next  *common.Hash  `json:"next,omitempty"`
+}
+
+func (IndexedNodesResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// OperatorsResponse lists the operators an owner has approved for all.
+type OperatorsResponse struct {
+	Block     uint64           `json:"block"`
+	Owner     common.Address   `json:"owner"`
+	Operators []common.Address `json:"operators"`
+}
+
+func (OperatorsResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// getSubnodes lists the indexed subnodes of {name}, which may be a name or
+// a node. Pages are ordered by labelhash.
+func (g *Gateway) getSubnodes(w http.ResponseWriter, r *http.Request) {
+	parent, err := parseNode(chi.URLParam(r, "name"))
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(fmt.Errorf("parsing name: %w", err)))
+		return
+	}
+	g.renderIndexedNodes(w, r, func(after *common.Hash, limit int) ([]indexer.Node, error) {
+		return g.index.Store().Subnodes(parent, after, limit)
+	}, func(node indexer.Node) common.Hash { return node.Label })
+}
+
+// getOwnedNames lists the nodes {owner} currently owns, ordered by node.
+func (g *Gateway) getOwnedNames(w http.ResponseWriter, r *http.Request) {
+	owner, err := parseAddressParam(r, "owner")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	g.renderIndexedNodes(w, r, func(after *common.Hash, limit int) ([]indexer.Node, error) {
+		return g.index.Store().OwnedBy(owner, after, limit)
+	}, func(node indexer.Node) common.Hash { return node.Node })
+}
+
+// getOperators lists the operators {owner} has approved for all.
+func (g *Gateway) getOperators(w http.ResponseWriter, r *http.Request) {
+	owner, err := parseAddressParam(r, "owner")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	block, err := g.indexHead()
+	if err != nil {
+		render.Render(w, r, ErrRender(err))
+		return
+	}
+	operators, err := g.index.Store().Operators(owner)
+	if err != nil {
+		render.Render(w, r, ErrRender(err))
+		return
+	}
+	if operators == nil {
+		operators = []common.Address{}
+	}
+	render.Render(w, r, OperatorsResponse{Block: block, Owner: owner, Operators: operators})
+}
+
+// renderIndexedNodes serves one page of list, reading the limit and cursor
+// query parameters. cursor returns the key a node sorts by in list.
+func (g *Gateway) renderIndexedNodes(w http.ResponseWriter, r *http.Request, list func(after *common.Hash, limit int) ([]indexer.Node, error), cursor func(indexer.Node) common.Hash) {
+	limit, after, err := parsePage(r)
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	block, err := g.indexHead()
+	if err != nil {
+		render.Render(w, r, ErrRender(err))
+		return
+	}
+	// Ask for one more than the page to know whether there is a next one.
+	nodes, err := list(after, limit+1)
+	if err != nil {
+		render.Render(w, r, ErrRender(err))
+		return
+	}
+	resp := IndexedNodesResponse{Block: block, Nodes: []IndexedNode{}}
+	if len(nodes) > limit {
+		nodes = nodes[:limit]
+		next := cursor(nodes[limit-1])
+		resp.Next = &next
+	}
+	for _, node := range nodes {
+		resp.Nodes = append(resp.Nodes, g.newIndexedNode(node))
+	}
+	render.Render(w, r, resp)
+}
+
+// indexHead returns the last indexed block, failing while the index is
+// still empty.
+func (g *Gateway) indexHead() (uint64, error) {
+	head, _, ok, err := g.index.Store().Head()
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, &gatewayError{status: http.StatusServiceUnavailable, err: errors.New("index has not synced yet")}
+	}
+	return head, nil
+}
+
+// nodeName returns the indexed name of node, if the index is enabled and
+// knows how node descends from the root.
+func (g *Gateway) nodeName(node common.Hash) (string, bool) {
+	if g.index == nil {
+		return "", false
+	}
+	name, _, ok, err := g.index.Name(node)
+	if err != nil {
+		logger.Warn().Err(err).Str("node", node.Hex()).Msg("recovering node name")
+		return "", false
+	}
+	return name, ok
+}
+
+func parsePage(r *http.Request) (int, *common.Hash, error) {
+	query := r.URL.Query()
+	limit := defaultIndexPageSize
+	if s := query.Get("limit"); s != "" {
+		n, err := parseInt(s)
+		if err != nil || n < 1 || n > maxIndexPageSize {
+			return 0, nil, fmt.Errorf("limit must be between 1 and %d", maxIndexPageSize)
+		}
+		limit = n
+	}
+	var after *common.Hash
+	if s := query.Get("cursor"); s != "" {
+		h, err := parseHash(s)
+		if err != nil {
+			return 0, nil, fmt.Errorf("parsing cursor: %w", err)
+		}
+		after = &h
+	}
+	return limit, after, nil
+}
+
+func parseAddressParam(r *http.Request, name string) (common.Address, error) {
+	s := chi.URLParam(r, name)
+	if !common.IsHexAddress(s) {
+		return common.Address{}, fmt.Errorf("invalid %s address %q", name, s)
+	}
+	return common.HexToAddress(s), nil
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/rs/zerolog"
+)
+
+// logger is the process wide structured logger. Request handlers should log
+// through requestLogger(ctx) so entries carry the request ID and the fields
+// gathered so far.
+var logger = zerolog.New(os.Stderr).With().Timestamp().Logger()
+
+// redactCalldata replaces calldata in logs with its selector and length.
+var redactCalldata bool
+
+// setupLogging configures the logger from LOG_LEVEL (trace, debug, info,
+// warn, error), LOG_FORMAT (json or console) and LOG_REDACT_CALLDATA. The
+// standard library logger is routed through it as well.
+func setupLogging() error {
+	level, err := zerolog.ParseLevel(strings.ToLower(GetOrDefault("LOG_LEVEL", "info")))
+	if err != nil {
+		return fmt.Errorf("parsing LOG_LEVEL: %w", err)
+	}
+	var out io.Writer = os.Stderr
+	switch format := GetOrDefault("LOG_FORMAT", "json"); format {
+	case "json":
+	case "console":
+		out = zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: time.RFC3339}
+	default:
+		return fmt.Errorf("unknown LOG_FORMAT %q", format)
+	}
+	if redactCalldata, err = strconv.ParseBool(GetOrDefault("LOG_REDACT_CALLDATA", "false")); err != nil {
+		return fmt.Errorf("parsing LOG_REDACT_CALLDATA: %w", err)
+	}
+	logger = zerolog.New(out).Level(level).With().
+		Timestamp().
+		Str("service", GetOrDefault("OTEL_SERVICE_NAME", "ens-gateway")).
+		Logger()
+	log.SetFlags(0)
+	log.SetOutput(logger)
+	return nil
+}
+
+type logEntryKey struct{}
+
+// logEntry is the request scoped logger. Fields are added to it as the
+// request moves through the pipeline.
+type logEntry struct {
+	logger zerolog.Logger
+}
+
+// requestLogger returns the logger for the request in ctx, or the process
+// logger outside of a request.
+func requestLogger(ctx context.Context) *zerolog.Logger {
+	if entry, ok := ctx.Value(logEntryKey{}).(*logEntry); ok {
+		return &entry.logger
+	}
+	return &logger
+}
+
+// setLogFields adds fields to every later log entry of the request in ctx.
+func setLogFields(ctx context.Context, fields map[string]interface{}) {
+	if entry, ok := ctx.Value(logEntryKey{}).(*logEntry); ok {
+		entry.logger = entry.logger.With().Fields(fields).Logger()
+	}
+}
+
+// requestLogging assigns each request an ID, returned in X-Request-ID, and
+// logs one entry when it completes. It must run after middleware.RequestID.
+func requestLogging(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reqID := middleware.GetReqID(r.Context())
+		w.Header().Set("X-Request-ID", reqID)
+		entry := &logEntry{logger: logger.With().
+			Str("request_id", reqID).
+			Str("http_method", r.Method).
+			Str("remote_ip", r.RemoteAddr).
+			Logger()}
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		start := time.Now()
+		defer func() {
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			entry.logger.WithLevel(statusLevel(status)).
+				Str("path", logPath(r)).
+				Int("status", status).
+				Int("bytes", ww.BytesWritten()).
+				Float64("latency_ms", float64(time.Since(start).Microseconds())/1000).
+				Msg("request")
+		}()
+		next.ServeHTTP(ww, r.WithContext(context.WithValue(r.Context(), logEntryKey{}, entry)))
+	})
+}
+
+// logPath is the request path, or its route pattern when calldata is
+// redacted since gateway paths embed the calldata.
+func logPath(r *http.Request) string {
+	if redactCalldata {
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			return rctx.RoutePattern()
+		}
+	}
+	return r.URL.Path
+}
+
+// logCalldata formats calldata for logging, honouring LOG_REDACT_CALLDATA.
+func logCalldata(hexCalldata string) string {
+	if !redactCalldata {
+		return hexCalldata
+	}
+	calldata, err := DecodeHex(hexCalldata)
+	if err != nil || len(calldata) < 4 {
+		return fmt.Sprintf("<redacted %d chars>", len(hexCalldata))
+	}
+	return fmt.Sprintf("0x%x<redacted %d bytes>", calldata[:4], len(calldata)-4)
+}
+
+func statusLevel(status int) zerolog.Level {
+	switch {
+	case status >= http.StatusInternalServerError:
+		return zerolog.ErrorLevel
+	case status >= http.StatusBadRequest:
+		return zerolog.WarnLevel
+	default:
+		return zerolog.InfoLevel
+	}
+}
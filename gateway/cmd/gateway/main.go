@@ -1,28 +1,33 @@
 package main
 
 import (
-	"bytes"
+	"context"
 	"encoding/hex"
-	"encoding/json"
-	"errors"
 	"fmt"
 	"github.com/go-chi/chi/v5"
-	"io"
 	"log"
 	"math/big"
 	"net/http"
 	"os"
+	"os/signal"
+	"strconv"
 	"strings"
+	"sync/atomic"
+	"syscall"
 
+	"github.com/0xpaulio/eth-sf-ens-rr/indexer"
+	"github.com/0xpaulio/eth-sf-ens-rr/relayer"
+	"github.com/0xpaulio/eth-sf-ens-rr/verifier"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/ethclient/gethclient"
-	"github.com/ethereum/go-ethereum/rlp"
-	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/go-chi/chi/v5/middleware"
-	"github.com/go-chi/httplog"
+	"github.com/go-chi/cors"
 	"github.com/go-chi/render"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 )
 
 func Must(key string) string {
@@ -45,6 +50,17 @@ func DecodeHex(h string) ([]byte, error) {
 	return hex.DecodeString(strings.TrimPrefix(h, "0x"))
 }
 
+// splitList splits a comma separated list, dropping empty items.
+func splitList(s string) []string {
+	var list []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
 /*
 decode calldata
 calculate storage slot
@@ -53,17 +69,36 @@ encode response
 */
 
 type Gateway struct {
-	l2GethClient      *gethclient.Client
-	l2EthClient       *ethclient.Client
+	l2                L2Client
 	l2ResolverAddress common.Address
-	selectors         map[[4]byte]string
+	handlers          *HandlerRegistry
+	proofCache        *lru.Cache
+	stateRoots        StateRootProvider
+	readiness         ReadinessConfig
+	canary            canaryCache
+	cachePolicy       CachePolicy
+	senders           CapabilitiesConfig
+	capabilities      CapabilitiesResponse
+	// index is the L2 registry event index, nil unless INDEXER_ENABLED.
+	index *indexer.Indexer
+	// relay submits L2 registry writes, nil unless RELAY_ENABLED.
+	relay *Relay
+	// metaTx relays signed requests through the forwarder, nil unless
+	// META_TX_ENABLED.
+	metaTx   *relayer.Relayer
+	draining int32
 }
 
 type GatewayResponse struct {
 	Data string `json:"data"`
+	// Exists is false when Data proves the record is absent, in which case the
+	// L1 callback reverts with the error named by Absence.
+	Exists  bool    `json:"exists"`
+	Absence Absence `json:"absence,omitempty"`
 }
 
 func (g GatewayResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	render.Status(r, http.StatusOK)
 	return nil
 }
 
@@ -76,27 +111,15 @@ type GatewayResponseData struct {
 type StateRootProofInput struct {
 	ResolverAddr string `json:"resolver_addr"`
 	AddrSlot     string `json:"addr_slot"`
+	BlockNumber  string `json:"block_number,omitempty"`
+	BatchIndex   string `json:"batch_index,omitempty"`
+	StateRoot    string `json:"state_root,omitempty"`
 }
 
 type StateRootProof struct {
-	StateRoot            string `json:"stateRoot"`
-	StateRootBatchHeader struct {
-		BatchIndex struct {
-			Type string `json:"type"`
-			Hex  string `json:"hex"`
-		} `json:"batchIndex"`
-		BatchRoot string `json:"batchRoot"`
-		BatchSize struct {
-			Type string `json:"type"`
-			Hex  string `json:"hex"`
-		} `json:"batchSize"`
-		PrevTotalElements struct {
-			Type string `json:"type"`
-			Hex  string `json:"hex"`
-		} `json:"prevTotalElements"`
-		ExtraData string `json:"extraData"`
-	} `json:"stateRootBatchHeader"`
-	StateRootProof struct {
+	StateRoot            string               `json:"stateRoot"`
+	StateRootBatchHeader StateRootBatchHeader `json:"stateRootBatchHeader"`
+	StateRootProof       struct {
 		Index    int `json:"index"`
 		Siblings []struct {
 			Type string `json:"type"`
@@ -107,35 +130,260 @@ type StateRootProof struct {
 	StorageTrieWitness []byte `json:"storageTrieWitness"`
 }
 
+// BigNumber is an ethers.js BigNumber as serialized by the sidecar.
+type BigNumber struct {
+	Type string `json:"type"`
+	Hex  string `json:"hex"`
+}
+
+// Int returns the number as a big.Int.
+func (n BigNumber) Int() *big.Int {
+	return new(big.Int).SetBytes(parseHex(n.Hex))
+}
+
+type StateRootBatchHeader struct {
+	BatchIndex        BigNumber `json:"batchIndex"`
+	BatchRoot         string    `json:"batchRoot"`
+	BatchSize         BigNumber `json:"batchSize"`
+	PrevTotalElements BigNumber `json:"prevTotalElements"`
+	ExtraData         string    `json:"extraData"`
+}
+
+// LastL2BlockNumber returns the last L2 block whose state root the batch
+// commits to.
+func (h *StateRootBatchHeader) LastL2BlockNumber() *big.Int {
+	return new(big.Int).Add(h.PrevTotalElements.Int(), h.BatchSize.Int())
+}
+
+// BatchIndex returns the index of the state batch the proof commits to.
+func (p *StateRootProof) BatchIndex() *big.Int {
+	return p.StateRootBatchHeader.BatchIndex.Int()
+}
+
+// L2BlockNumber returns the L2 block whose state root is proven. Element i of
+// the state commitment chain is the state root of L2 block i+1.
+func (p *StateRootProof) L2BlockNumber() *big.Int {
+	blockNum := p.StateRootBatchHeader.PrevTotalElements.Int()
+	blockNum.Add(blockNum, big.NewInt(int64(p.StateRootProof.Index)))
+	return blockNum.Add(blockNum, big.NewInt(1))
+}
+
 func main() {
-	l2RPCURL := GetOrDefault("L2_RPC_URL", "https://goerli.optimism.io")
-	l2RPCClient, err := rpc.Dial(l2RPCURL)
+	if len(os.Args) > 1 {
+		if err := runCommand(os.Args[1], os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", os.Args[1], err)
+			os.Exit(1)
+		}
+		return
+	}
+	runServer()
+}
+
+// runServer serves the gateway until SIGINT or SIGTERM.
+func runServer() {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := setupLogging(); err != nil {
+		log.Fatal("setting up logging: ", err)
+	}
+
+	shutdownTracing, err := setupTracing(ctx)
 	if err != nil {
-		log.Fatal("dialing L2 RPC", err)
+		log.Fatal("setting up tracing", err)
 	}
-	selectors := make(map[[4]byte]string, len(methodNames))
-	for _, name := range methodNames {
-		selectors[mustGetSelector(abi, name)] = name
+	defer shutdownTracing(context.Background())
+
+	serverConfig, err := serverConfigFromEnv()
+	if err != nil {
+		log.Fatal("loading server config", err)
+	}
+
+	l2Pool, err := l2PoolFromEnv()
+	if err != nil {
+		log.Fatal("creating L2 RPC pool", err)
+	}
+	go l2Pool.Run(ctx)
+
+	proofCacheSize, err := strconv.Atoi(GetOrDefault("PROOF_CACHE_SIZE", "1024"))
+	if err != nil {
+		log.Fatal("parsing PROOF_CACHE_SIZE", err)
+	}
+	proofCache, err := lru.New(proofCacheSize)
+	if err != nil {
+		log.Fatal("creating proof cache", err)
+	}
+
+	stateRootConfig, err := httpStateRootProviderConfigFromEnv()
+	if err != nil {
+		log.Fatal("loading state root provider config", err)
+	}
+
+	readiness, err := readinessConfigFromEnv()
+	if err != nil {
+		log.Fatal("loading readiness config", err)
+	}
+
+	rateLimitConfig, err := rateLimitConfigFromEnv()
+	if err != nil {
+		log.Fatal("loading rate limit config", err)
+	}
+	rateLimiter, err := NewRateLimiter(rateLimitConfig)
+	if err != nil {
+		log.Fatal("creating rate limiter", err)
+	}
+
+	cachePolicy, err := cachePolicyFromEnv()
+	if err != nil {
+		log.Fatal("loading cache policy", err)
+	}
+
+	capabilitiesConfig, err := capabilitiesConfigFromEnv()
+	if err != nil {
+		log.Fatal("loading capabilities config", err)
+	}
+
+	debugEndpoints, err := debugEndpointsFromEnv()
+	if err != nil {
+		log.Fatal("loading debug config", err)
+	}
+
+	corsOptions, err := corsOptionsFromEnv()
+	if err != nil {
+		log.Fatal("loading CORS config", err)
+	}
+
+	l2ResolverAddress := common.HexToAddress(GetOrDefault("L2_RESOLVER_ADDR", "0xE933897412cc2164331e542B2a2Be491612C233F"))
+	indexConfig, err := indexConfigFromEnv(l2ResolverAddress)
+	if err != nil {
+		log.Fatal("loading indexer config", err)
+	}
+
+	relayConfig, err := relayConfigFromEnv()
+	if err != nil {
+		log.Fatal("loading relay config", err)
+	}
+	metaTxConfig, err := metaTxConfigFromEnv(l2ResolverAddress)
+	if err != nil {
+		log.Fatal("loading meta-transaction config", err)
 	}
 
 	gateway := Gateway{
-		l2GethClient:      gethclient.New(l2RPCClient),
-		l2EthClient:       ethclient.NewClient(l2RPCClient),
-		l2ResolverAddress: common.HexToAddress(GetOrDefault("L2_RESOLVER_ADDR", "0xE933897412cc2164331e542B2a2Be491612C233F")),
-		selectors:         selectors,
+		l2:                l2Pool,
+		l2ResolverAddress: l2ResolverAddress,
+		handlers:          newHandlerRegistry(abi),
+		proofCache:        proofCache,
+		stateRoots:        NewHTTPStateRootProvider(stateRootConfig),
+		readiness:         readiness,
+		cachePolicy:       cachePolicy,
+		senders:           capabilitiesConfig,
 	}
+	gateway.capabilities = newCapabilities(&gateway, capabilitiesConfig, stateRootConfig, l2Pool)
 
-	logger := httplog.NewLogger("httplog-example", httplog.Options{
-		LogLevel: "Debug",
-		Concise:  true,
-	})
+	if indexConfig.Enabled {
+		store, err := indexer.OpenStore(indexConfig.DBPath)
+		if err != nil {
+			log.Fatal("opening index", err)
+		}
+		defer store.Close()
+		ix, err := indexer.New(store, l2Pool, indexConfig.Config, logger)
+		if err != nil {
+			log.Fatal("creating indexer", err)
+		}
+		go ix.Run(ctx)
+		gateway.index = ix
+	}
+
+	if relayConfig.Enabled {
+		relay, err := NewRelay(ctx, l2Pool, l2ResolverAddress, relayConfig)
+		if err != nil {
+			log.Fatal("creating relay", err)
+		}
+		gateway.relay = relay
+	}
+
+	if metaTxConfig.Enabled {
+		outbox, err := relayer.OpenOutbox(metaTxConfig.DBPath)
+		if err != nil {
+			log.Fatal("opening meta-transaction outbox", err)
+		}
+		defer outbox.Close()
+		rl, err := relayer.New(outbox, l2Pool, metaTxConfig.Key, metaTxConfig.Config, logger)
+		if err != nil {
+			log.Fatal("creating meta-transaction relayer", err)
+		}
+		logger.Info().Str("relayer", rl.Address().Hex()).Str("forwarder", metaTxConfig.Forwarder.Hex()).
+			Msg("meta-transaction relayer enabled")
+		go rl.Run(ctx)
+		gateway.metaTx = rl
+	}
+
+	r := gateway.routes(rateLimiter, corsOptions, debugEndpoints)
+
+	// Fail readiness as soon as shutdown starts so load balancers stop
+	// routing here while in-flight requests drain.
+	go func() {
+		<-ctx.Done()
+		atomic.StoreInt32(&gateway.draining, 1)
+	}()
+	if err := serve(ctx, serverConfig, r); err != nil {
+		log.Fatal("serving", err)
+	}
+}
 
+// routes mounts the gateway's endpoints. CORS runs before routing on the
+// public routes so preflight requests are answered, and before rate limiting
+// so rejections can be read by browsers.
+func (g *Gateway) routes(rateLimiter *RateLimiter, corsOptions cors.Options, debugEndpoints bool) http.Handler {
 	r := chi.NewRouter()
-	r.Use(middleware.Logger)
+	r.Use(traceRequests)
+	r.Use(middleware.RequestID)
+	r.Use(requestLogging)
 	r.Use(middleware.Recoverer)
-	r.Use(httplog.RequestLogger(logger))
-	r.Get("/gateway/{sender}/{data}.json", gateway.getGateway)
-	http.ListenAndServe(":41234", r)
+	// EIP-3668 clients expect JSON whatever their Accept header says.
+	r.Use(render.SetContentType(render.ContentTypeJSON))
+	r.Route("/gateway", func(r chi.Router) {
+		r.Use(corsHandler(corsOptions))
+		r.With(rateLimiter.Middleware).Get("/{sender}/{data}.json", g.getGateway)
+		r.With(rateLimiter.Middleware).Post("/", g.postGateway)
+	})
+	if debugEndpoints {
+		r.Route("/debug", func(r chi.Router) {
+			r.With(rateLimiter.Middleware).Get("/proof/{sender}/{data}.json", g.getDebugProof)
+			r.With(rateLimiter.Middleware).Post("/proof", g.postDebugProof)
+		})
+	}
+	r.Route("/v1", func(r chi.Router) {
+		r.Use(corsHandler(corsOptions))
+		r.Use(rateLimiter.Middleware)
+		r.Get("/name/{name}/owner", g.getNameOwner)
+		r.Get("/name/{name}/resolver", g.getNameResolver)
+		r.Get("/name/{name}/ttl", g.getNameTTL)
+		r.Get("/name/{name}/records", g.getNameRecords)
+		r.Get("/tx/{txHash}/commitment", g.getCommitment)
+		if g.index != nil {
+			r.Get("/name/{name}/subnodes", g.getSubnodes)
+			r.Get("/owner/{owner}/names", g.getOwnedNames)
+			r.Get("/owner/{owner}/operators", g.getOperators)
+		}
+		if g.relay != nil {
+			r.Post("/relay", g.postRelay)
+			r.Get("/relay/{txHash}", g.getRelay)
+		}
+		if g.metaTx != nil {
+			r.Post("/meta", g.postMetaTx)
+			r.Get("/meta/{id}", g.getMetaTx)
+			r.Get("/meta/account/{address}", g.getMetaTxAccount)
+		}
+	})
+	r.Handle("/metrics", promhttp.Handler())
+	r.Get("/healthz", g.getHealthz)
+	r.Get("/readyz", g.getReadyz)
+	r.Route("/capabilities", func(r chi.Router) {
+		r.Use(corsHandler(corsOptions))
+		r.Get("/", g.getCapabilities)
+	})
+	return r
 }
 
 func getoSLOForResolver(node [32]byte) []byte {
@@ -154,170 +402,297 @@ func getSLOForRecords(node [32]byte) []byte {
 	)
 }
 
-func getSLO(methodName string, decodedCalldata []interface{}) ([]byte, error) {
-	switch methodName {
-	case "owner":
-		node, ok := decodedCalldata[0].([32]byte)
-		if !ok {
-			return nil, errors.New("get SLO for owner")
-		}
-		return getoSLOForOwner(node), nil
-	case "resolver":
-		node, ok := decodedCalldata[0].([32]byte)
-		if !ok {
-			return nil, errors.New("get SLO for address")
-		}
-		return getoSLOForResolver(node), nil
-	default:
-		return nil, fmt.Errorf("get SLO for unknown method %s", methodName)
-	}
-}
-
-func (g *Gateway) decode(hexCalldata string) (methodName string, decoded []interface{}, err error) {
+func (g *Gateway) decode(hexCalldata string) (handler *MethodHandler, call *Call, err error) {
 	calldata, err := DecodeHex(hexCalldata)
 	if err != nil {
-		return "", nil, fmt.Errorf("decoding hex calldata: %w", err)
+		return nil, nil, fmt.Errorf("decoding hex calldata: %w", err)
+	}
+	if len(calldata) < 4 {
+		return nil, nil, fmt.Errorf("calldata too short: %d bytes", len(calldata))
 	}
 	functionSignature := calldata[:4]
 	functionParameters := calldata[4:]
 	var sig [4]byte
 	copy(sig[:], functionSignature)
-	methodName, ok := g.selectors[sig]
+	handler, ok := g.handlers.Lookup(sig)
 	if !ok {
-		return "", nil, fmt.Errorf("unknown function signature: %s", string(functionSignature))
+		return nil, nil, fmt.Errorf("unknown function signature: 0x%x", functionSignature)
 	}
-	unpacked, err := abi.Methods[methodName].Inputs.Unpack(functionParameters)
-	return methodName, unpacked, err
+	call, err = handler.Decode(functionParameters)
+	return handler, call, err
 }
 
 func (g *Gateway) getGateway(w http.ResponseWriter, r *http.Request) {
-	sender := chi.URLParam(r, "sender")
-	hexCalldata := chi.URLParam(r, "data")
-	log.Printf("sender: %s, hexCalldata: %s", sender, hexCalldata)
-	methodName, decoded, err := g.decode(hexCalldata)
+	g.serveGateway(w, r, gatewayRequestFromURL(r))
+}
+
+func (g *Gateway) postGateway(w http.ResponseWriter, r *http.Request) {
+	req, err := gatewayRequestFromBody(r)
 	if err != nil {
-		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
 	}
-	log.Printf("method: %s decoded: %+v", methodName, decoded)
-	slo, err := getSLO(methodName, decoded)
+	g.serveGateway(w, r, req)
+}
+
+func (g *Gateway) serveGateway(w http.ResponseWriter, r *http.Request, req *GatewayRequest) {
+	setLogFields(r.Context(), map[string]interface{}{
+		"sender": req.Sender,
+		"method": g.methodName(req.Data),
+	})
+	requestLogger(r.Context()).Debug().Str("calldata", logCalldata(req.Data)).Msg("gateway request")
+	if !g.senders.SupportsSender(req.Sender) {
+		requestsTotal.WithLabelValues(g.methodName(req.Data), strconv.Itoa(http.StatusBadRequest)).Inc()
+		render.Render(w, r, ErrInvalidRequest(fmt.Errorf("unsupported sender %s", req.Sender)))
+		return
+	}
+	selector, err := ParseProofSelector(req.Block, req.BatchIndex, req.StateRoot)
 	if err != nil {
-		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		requestsTotal.WithLabelValues(g.methodName(req.Data), strconv.Itoa(http.StatusBadRequest)).Inc()
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
 	}
-	log.Printf("slo: %+v", slo)
-	blockNum, err := g.l2EthClient.BlockNumber(r.Context())
+	proof, err := g.cachedProve(r.Context(), req.Data, selector)
 	if err != nil {
-		http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+		errResponse := ErrRender(err)
+		requestLogger(r.Context()).WithLevel(statusLevel(errResponse.HTTPStatusCode)).Err(err).
+			Str("calldata", logCalldata(req.Data)).
+			Msg("proving failed")
+		requestsTotal.WithLabelValues(g.methodName(req.Data), strconv.Itoa(errResponse.HTTPStatusCode)).Inc()
+		render.Render(w, r, errResponse)
+		return
 	}
-	addressSlot := fmt.Sprintf("0x%s", hex.EncodeToString(slo))
-	res, err := g.l2GethClient.GetProof(r.Context(), g.l2ResolverAddress, []string{addressSlot}, new(big.Int).SetUint64(blockNum))
+	if g.setCacheHeaders(r.Context(), w, r, proof) {
+		requestsTotal.WithLabelValues(proof.MethodName, strconv.Itoa(http.StatusNotModified)).Inc()
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	requestsTotal.WithLabelValues(proof.MethodName, strconv.Itoa(http.StatusOK)).Inc()
+
+	render.Render(
+		w, r,
+		GatewayResponse{
+			Data:    fmt.Sprintf("0x%s", hex.EncodeToString(proof.Encoded)),
+			Exists:  proof.Exists(),
+			Absence: proof.Absence,
+		},
+	)
+}
+
+// methodName returns the handled method the calldata selects, or "unknown".
+func (g *Gateway) methodName(hexCalldata string) string {
+	calldata, err := DecodeHex(hexCalldata)
+	if err != nil || len(calldata) < 4 {
+		return "unknown"
+	}
+	var sig [4]byte
+	copy(sig[:], calldata[:4])
+	if handler, ok := g.handlers.Lookup(sig); ok {
+		return handler.Name
+	}
+	return "unknown"
+}
+
+// cachedProve serves historical proofs from the proof cache. Proofs against
+// the latest head are always regenerated.
+func (g *Gateway) cachedProve(ctx context.Context, hexCalldata string, selector ProofSelector) (*ProofResult, error) {
+	if selector.IsLatest() || g.proofCache == nil {
+		return g.prove(ctx, hexCalldata, selector)
+	}
+	key := strings.ToLower(hexCalldata) + "@" + selector.String()
+	if cached, ok := g.proofCache.Get(key); ok {
+		observeCache("proof", true)
+		return cached.(*ProofResult), nil
+	}
+	observeCache("proof", false)
+	proof, err := g.prove(ctx, hexCalldata, selector)
 	if err != nil {
-		http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+		return nil, err
 	}
-	log.Printf("getProof result: %+v", res)
-	jsonBody, err := json.Marshal(StateRootProofInput{
-		ResolverAddr: g.l2ResolverAddress.Hex(),
-		AddrSlot:     addressSlot,
-	})
+	cached := *proof
+	cached.Debug = nil
+	g.proofCache.Add(key, &cached)
+	return proof, nil
+}
+
+func (g *Gateway) prove(ctx context.Context, hexCalldata string, selector ProofSelector) (result *ProofResult, err error) {
+	ctx, span := tracer.Start(ctx, "prove")
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	_, phase, end := startPhase(ctx, phaseDecode)
+	handler, call, err := g.decode(hexCalldata)
 	if err != nil {
-		http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+		end(err)
+		return nil, badRequest(err)
 	}
-	bodyReader := bytes.NewReader(jsonBody)
-	req, err := http.NewRequest(http.MethodPost, "http://localhost:41235/storage_proof", bodyReader)
+	methodName := handler.Name
+	slo, node := call.Slot, call.Node
+	addressSlot := fmt.Sprintf("0x%s", hex.EncodeToString(slo))
+	attrs := []attribute.KeyValue{attrMethod.String(methodName), attrSlot.String(addressSlot)}
+	fields := map[string]interface{}{"slot": addressSlot}
+	var name string
+	if node != nil {
+		attrs = append(attrs, attrNode.String(node.Hex()))
+		fields["node"] = node.Hex()
+		if n, ok := g.nodeName(*node); ok {
+			name = n
+			attrs = append(attrs, attrName.String(name))
+			fields["name"] = name
+		}
+	}
+	span.SetAttributes(attrs...)
+	phase.SetAttributes(attrs...)
+	setLogFields(ctx, fields)
+	end(nil)
+
+	phaseCtx, phase, end := startPhase(ctx, phaseStateRootProof)
+	stateRootProof, err := g.stateRoots.StateRootProof(phaseCtx, selector.stateRootProofInput(g.l2ResolverAddress.Hex(), addressSlot))
+	end(err)
 	if err != nil {
-		http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+		return nil, err
 	}
-	req.Header.Set("Content-Type", "application/json")
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	batchIndex := attrBatchIndex.String(stateRootProof.BatchIndex().String())
+	phase.SetAttributes(batchIndex)
+	span.SetAttributes(batchIndex)
+	setLogFields(ctx, map[string]interface{}{"batchIndex": stateRootProof.BatchIndex().String()})
+	requestLogger(ctx).Debug().Str("state_root", stateRootProof.StateRoot).Msg("got state root proof")
+
+	phaseCtx, _, end = startPhase(ctx, phaseBlockNumber)
+	blockNum, err := g.proofBlockNumber(phaseCtx, selector, stateRootProof)
+	end(err)
 	if err != nil {
-		http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+		return nil, err
 	}
-	defer resp.Body.Close()
+	span.SetAttributes(attrBlock.String(blockNum.String()))
+	setLogFields(ctx, map[string]interface{}{"block": blockNum.String()})
 
-	fmt.Println("response Status:", resp.Status)
-	fmt.Println("response Headers:", resp.Header)
-	body, err := io.ReadAll(resp.Body)
+	phaseCtx, _, end = startPhase(ctx, phaseGetProof, attrBlock.String(blockNum.String()))
+	res, err := g.l2.GetProof(phaseCtx, g.l2ResolverAddress, []string{addressSlot}, blockNum)
+	end(err)
 	if err != nil {
-		fmt.Printf("reading stateRootProof body: %s", err)
-		http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+		if !selector.IsLatest() && isMissingStateErr(err) {
+			return nil, &gatewayError{status: http.StatusNotImplemented, err: fmt.Errorf("%w at block %s", ErrHistoricalStateUnavailable, blockNum)}
+		}
+		return nil, badGateway(fmt.Errorf("getting proof at block %s: %w", blockNum, err))
 	}
-	stateRootProof := &StateRootProof{}
-	err = json.Unmarshal(body, stateRootProof)
+	requestLogger(ctx).Trace().Interface("proof", res).Msg("got L2 proof")
+
+	phaseCtx, _, end = startPhase(ctx, phaseVerify, attrBlock.String(blockNum.String()))
+	header, err := g.l2.HeaderByNumber(phaseCtx, blockNum)
 	if err != nil {
-		fmt.Printf("unmarshaling stateRootProof body: %s", err)
-		http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+		end(err)
+		return nil, badGateway(fmt.Errorf("getting L2 header %s: %w", blockNum, err))
 	}
-	log.Printf("stateRootProof: %+v", stateRootProof)
-
-	stateRootProof.StateTrieWitness, err = rlp.EncodeToBytes(res.AccountProof)
+	if header.Root != common.HexToHash(stateRootProof.StateRoot) {
+		err = fmt.Errorf("L2 state root %s at block %s does not match committed root %s", header.Root, blockNum, stateRootProof.StateRoot)
+		end(err)
+		return nil, badGateway(err)
+	}
+	value, absence, err := checkProof(header.Root, res, slo)
+	end(err)
 	if err != nil {
-		fmt.Printf("RLP encoding failed: %s", err)
-		http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+		return nil, badGateway(fmt.Errorf("checking proof at block %s: %w", blockNum, err))
+	}
+	observeStateRootAge(header.Time)
+	if absence != AbsenceNone {
+		requestLogger(ctx).Debug().Str("absence", string(absence)).Msg("proof excludes slot")
 	}
 
-	stateRootProof.StorageTrieWitness, err = rlp.EncodeToBytes(res.StorageProof)
+	_, _, end = startPhase(ctx, phaseEncode)
+	encoded, err := g.encodeWitnesses(stateRootProof, res)
+	end(err)
 	if err != nil {
-		fmt.Printf("RLP encoding failed: %s", err)
-		http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+		return nil, err
 	}
+	return &ProofResult{
+		MethodName:  methodName,
+		Node:        node,
+		Name:        name,
+		Slot:        common.BytesToHash(slo),
+		StateRoot:   header.Root,
+		BlockNumber: blockNum,
+		BlockTime:   header.Time,
+		Historical:  !selector.IsLatest(),
+		Encoded:     encoded,
+		Value:       value,
+		Absence:     absence,
+		Debug: &ProofDebug{
+			Handler:        handler,
+			Call:           call,
+			StateRootProof: stateRootProof,
+			Account:        res,
+		},
+	}, nil
+}
 
-	stateRootProofBytes, err := encodeProof(stateRootProof)
+// encodeWitnesses attaches the account and storage witnesses from res to the
+// state root proof and ABI encodes it.
+func (g *Gateway) encodeWitnesses(stateRootProof *StateRootProof, res *gethclient.AccountResult) ([]byte, error) {
+	var err error
+	stateRootProof.StateTrieWitness, err = encodeWitness(res.AccountProof)
 	if err != nil {
-		fmt.Printf("unmarshaling stateRootProof body: %s", err)
-		http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+		return nil, fmt.Errorf("RLP encoding account proof: %w", err)
+	}
+
+	if len(res.StorageProof) > 0 {
+		stateRootProof.StorageTrieWitness, err = encodeWitness(res.StorageProof[0].Proof)
+		if err != nil {
+			return nil, fmt.Errorf("RLP encoding storage proof: %w", err)
+		}
 	}
 
-	//respBytes, err := json.Marshal(
-	//	GatewayResponse{
-	//		Data: fmt.Sprintf("0x%s", hex.EncodeToString(stateRootProofBytes)),
-	//	},
-	//)
-	//if err != nil {
-	//	fmt.Printf("unmarshaling stateRootProof body: %s", err)
-	//	http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
-	//}
+	encoded, err := encodeProof(stateRootProof)
+	if err != nil {
+		return nil, fmt.Errorf("encoding proof: %w", err)
+	}
+	return encoded, nil
+}
 
-	render.Render(
-		w, r,
-		GatewayResponse{
-			Data: fmt.Sprintf("0x%s", hex.EncodeToString(stateRootProofBytes)),
-		},
-	)
-	//w.Write(respBytes)
+// proofBlockNumber returns the L2 block the storage proof must be generated
+// at: the block committed by the returned batch header, so the witnesses match
+// its state root.
+func (g *Gateway) proofBlockNumber(ctx context.Context, selector ProofSelector, stateRootProof *StateRootProof) (*big.Int, error) {
+	blockNum := stateRootProof.L2BlockNumber()
+	if selector.IsLatest() {
+		head, err := g.l2.BlockNumber(ctx)
+		if err != nil {
+			return nil, badGateway(fmt.Errorf("getting L2 block number: %w", err))
+		}
+		if blockNum.Cmp(new(big.Int).SetUint64(head)) > 0 {
+			return nil, badGateway(fmt.Errorf("L2 RPC head %d is behind committed block %s", head, blockNum))
+		}
+		return blockNum, nil
+	}
+	if selector.Block != nil && selector.Block.Cmp(blockNum) != 0 {
+		return nil, badGateway(fmt.Errorf("state root proof is for block %s, requested %s", blockNum, selector.Block))
+	}
+	if selector.BatchIndex != nil && selector.BatchIndex.Cmp(stateRootProof.BatchIndex()) != 0 {
+		return nil, badGateway(fmt.Errorf("state root proof is for batch %s, requested %s", stateRootProof.BatchIndex(), selector.BatchIndex))
+	}
+	if selector.StateRoot != nil && *selector.StateRoot != common.HexToHash(stateRootProof.StateRoot) {
+		return nil, badGateway(fmt.Errorf("state root proof is for root %s, requested %s", stateRootProof.StateRoot, selector.StateRoot))
+	}
+	return blockNum, nil
 }
 
 func encodeProof(proofObj *StateRootProof) (resp []byte, err error) {
-	tmp := convertToContractProof(proofObj)
-
-	enc := mustParseABI(stateProof)
-	return enc.Methods["helper"].Inputs.Pack(tmp)
-}
-
-type SP struct {
-	StateRoot            [32]byte `json:"stateRoot"`
-	StateRootBatchHeader struct {
-		BatchIndex        *big.Int `json:"batchIndex"`
-		BatchRoot         [32]byte `json:"batchRoot"`
-		BatchSize         *big.Int `json:"batchSize"`
-		PrevTotalElements *big.Int `json:"prevTotalElements"`
-		ExtraData         []byte   `json:"extraData"`
-	} `json:"stateRootBatchHeader"`
-	StateRootProof struct {
-		Index    *big.Int   `json:"index"`
-		Siblings [][32]byte `json:"siblings"`
-	} `json:"stateRootProof"`
-	StateTrieWitness   []byte `json:"stateTrieWitness"`
-	StorageTrieWitness []byte `json:"storageTrieWitness"`
+	return verifier.Encode(convertToContractProof(proofObj))
 }
 
-func convertToContractProof(obj *StateRootProof) (sp *SP) {
-	sp = new(SP)
+func convertToContractProof(obj *StateRootProof) (sp *verifier.L2StateProof) {
+	sp = new(verifier.L2StateProof)
 
 	copy(sp.StateRoot[:], parseHex(obj.StateRoot))
-	sp.StateRootBatchHeader.BatchIndex = big.NewInt(0).SetBytes(parseHex(obj.StateRootBatchHeader.BatchIndex.Hex))
+	sp.StateRootBatchHeader.BatchIndex = obj.StateRootBatchHeader.BatchIndex.Int()
 	copy(sp.StateRootBatchHeader.BatchRoot[:], parseHex(obj.StateRootBatchHeader.BatchRoot))
-	sp.StateRootBatchHeader.BatchSize = big.NewInt(0).SetBytes(parseHex(obj.StateRootBatchHeader.BatchSize.Hex))
-	sp.StateRootBatchHeader.PrevTotalElements = big.NewInt(0).SetBytes(parseHex(obj.StateRootBatchHeader.PrevTotalElements.Hex))
+	sp.StateRootBatchHeader.BatchSize = obj.StateRootBatchHeader.BatchSize.Int()
+	sp.StateRootBatchHeader.PrevTotalElements = obj.StateRootBatchHeader.PrevTotalElements.Int()
 	sp.StateRootBatchHeader.ExtraData = parseHex(obj.StateRootBatchHeader.ExtraData)
 	sp.StateRootProof.Index = big.NewInt(int64(obj.StateRootProof.Index))
 
@@ -331,7 +706,7 @@ func convertToContractProof(obj *StateRootProof) (sp *SP) {
 }
 
 func parseHex(str string) (b []byte) {
-	b, _ = hex.DecodeString(strings.TrimPrefix("0x", str))
+	b, _ = hex.DecodeString(strings.TrimPrefix(str, "0x"))
 	return
 }
 
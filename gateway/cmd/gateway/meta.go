@@ -0,0 +1,304 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/0xpaulio/eth-sf-ens-rr/relayer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+// MetaTxConfig configures the gasless meta-transaction relayer.
+type MetaTxConfig struct {
+	Enabled bool
+	// Key is the private key of the account paying for relayed requests.
+	Key *ecdsa.PrivateKey
+	// DBPath is the LevelDB outbox directory. Empty keeps the outbox in
+	// memory, so queued requests are lost on restart.
+	DBPath string
+	relayer.Config
+}
+
+func metaTxConfigFromEnv(registry common.Address) (MetaTxConfig, error) {
+	config := MetaTxConfig{
+		DBPath: GetOrDefault("META_TX_DB_PATH", ""),
+		Config: relayer.Config{Registry: registry},
+	}
+	var err error
+	if config.Enabled, err = strconv.ParseBool(GetOrDefault("META_TX_ENABLED", "false")); err != nil {
+		return config, fmt.Errorf("parsing META_TX_ENABLED: %w", err)
+	}
+	if !config.Enabled {
+		return config, nil
+	}
+	if config.Key, err = crypto.HexToECDSA(trimHexPrefix(Must("META_TX_RELAYER_KEY"))); err != nil {
+		return config, errors.New("parsing META_TX_RELAYER_KEY: invalid private key")
+	}
+	forwarder := Must("L2_FORWARDER_ADDR")
+	if !common.IsHexAddress(forwarder) {
+		return config, fmt.Errorf("invalid L2_FORWARDER_ADDR %q", forwarder)
+	}
+	config.Forwarder = common.HexToAddress(forwarder)
+	for _, n := range []struct {
+		env string
+		def string
+		dst *uint64
+	}{
+		{"META_TX_MAX_GAS", "300000", &config.MaxGas},
+		{"META_TX_GAS_OVERHEAD", "100000", &config.GasOverhead},
+		{"META_TX_BUMP_PERCENT", "20", &config.BumpPercent},
+		{"META_TX_MAX_ATTEMPTS", "5", &config.MaxAttempts},
+	} {
+		if *n.dst, err = strconv.ParseUint(GetOrDefault(n.env, n.def), 10, 64); err != nil {
+			return config, fmt.Errorf("parsing %s: %w", n.env, err)
+		}
+	}
+	if config.Quota, err = strconv.Atoi(GetOrDefault("META_TX_QUOTA", "20")); err != nil || config.Quota < 0 {
+		return config, fmt.Errorf("parsing META_TX_QUOTA: invalid count %q", GetOrDefault("META_TX_QUOTA", "20"))
+	}
+	for _, d := range []struct {
+		env string
+		def string
+		dst *time.Duration
+	}{
+		{"META_TX_QUOTA_WINDOW", "24h", &config.QuotaWindow},
+		{"META_TX_BUMP_AFTER", "1m", &config.BumpAfter},
+		{"META_TX_POLL_INTERVAL", "5s", &config.PollInterval},
+	} {
+		if *d.dst, err = time.ParseDuration(GetOrDefault(d.env, d.def)); err != nil {
+			return config, fmt.Errorf("parsing %s: %w", d.env, err)
+		}
+	}
+	switch {
+	case config.MaxGas == 0:
+		return config, errors.New("META_TX_MAX_GAS must be positive")
+	case config.MaxAttempts == 0:
+		return config, errors.New("META_TX_MAX_ATTEMPTS must be positive")
+	case config.QuotaWindow <= 0:
+		return config, errors.New("META_TX_QUOTA_WINDOW must be positive")
+	case config.BumpAfter <= 0:
+		return config, errors.New("META_TX_BUMP_AFTER must be positive")
+	case config.PollInterval <= 0:
+		return config, errors.New("META_TX_POLL_INTERVAL must be positive")
+	}
+	// 100 gwei.
+	if config.MaxFeeCap, err = parseUint(GetOrDefault("META_TX_MAX_FEE_CAP", "100000000000")); err != nil {
+		return config, fmt.Errorf("parsing META_TX_MAX_FEE_CAP: %w", err)
+	}
+	// 1 ETH per quota window, across all senders.
+	if config.SpendCap, err = parseUint(GetOrDefault("META_TX_SPEND_CAP", "1000000000000000000")); err != nil {
+		return config, fmt.Errorf("parsing META_TX_SPEND_CAP: %w", err)
+	}
+	return config, nil
+}
+
+func trimHexPrefix(s string) string {
+	if len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+		return s[2:]
+	}
+	return s
+}
+
+// ForwardRequest is a forwarder ForwardRequest as signed by its sender.
+// Numbers may be decimal or 0x-prefixed hex.
+type ForwardRequest struct {
+	From  common.Address        `json:"from"`
+	To    common.Address        `json:"to"`
+	Value *math.HexOrDecimal256 `json:"value,omitempty"`
+	Gas   math.HexOrDecimal64   `json:"gas"`
+	Nonce math.HexOrDecimal64   `json:"nonce"`
+	Data  hexutil.Bytes         `json:"data"`
+}
+
+// MetaTxRequest is the POST /v1/meta body.
+type MetaTxRequest struct {
+	Request   ForwardRequest `json:"request"`
+	Signature hexutil.Bytes  `json:"signature"`
+}
+
+func (req *MetaTxRequest) Bind(r *http.Request) error {
+	if len(req.Signature) == 0 {
+		return errors.New("signature is required")
+	}
+	return nil
+}
+
+// MetaTxStatus reports a relayed request. TxHash is the latest transaction
+// sent for it until one is mined, then the mined one.
+type MetaTxStatus struct {
+	ID          uint64         `json:"id"`
+	Digest      common.Hash    `json:"digest"`
+	Method      string         `json:"method"`
+	From        common.Address `json:"from"`
+	Nonce       uint64         `json:"nonce"`
+	Status      string         `json:"status"`
+	TxHash      *common.Hash   `json:"txHash,omitempty"`
+	BlockNumber *uint64        `json:"blockNumber,omitempty"`
+	Attempts    uint64         `json:"attempts"`
+	Error       string         `json:"error,omitempty"`
+}
+
+func newMetaTxStatus(entry *relayer.Entry) MetaTxStatus {
+	status := MetaTxStatus{
+		ID:       entry.ID,
+		Digest:   entry.Digest,
+		Method:   entry.Method,
+		From:     entry.Request.From,
+		Nonce:    entry.Request.Nonce,
+		Status:   entry.Status,
+		Attempts: entry.Attempts,
+		Error:    entry.Error,
+	}
+	switch {
+	case entry.TxHash != (common.Hash{}):
+		hash, block := entry.TxHash, entry.BlockNumber
+		status.TxHash, status.BlockNumber = &hash, &block
+	case len(entry.TxHashes) > 0:
+		hash := entry.TxHashes[len(entry.TxHashes)-1]
+		status.TxHash = &hash
+	}
+	return status
+}
+
+func (MetaTxStatus) Render(w http.ResponseWriter, r *http.Request) error {
+	w.Header().Set("Cache-Control", "no-store")
+	return nil
+}
+
+// MetaTxAccount is what a sender needs to sign its next request: the nonce
+// to use, the EIP-712 domain and types, and its remaining quota.
+type MetaTxAccount struct {
+	Address     common.Address           `json:"address"`
+	Nonce       uint64                   `json:"nonce"`
+	Domain      apitypes.TypedDataDomain `json:"domain"`
+	Types       apitypes.Types           `json:"types"`
+	PrimaryType string                   `json:"primaryType"`
+	Registry    common.Address           `json:"registry"`
+	Methods     []string                 `json:"methods"`
+	Quota       *MetaTxQuota             `json:"quota,omitempty"`
+}
+
+// MetaTxQuota is a sender's use of its request quota.
+type MetaTxQuota struct {
+	Limit  int    `json:"limit"`
+	Used   int    `json:"used"`
+	Window string `json:"window"`
+}
+
+func (MetaTxAccount) Render(w http.ResponseWriter, r *http.Request) error {
+	w.Header().Set("Cache-Control", "no-store")
+	return nil
+}
+
+// postMetaTx queues a signed forward request.
+func (g *Gateway) postMetaTx(w http.ResponseWriter, r *http.Request) {
+	body := &MetaTxRequest{}
+	if err := render.Bind(r, body); err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	req := &relayer.Request{
+		From:      body.Request.From,
+		To:        body.Request.To,
+		Value:     (*big.Int)(body.Request.Value),
+		Gas:       uint64(body.Request.Gas),
+		Nonce:     uint64(body.Request.Nonce),
+		Data:      body.Request.Data,
+		Signature: body.Signature,
+	}
+	setLogFields(r.Context(), map[string]interface{}{"from": req.From.Hex(), "nonce": req.Nonce})
+	entry, err := g.metaTx.Submit(r.Context(), req)
+	if err != nil {
+		errResponse := metaTxError(err)
+		requestLogger(r.Context()).WithLevel(statusLevel(errResponse.HTTPStatusCode)).Err(err).Msg("forward request rejected")
+		render.Render(w, r, errResponse)
+		return
+	}
+	render.Status(r, http.StatusAccepted)
+	render.Render(w, r, newMetaTxStatus(entry))
+}
+
+// getMetaTx reports a relayed request by id.
+func (g *Gateway) getMetaTx(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(fmt.Errorf("invalid request id %q", chi.URLParam(r, "id"))))
+		return
+	}
+	entry, err := g.metaTx.Outbox().Get(id)
+	if err != nil {
+		render.Render(w, r, metaTxError(err))
+		return
+	}
+	render.Render(w, r, newMetaTxStatus(entry))
+}
+
+// getMetaTxAccount returns what {address} needs to sign its next request.
+func (g *Gateway) getMetaTxAccount(w http.ResponseWriter, r *http.Request) {
+	address, err := parseAddressParam(r, "address")
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	domain, err := g.metaTx.Domain(r.Context())
+	if err != nil {
+		render.Render(w, r, ErrRender(badGateway(err)))
+		return
+	}
+	nonce, err := g.metaTx.Nonce(r.Context(), address)
+	if err != nil {
+		render.Render(w, r, ErrRender(badGateway(err)))
+		return
+	}
+	resp := MetaTxAccount{
+		Address:     address,
+		Nonce:       nonce,
+		Domain:      domain,
+		Types:       relayer.Types,
+		PrimaryType: "ForwardRequest",
+		Registry:    g.l2ResolverAddress,
+	}
+	for _, method := range relayer.Methods {
+		resp.Methods = append(resp.Methods, method.Name)
+	}
+	sort.Strings(resp.Methods)
+	if config := g.metaTx.Config(); config.Quota > 0 {
+		used, err := g.metaTx.Used(address)
+		if err != nil {
+			render.Render(w, r, ErrRender(err))
+			return
+		}
+		resp.Quota = &MetaTxQuota{Limit: config.Quota, Used: used, Window: config.QuotaWindow.String()}
+	}
+	render.Render(w, r, resp)
+}
+
+// metaTxError maps relayer errors to responses.
+func metaTxError(err error) *ErrResponse {
+	status := 0
+	switch {
+	case errors.Is(err, relayer.ErrInvalidRequest):
+		status = http.StatusBadRequest
+	case errors.Is(err, relayer.ErrDuplicate):
+		status = http.StatusConflict
+	case errors.Is(err, relayer.ErrQuotaExceeded):
+		status = http.StatusTooManyRequests
+	case errors.Is(err, relayer.ErrNotFound):
+		status = http.StatusNotFound
+	default:
+		return ErrRender(err)
+	}
+	return ErrRender(&gatewayError{status: status, err: err})
+}
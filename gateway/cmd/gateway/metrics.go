@@ -0,0 +1,77 @@
+package main
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Pipeline phases, in the order getGateway runs them.
+const (
+	phaseDecode         = "decode"
+	phaseStateRootProof = "state_root_proof"
+	phaseBlockNumber    = "block_number"
+	phaseGetProof       = "get_proof"
+	phaseVerify         = "verify"
+	phaseEncode         = "encode"
+)
+
+const (
+	upstreamL2        = "l2_rpc"
+	upstreamStateRoot = "state_root_provider"
+)
+
+var (
+	requestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gateway",
+		Name:      "requests_total",
+		Help:      "Gateway requests by decoded method name and HTTP status.",
+	}, []string{"method", "status"})
+
+	phaseDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "gateway",
+		Name:      "phase_duration_seconds",
+		Help:      "Latency of each step of the proof pipeline.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 14),
+	}, []string{"phase"})
+
+	upstreamErrorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gateway",
+		Name:      "upstream_errors_total",
+		Help:      "Failed calls to upstream services by upstream and call.",
+	}, []string{"upstream", "call"})
+
+	cacheRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gateway",
+		Name:      "cache_requests_total",
+		Help:      "Cache lookups by cache and result (hit or miss).",
+	}, []string{"cache", "result"})
+
+	stateRootAge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "gateway",
+		Name:      "state_root_age_seconds",
+		Help:      "Age of the L2 block whose state root was most recently served.",
+	})
+)
+
+// observePhase records the time spent in phase since start.
+func observePhase(phase string, start time.Time) {
+	phaseDuration.WithLabelValues(phase).Observe(time.Since(start).Seconds())
+}
+
+func observeUpstreamError(upstream, call string) {
+	upstreamErrorsTotal.WithLabelValues(upstream, call).Inc()
+}
+
+func observeCache(cache string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	cacheRequestsTotal.WithLabelValues(cache, result).Inc()
+}
+
+func observeStateRootAge(blockTime uint64) {
+	stateRootAge.Set(time.Since(time.Unix(int64(blockTime), 0)).Seconds())
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+
+	"github.com/0xpaulio/eth-sf-ens-rr/bindings"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+// nameLookups builds the L1 registry calldata for the lookups the /v1/name
+// routes serve.
+var nameLookups = map[string]func(node [32]byte) ([]byte, error){
+	"owner": func(node [32]byte) ([]byte, error) {
+		return bindings.L1ENSRegistryOwnerArgs{Node: node}.Pack()
+	},
+	"resolver": func(node [32]byte) ([]byte, error) {
+		return bindings.L1ENSRegistryResolverArgs{Node: node}.Pack()
+	},
+	"ttl": func(node [32]byte) ([]byte, error) {
+		return bindings.L1ENSRegistryTtlArgs{Node: node}.Pack()
+	},
+}
+
+// recordLookups are the lookups /v1/name/{name}/records proves.
+var recordLookups = []string{"owner", "resolver", "ttl"}
+
+// NameResponse holds proven lookups of a normalised name.
+type NameResponse struct {
+	Name    string                `json:"name"`
+	Node    common.Hash           `json:"node"`
+	Results map[string]NameResult `json:"results"`
+}
+
+func (NameResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+// NameResult is one proven lookup. Data is what the gateway route would
+// return, and CallbackData the complete call to the L1 callback the
+// OffchainLookup names.
+type NameResult struct {
+	Value        interface{} `json:"value"`
+	Exists       bool        `json:"exists"`
+	Absence      Absence     `json:"absence,omitempty"`
+	StateRoot    common.Hash `json:"stateRoot"`
+	BlockNumber  *big.Int    `json:"blockNumber"`
+	Data         string      `json:"data"`
+	Callback     string      `json:"callback"`
+	CallbackData string      `json:"callbackData"`
+}
+
+func (g *Gateway) getNameOwner(w http.ResponseWriter, r *http.Request) {
+	g.serveName(w, r, "owner")
+}
+
+func (g *Gateway) getNameResolver(w http.ResponseWriter, r *http.Request) {
+	g.serveName(w, r, "resolver")
+}
+
+func (g *Gateway) getNameTTL(w http.ResponseWriter, r *http.Request) {
+	g.serveName(w, r, "ttl")
+}
+
+func (g *Gateway) getNameRecords(w http.ResponseWriter, r *http.Request) {
+	g.serveName(w, r, recordLookups...)
+}
+
+// serveName normalises {name} and proves each lookup through the same
+// pipeline as the gateway route. The usual block, batchIndex and stateRoot
+// query parameters select a historical root.
+func (g *Gateway) serveName(w http.ResponseWriter, r *http.Request, lookups ...string) {
+	name, err := normalizeName(chi.URLParam(r, "name"))
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	query := r.URL.Query()
+	selector, err := ParseProofSelector(query.Get("block"), query.Get("batchIndex"), query.Get("stateRoot"))
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	node := namehash(name)
+	setLogFields(r.Context(), map[string]interface{}{"name": name})
+
+	resp := NameResponse{Name: name, Node: node, Results: make(map[string]NameResult)}
+	for _, lookup := range lookups {
+		result, err := g.proveName(r, lookup, node, selector)
+		if err != nil {
+			errResponse := ErrRender(err)
+			requestLogger(r.Context()).WithLevel(statusLevel(errResponse.HTTPStatusCode)).Err(err).
+				Str("lookup", lookup).
+				Msg("proving failed")
+			render.Render(w, r, errResponse)
+			return
+		}
+		resp.Results[lookup] = result
+	}
+	w.Header().Set("Cache-Control", "no-cache")
+	render.Render(w, r, resp)
+}
+
+func (g *Gateway) proveName(r *http.Request, lookup string, node common.Hash, selector ProofSelector) (NameResult, error) {
+	calldata, err := nameLookups[lookup](node)
+	if err != nil {
+		return NameResult{}, fmt.Errorf("packing %s calldata: %w", lookup, err)
+	}
+	proof, err := g.cachedProve(r.Context(), hexutil.Encode(calldata), selector)
+	if err != nil {
+		return NameResult{}, err
+	}
+	handler, _ := g.handlers.ByName(lookup)
+	// The L1 registry's OffchainLookup extraData is the ABI-encoded node.
+	callbackData, err := abi.Pack(handler.Callback, proof.Encoded, node.Bytes())
+	if err != nil {
+		return NameResult{}, fmt.Errorf("packing %s calldata: %w", handler.Callback, err)
+	}
+	return NameResult{
+		Value:        handler.Result(proof.Value),
+		Exists:       proof.Exists(),
+		Absence:      proof.Absence,
+		StateRoot:    proof.StateRoot,
+		BlockNumber:  proof.BlockNumber,
+		Data:         hexutil.Encode(proof.Encoded),
+		Callback:     handler.Callback,
+		CallbackData: hexutil.Encode(callbackData),
+	}, nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/adraffy/go-ens-normalize/ensip15"
+)
+
+// NameError is a name that fails normalisation.
+type NameError struct {
+	Name   string
+	Label  int
+	Reason string
+}
+
+func (e *NameError) Error() string {
+	if e.Label < 0 {
+		return fmt.Sprintf("invalid name %q: %s", e.Name, e.Reason)
+	}
+	return fmt.Sprintf("invalid name %q: label %d: %s", e.Name, e.Label+1, e.Reason)
+}
+
+// normalizeName normalises name following ENSIP-15. ENSIP-15 splits names on
+// "." before mapping, so each label is normalised on its own to report which
+// one is invalid.
+func normalizeName(name string) (string, error) {
+	if name == "" {
+		return "", &NameError{Name: name, Label: -1, Reason: "empty name"}
+	}
+	if !utf8.ValidString(name) {
+		return "", &NameError{Name: name, Label: -1, Reason: "not valid UTF-8"}
+	}
+	labels := strings.Split(name, ".")
+	for i, label := range labels {
+		if label == "" {
+			return "", &NameError{Name: name, Label: i, Reason: "empty label"}
+		}
+		normalized, err := ensip15.Shared().Normalize(label)
+		if err != nil {
+			return "", &NameError{Name: name, Label: i, Reason: err.Error()}
+		}
+		labels[i] = normalized
+	}
+	return strings.Join(labels, "."), nil
+}
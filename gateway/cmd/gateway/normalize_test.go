@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeName(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		want string
+	}{
+		{"Alice.ETH", "alice.eth"},
+		{"_alice.eth", "_alice.eth"},
+		{"straße.eth", "straße.eth"},
+		{"❤️.eth", "❤.eth"},
+		{"👨‍👩‍👧.eth", "👨‍👩‍👧.eth"},
+		{"👩🏽‍💻.eth", "👩🏽‍💻.eth"},
+		{"ΞΕΝΟΣ.eth", "ξενοσ.eth"},
+		{"ＡＢＣ.eth", "abc.eth"},
+		{"a-b-c.eth", "a-b-c.eth"},
+	} {
+		got, err := normalizeName(tt.name)
+		if err != nil {
+			t.Errorf("normalizeName(%q) failed: %v", tt.name, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("normalizeName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	for _, name := range []string{
+		"",
+		"alice..eth",
+		"a b.eth",
+		"a_b.eth",
+		"ab--c.eth",
+		"xn--ls8h.eth",
+		"a\u200db.eth",
+		"́a.eth",
+		"’alice.eth",
+		"a··b.eth",
+		"ΑΒΓ.eth",
+		"раураl.eth",
+	} {
+		if got, err := normalizeName(name); err == nil {
+			t.Errorf("normalizeName(%q) = %q, want an error", name, got)
+		}
+	}
+}
+
+// TestNormalizeNameVectors checks normalizeName against the ENSIP-15
+// validation tests shipped with the normaliser, or ENSIP15_TESTS when set.
+func TestNormalizeNameVectors(t *testing.T) {
+	path := os.Getenv("ENSIP15_TESTS")
+	if path == "" {
+		dir, err := exec.Command("go", "list", "-m", "-f", "{{.Dir}}", "github.com/adraffy/go-ens-normalize").Output()
+		if err != nil {
+			t.Skipf("locating the normaliser module: %v", err)
+		}
+		path = filepath.Join(strings.TrimSpace(string(dir)), "ensip15", "tests.json")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Skipf("no ENSIP-15 tests: %v", err)
+	}
+	var tests []struct {
+		Name  string `json:"name"`
+		Norm  string `json:"norm"`
+		Error bool   `json:"error"`
+	}
+	if err := json.Unmarshal(data, &tests); err != nil {
+		t.Fatal(err)
+	}
+	// The first entry describes the version of the tests.
+	for _, tt := range tests[1:] {
+		if tt.Name == "" {
+			// Valid in ENSIP-15, but not a name the gateway resolves.
+			continue
+		}
+		if tt.Norm == "" {
+			tt.Norm = tt.Name
+		}
+		got, err := normalizeName(tt.Name)
+		switch {
+		case tt.Error && err == nil:
+			t.Errorf("normalizeName(%+q) = %+q, want an error", tt.Name, got)
+		case !tt.Error && err != nil:
+			t.Errorf("normalizeName(%+q) failed: %v", tt.Name, err)
+		case !tt.Error && got != tt.Norm:
+			t.Errorf("normalizeName(%+q) = %+q, want %+q", tt.Name, got, tt.Norm)
+		}
+	}
+}
@@ -0,0 +1,420 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// L2Client is the subset of the L2 RPC the gateway reads from.
+type L2Client interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	ChainID(ctx context.Context) (*big.Int, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	GetProof(ctx context.Context, account common.Address, keys []string, blockNumber *big.Int) (*gethclient.AccountResult, error)
+	StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error)
+	TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error)
+	TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error)
+}
+
+const (
+	// poolEWMAWeight is the weight of the latest sample in the latency and
+	// error rate moving averages.
+	poolEWMAWeight = 0.2
+	// poolRedialAfter is the number of consecutive failed health checks
+	// after which a node's connection is re-established.
+	poolRedialAfter = 3
+)
+
+var l2NodeHealthy = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: "gateway",
+	Name:      "l2_node_healthy",
+	Help:      "Whether an L2 RPC node passed its last health check.",
+}, []string{"node"})
+
+// l2Node is one L2 RPC endpoint in the pool and its health score.
+type l2Node struct {
+	url  string
+	name string
+
+	mu        sync.RWMutex
+	rpc       *rpc.Client
+	eth       *ethclient.Client
+	geth      *gethclient.Client
+	healthy   bool
+	failures  int
+	latency   time.Duration
+	errorRate float64
+}
+
+func newL2Node(rawURL string) *l2Node {
+	n := &l2Node{url: rawURL, name: redactURL(rawURL)}
+	if err := n.dial(); err != nil {
+		logger.Error().Err(err).Str("node", n.name).Msg("dialing L2 RPC")
+	}
+	return n
+}
+
+func (n *l2Node) dial() error {
+	client, err := dialL2(n.url)
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if err != nil {
+		n.healthy = false
+		return err
+	}
+	if n.rpc != nil {
+		n.rpc.Close()
+	}
+	n.rpc = client
+	n.eth = ethclient.NewClient(client)
+	n.geth = gethclient.New(client)
+	n.healthy = true
+	n.failures = 0
+	return nil
+}
+
+func (n *l2Node) clients() (*ethclient.Client, *gethclient.Client, bool) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.eth, n.geth, n.rpc != nil
+}
+
+// score ranks nodes for selection, lower is better. Latency is penalised by
+// the recent error rate so a fast but flaky node loses to a slower stable one.
+func (n *l2Node) score() float64 {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	score := float64(n.latency) * (1 + 10*n.errorRate)
+	if !n.healthy {
+		score += float64(time.Hour)
+	}
+	return score
+}
+
+func (n *l2Node) observe(latency time.Duration, err error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	sample := 0.0
+	if err != nil {
+		sample = 1
+	}
+	n.errorRate = (1-poolEWMAWeight)*n.errorRate + poolEWMAWeight*sample
+	if n.latency == 0 {
+		n.latency = latency
+	} else {
+		n.latency = time.Duration((1-poolEWMAWeight)*float64(n.latency) + poolEWMAWeight*float64(latency))
+	}
+}
+
+// L2Pool spreads L2 reads across several RPC endpoints. Calls go to the best
+// scoring node and are retried on the next one when it fails.
+//
+// When quorum is above one, GetProof instead asks every node and only returns
+// a proof that at least quorum of them agree on.
+type L2Pool struct {
+	nodes    []*l2Node
+	interval time.Duration
+	timeout  time.Duration
+	quorum   int
+}
+
+// NewL2Pool dials every URL. Nodes that fail to dial are kept and retried by
+// the health check loop.
+func NewL2Pool(urls []string, interval, timeout time.Duration, quorum int) (*L2Pool, error) {
+	if len(urls) == 0 {
+		return nil, errors.New("no L2 RPC URLs")
+	}
+	if interval <= 0 || timeout <= 0 {
+		return nil, fmt.Errorf("health check interval %s and timeout %s must be positive", interval, timeout)
+	}
+	if quorum > len(urls) {
+		return nil, fmt.Errorf("proof quorum %d exceeds the %d configured L2 RPC URLs", quorum, len(urls))
+	}
+	p := &L2Pool{interval: interval, timeout: timeout, quorum: quorum}
+	for _, u := range urls {
+		p.nodes = append(p.nodes, newL2Node(u))
+	}
+	return p, nil
+}
+
+// l2PoolFromEnv creates the pool for L2_RPC_URLS, falling back to the single
+// L2_RPC_URL.
+func l2PoolFromEnv() (*L2Pool, error) {
+	urls := splitList(GetOrDefault("L2_RPC_URLS", GetOrDefault("L2_RPC_URL", "https://goerli.optimism.io")))
+	interval, err := time.ParseDuration(GetOrDefault("L2_HEALTH_INTERVAL", "10s"))
+	if err != nil {
+		return nil, fmt.Errorf("parsing L2_HEALTH_INTERVAL: %w", err)
+	}
+	timeout, err := time.ParseDuration(GetOrDefault("L2_HEALTH_TIMEOUT", "3s"))
+	if err != nil {
+		return nil, fmt.Errorf("parsing L2_HEALTH_TIMEOUT: %w", err)
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("L2_HEALTH_INTERVAL must be positive, got %s", interval)
+	}
+	if timeout <= 0 {
+		return nil, fmt.Errorf("L2_HEALTH_TIMEOUT must be positive, got %s", timeout)
+	}
+	quorum, err := strconv.Atoi(GetOrDefault("L2_PROOF_QUORUM", "1"))
+	if err != nil {
+		return nil, fmt.Errorf("parsing L2_PROOF_QUORUM: %w", err)
+	}
+	return NewL2Pool(urls, interval, timeout, quorum)
+}
+
+// Run health checks every node until ctx is done.
+func (p *L2Pool) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		p.checkAll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *L2Pool) checkAll(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, n := range p.nodes {
+		wg.Add(1)
+		go func(n *l2Node) {
+			defer wg.Done()
+			p.check(ctx, n)
+		}(n)
+	}
+	wg.Wait()
+}
+
+func (p *L2Pool) check(ctx context.Context, n *l2Node) {
+	eth, _, ok := n.clients()
+	err := errors.New("not connected")
+	if ok {
+		ctx, cancel := context.WithTimeout(ctx, p.timeout)
+		start := time.Now()
+		_, err = eth.BlockNumber(ctx)
+		cancel()
+		n.observe(time.Since(start), err)
+	}
+
+	n.mu.Lock()
+	n.healthy = err == nil
+	if err != nil {
+		n.failures++
+	} else {
+		n.failures = 0
+	}
+	redial := n.failures >= poolRedialAfter
+	n.mu.Unlock()
+
+	if err == nil {
+		l2NodeHealthy.WithLabelValues(n.name).Set(1)
+		return
+	}
+	l2NodeHealthy.WithLabelValues(n.name).Set(0)
+	logger.Warn().Err(err).Str("node", n.name).Msg("L2 RPC failed health check")
+	if redial {
+		if err := n.dial(); err != nil {
+			logger.Error().Err(err).Str("node", n.name).Msg("redialing L2 RPC")
+		}
+	}
+}
+
+// ranked returns the nodes ordered best first.
+func (p *L2Pool) ranked() []*l2Node {
+	nodes := make([]*l2Node, len(p.nodes))
+	copy(nodes, p.nodes)
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return nodes[i].score() < nodes[j].score()
+	})
+	return nodes
+}
+
+// poolCall runs an idempotent call against the pool, moving on to the next
+// best node whenever one fails.
+func poolCall[T any](ctx context.Context, p *L2Pool, call string, fn func(*ethclient.Client, *gethclient.Client) (T, error)) (T, error) {
+	var (
+		zero T
+		errs []string
+	)
+	for _, n := range p.ranked() {
+		eth, geth, ok := n.clients()
+		if !ok {
+			continue
+		}
+		start := time.Now()
+		res, err := fn(eth, geth)
+		n.observe(time.Since(start), err)
+		if err == nil {
+			return res, nil
+		}
+		if ctx.Err() != nil {
+			return zero, err
+		}
+		observeUpstreamError(upstreamL2, call)
+		errs = append(errs, fmt.Sprintf("%s: %s", n.name, err))
+	}
+	if len(errs) == 0 {
+		return zero, fmt.Errorf("%s: no connected L2 RPC nodes", call)
+	}
+	return zero, fmt.Errorf("%s failed on all L2 RPC nodes: %s", call, strings.Join(errs, "; "))
+}
+
+func (p *L2Pool) BlockNumber(ctx context.Context) (uint64, error) {
+	return poolCall(ctx, p, "eth_blockNumber", func(eth *ethclient.Client, _ *gethclient.Client) (uint64, error) {
+		return eth.BlockNumber(ctx)
+	})
+}
+
+func (p *L2Pool) ChainID(ctx context.Context) (*big.Int, error) {
+	return poolCall(ctx, p, "eth_chainId", func(eth *ethclient.Client, _ *gethclient.Client) (*big.Int, error) {
+		return eth.ChainID(ctx)
+	})
+}
+
+func (p *L2Pool) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return poolCall(ctx, p, "eth_getBlockByNumber", func(eth *ethclient.Client, _ *gethclient.Client) (*types.Header, error) {
+		return eth.HeaderByNumber(ctx, number)
+	})
+}
+
+func (p *L2Pool) GetProof(ctx context.Context, account common.Address, keys []string, blockNumber *big.Int) (*gethclient.AccountResult, error) {
+	if p.quorum > 1 {
+		return p.quorumGetProof(ctx, account, keys, blockNumber)
+	}
+	return poolCall(ctx, p, "eth_getProof", func(_ *ethclient.Client, geth *gethclient.Client) (*gethclient.AccountResult, error) {
+		return geth.GetProof(ctx, account, keys, blockNumber)
+	})
+}
+
+func (p *L2Pool) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	return poolCall(ctx, p, "eth_getStorageAt", func(eth *ethclient.Client, _ *gethclient.Client) ([]byte, error) {
+		return eth.StorageAt(ctx, account, key, blockNumber)
+	})
+}
+
+func (p *L2Pool) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	return poolCall(ctx, p, "eth_getLogs", func(eth *ethclient.Client, _ *gethclient.Client) ([]types.Log, error) {
+		return eth.FilterLogs(ctx, q)
+	})
+}
+
+func (p *L2Pool) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	type result struct {
+		tx      *types.Transaction
+		pending bool
+	}
+	// A node that does not know the transaction answers, so NotFound is not
+	// failed over but returned once the call is done.
+	res, err := poolCall(ctx, p, "eth_getTransactionByHash", func(eth *ethclient.Client, _ *gethclient.Client) (result, error) {
+		tx, pending, err := eth.TransactionByHash(ctx, hash)
+		if errors.Is(err, ethereum.NotFound) {
+			return result{}, nil
+		}
+		return result{tx, pending}, err
+	})
+	if err == nil && res.tx == nil {
+		err = ethereum.NotFound
+	}
+	return res.tx, res.pending, err
+}
+
+func (p *L2Pool) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	receipt, err := poolCall(ctx, p, "eth_getTransactionReceipt", func(eth *ethclient.Client, _ *gethclient.Client) (*types.Receipt, error) {
+		receipt, err := eth.TransactionReceipt(ctx, hash)
+		if errors.Is(err, ethereum.NotFound) {
+			return nil, nil
+		}
+		return receipt, err
+	})
+	if err == nil && receipt == nil {
+		err = ethereum.NotFound
+	}
+	return receipt, err
+}
+
+// SendTransaction submits a signed transaction. Resending it to another node
+// is harmless, so transport failures fail over like reads, but a node's
+// rejection of the transaction is returned as is: the others would reject it
+// too.
+func (p *L2Pool) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	var rejected error
+	_, err := poolCall(ctx, p, "eth_sendRawTransaction", func(eth *ethclient.Client, _ *gethclient.Client) (struct{}, error) {
+		err := eth.SendTransaction(ctx, tx)
+		var rpcErr rpc.Error
+		if errors.As(err, &rpcErr) {
+			rejected = err
+			return struct{}{}, nil
+		}
+		return struct{}{}, err
+	})
+	if rejected != nil {
+		return rejected
+	}
+	return err
+}
+
+func (p *L2Pool) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return poolCall(ctx, p, "eth_getCode", func(eth *ethclient.Client, _ *gethclient.Client) ([]byte, error) {
+		return eth.CodeAt(ctx, account, blockNumber)
+	})
+}
+
+func (p *L2Pool) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return poolCall(ctx, p, "eth_call", func(eth *ethclient.Client, _ *gethclient.Client) ([]byte, error) {
+		return eth.CallContract(ctx, msg, blockNumber)
+	})
+}
+
+func (p *L2Pool) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return poolCall(ctx, p, "eth_gasPrice", func(eth *ethclient.Client, _ *gethclient.Client) (*big.Int, error) {
+		return eth.SuggestGasPrice(ctx)
+	})
+}
+
+func (p *L2Pool) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return poolCall(ctx, p, "eth_maxPriorityFeePerGas", func(eth *ethclient.Client, _ *gethclient.Client) (*big.Int, error) {
+		return eth.SuggestGasTipCap(ctx)
+	})
+}
+
+func (p *L2Pool) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return poolCall(ctx, p, "eth_getTransactionCount", func(eth *ethclient.Client, _ *gethclient.Client) (uint64, error) {
+		return eth.NonceAt(ctx, account, blockNumber)
+	})
+}
+
+func (p *L2Pool) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return poolCall(ctx, p, "eth_getTransactionCount", func(eth *ethclient.Client, _ *gethclient.Client) (uint64, error) {
+		return eth.PendingNonceAt(ctx, account)
+	})
+}
+
+// redactURL drops everything but the scheme and host so API keys in paths or
+// query strings stay out of logs and metric labels.
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return "invalid-url"
+	}
+	return u.Scheme + "://" + u.Host
+}
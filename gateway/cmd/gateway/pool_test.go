@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// fakeEthService answers eth_blockNumber with head, or with a JSON-RPC error
+// when reject is set, and rejects every raw transaction.
+type fakeEthService struct {
+	head   uint64
+	reject bool
+	calls  int32
+}
+
+func (s *fakeEthService) BlockNumber() (hexutil.Uint64, error) {
+	atomic.AddInt32(&s.calls, 1)
+	if s.reject {
+		return 0, errors.New("header not found")
+	}
+	return hexutil.Uint64(s.head), nil
+}
+
+func (s *fakeEthService) SendRawTransaction(data hexutil.Bytes) (common.Hash, error) {
+	atomic.AddInt32(&s.calls, 1)
+	return common.Hash{}, errors.New("nonce too low")
+}
+
+// newFakeNode serves svc over HTTP and returns a pool node for it whose
+// latency is preset so the pool ranks nodes in a known order.
+func newFakeNode(t *testing.T, svc *fakeEthService, latency time.Duration) *l2Node {
+	t.Helper()
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", svc); err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(server)
+	t.Cleanup(ts.Close)
+	n := newL2Node(ts.URL)
+	n.latency = latency
+	return n
+}
+
+func TestL2NodeScore(t *testing.T) {
+	n := &l2Node{healthy: true}
+	n.observe(100*time.Millisecond, nil)
+	if n.latency != 100*time.Millisecond || n.errorRate != 0 {
+		t.Fatalf("first sample: latency %s, error rate %v", n.latency, n.errorRate)
+	}
+	n.observe(200*time.Millisecond, errors.New("boom"))
+	if n.latency != 120*time.Millisecond {
+		t.Errorf("latency %s, want 120ms", n.latency)
+	}
+	if n.errorRate != poolEWMAWeight {
+		t.Errorf("error rate %v, want %v", n.errorRate, poolEWMAWeight)
+	}
+
+	stable := &l2Node{healthy: true, latency: 200 * time.Millisecond}
+	flaky := &l2Node{healthy: true, latency: 100 * time.Millisecond, errorRate: 0.5}
+	if flaky.score() <= stable.score() {
+		t.Errorf("flaky node scores %v, stable node %v", flaky.score(), stable.score())
+	}
+	down := &l2Node{latency: time.Millisecond}
+	if down.score() <= stable.score() {
+		t.Errorf("unhealthy node scores %v, stable node %v", down.score(), stable.score())
+	}
+}
+
+func TestPoolCallFailsOver(t *testing.T) {
+	tests := []struct {
+		name  string
+		first func(t *testing.T) *l2Node
+	}{
+		{
+			name: "rpc error",
+			first: func(t *testing.T) *l2Node {
+				return newFakeNode(t, &fakeEthService{reject: true}, time.Millisecond)
+			},
+		},
+		{
+			name: "transport error",
+			first: func(t *testing.T) *l2Node {
+				ts := httptest.NewServer(rpc.NewServer())
+				ts.Close()
+				n := newL2Node(ts.URL)
+				n.latency = time.Millisecond
+				return n
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first := tt.first(t)
+			second := newFakeNode(t, &fakeEthService{head: 42}, time.Second)
+			p := &L2Pool{nodes: []*l2Node{second, first}, quorum: 1}
+			if ranked := p.ranked(); ranked[0] != first {
+				t.Fatal("faster node not ranked first")
+			}
+
+			head, err := p.BlockNumber(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if head != 42 {
+				t.Errorf("head %d, want 42", head)
+			}
+			if first.errorRate == 0 {
+				t.Error("failed node's error rate not raised")
+			}
+			if second.errorRate != 0 {
+				t.Errorf("answering node's error rate %v", second.errorRate)
+			}
+		})
+	}
+}
+
+func TestPoolCallAllFail(t *testing.T) {
+	p := &L2Pool{nodes: []*l2Node{
+		newFakeNode(t, &fakeEthService{reject: true}, time.Millisecond),
+		newFakeNode(t, &fakeEthService{reject: true}, time.Second),
+	}, quorum: 1}
+	if _, err := p.BlockNumber(context.Background()); err == nil {
+		t.Fatal("expected an error when every node fails")
+	}
+}
+
+func TestPoolSendTransactionRejected(t *testing.T) {
+	first := &fakeEthService{}
+	second := &fakeEthService{}
+	p := &L2Pool{nodes: []*l2Node{
+		newFakeNode(t, first, time.Millisecond),
+		newFakeNode(t, second, time.Second),
+	}, quorum: 1}
+
+	tx := types.NewTransaction(0, common.Address{}, big.NewInt(0), 21000, big.NewInt(1), nil)
+	err := p.SendTransaction(context.Background(), tx)
+	var rpcErr rpc.Error
+	if !errors.As(err, &rpcErr) {
+		t.Fatalf("got %v, want the node's rejection", err)
+	}
+	if n1, n2 := atomic.LoadInt32(&first.calls), atomic.LoadInt32(&second.calls); n1 != 1 || n2 != 0 {
+		t.Errorf("calls: first %d, second %d; a rejection must not be resent", n1, n2)
+	}
+}
+
+func TestNewL2PoolRejectsHealthCheck(t *testing.T) {
+	for _, tt := range []struct{ interval, timeout time.Duration }{
+		{0, time.Second},
+		{-time.Second, time.Second},
+		{time.Second, 0},
+	} {
+		if _, err := NewL2Pool([]string{"http://localhost:8545"}, tt.interval, tt.timeout, 1); err == nil {
+			t.Errorf("NewL2Pool accepted interval %s and timeout %s", tt.interval, tt.timeout)
+		}
+	}
+}
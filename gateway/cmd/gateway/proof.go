@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// Absence names the L1ENSRegistry error a proof of a missing record reverts
+// with. It is empty when the record exists.
+type Absence string
+
+const (
+	AbsenceNone    Absence = ""
+	AbsenceAccount Absence = "AccountDNE"
+	AbsenceStorage Absence = "StorageDNE"
+)
+
+// ProofResult is the outcome of the proof pipeline for one lookup.
+type ProofResult struct {
+	// MethodName is the handled method the calldata selected.
+	MethodName string
+	// Node is the ENS node the lookup is for, nil for operator lookups.
+	Node *common.Hash
+	// Name is Node's name as recovered by the indexer, if it is enabled.
+	Name string
+	// Slot is the L2 registry storage slot that was proven.
+	Slot common.Hash
+	// StateRoot is the committed L2 state root the proof is against.
+	StateRoot common.Hash
+	// BlockNumber and BlockTime identify the L2 block of StateRoot.
+	BlockNumber *big.Int
+	BlockTime   uint64
+	// Historical is set when the request pinned a past state root.
+	Historical bool
+	// Encoded is the ABI-encoded L2StateProof passed to the L1 callback.
+	Encoded []byte
+	// Value is the proven storage value, zero when Absence is set.
+	Value common.Hash
+	// Absence reports whether the proof excludes the account or slot.
+	Absence Absence
+	// Debug holds the intermediate values of a fresh proof. It is dropped
+	// before proofs are cached.
+	Debug *ProofDebug
+}
+
+// ProofDebug is what went into a ProofResult, for the debug endpoint.
+type ProofDebug struct {
+	Handler        *MethodHandler
+	Call           *Call
+	StateRootProof *StateRootProof
+	Account        *gethclient.AccountResult
+}
+
+// Exists reports whether the proof includes the requested record.
+func (p *ProofResult) Exists() bool {
+	return p.Absence == AbsenceNone
+}
+
+// checkProof verifies an eth_getProof result for a single slot against the
+// state root it was generated at. A valid exclusion proof of the account or
+// slot is reported through Absence, any other inconsistency is an error.
+func checkProof(stateRoot common.Hash, res *gethclient.AccountResult, slot []byte) (common.Hash, Absence, error) {
+	accountRLP, err := verifyProof(stateRoot, res.Address.Bytes(), res.AccountProof)
+	if err != nil {
+		return common.Hash{}, AbsenceNone, fmt.Errorf("verifying account proof: %w", err)
+	}
+	if accountRLP == nil {
+		return common.Hash{}, AbsenceAccount, nil
+	}
+	var account types.StateAccount
+	if err := rlp.DecodeBytes(accountRLP, &account); err != nil {
+		return common.Hash{}, AbsenceNone, fmt.Errorf("decoding account: %w", err)
+	}
+	if account.Root != res.StorageHash {
+		return common.Hash{}, AbsenceNone, fmt.Errorf("account storage root %s does not match storage hash %s", account.Root, res.StorageHash)
+	}
+
+	if len(res.StorageProof) != 1 {
+		return common.Hash{}, AbsenceNone, fmt.Errorf("expected 1 storage proof, got %d", len(res.StorageProof))
+	}
+	storage := res.StorageProof[0]
+	if account.Root == types.EmptyRootHash && len(storage.Proof) == 0 {
+		return common.Hash{}, AbsenceStorage, nil
+	}
+	valueRLP, err := verifyProof(account.Root, slot, storage.Proof)
+	if err != nil {
+		return common.Hash{}, AbsenceNone, fmt.Errorf("verifying storage proof: %w", err)
+	}
+	if valueRLP == nil {
+		if storage.Value != nil && storage.Value.Sign() != 0 {
+			return common.Hash{}, AbsenceNone, fmt.Errorf("storage proof excludes slot but reports value %s", storage.Value)
+		}
+		return common.Hash{}, AbsenceStorage, nil
+	}
+	var value []byte
+	if err := rlp.DecodeBytes(valueRLP, &value); err != nil {
+		return common.Hash{}, AbsenceNone, fmt.Errorf("decoding storage value: %w", err)
+	}
+	// Zero values are deleted from the trie, so an inclusion proof of one
+	// cannot come from a well-formed storage trie.
+	if len(bytes.TrimLeft(value, "\x00")) == 0 {
+		return common.Hash{}, AbsenceNone, errors.New("storage proof includes an empty value")
+	}
+	if storage.Value == nil || storage.Value.Cmp(new(big.Int).SetBytes(value)) != 0 {
+		return common.Hash{}, AbsenceNone, fmt.Errorf("storage proof value %x does not match reported value %s", value, storage.Value)
+	}
+	return common.BytesToHash(value), AbsenceNone, nil
+}
+
+// verifyProof looks up the secure trie key for preimage in the trie rooted at
+// root. It returns a nil value for a valid exclusion proof.
+func verifyProof(root common.Hash, preimage []byte, hexNodes []string) ([]byte, error) {
+	nodes, err := decodeProofNodes(hexNodes)
+	if err != nil {
+		return nil, err
+	}
+	db := memorydb.New()
+	for _, node := range nodes {
+		if err := db.Put(crypto.Keccak256(node), node); err != nil {
+			return nil, err
+		}
+	}
+	return trie.VerifyProof(root, crypto.Keccak256(preimage), db)
+}
+
+func decodeProofNodes(hexNodes []string) ([][]byte, error) {
+	nodes := make([][]byte, len(hexNodes))
+	for i, h := range hexNodes {
+		node, err := hexutil.Decode(h)
+		if err != nil {
+			return nil, fmt.Errorf("decoding proof node %d: %w", i, err)
+		}
+		nodes[i] = node
+	}
+	return nodes, nil
+}
+
+// encodeWitness RLP encodes proof nodes as the list of byte strings that
+// Lib_MerkleTrie expects.
+func encodeWitness(hexNodes []string) ([]byte, error) {
+	nodes, err := decodeProofNodes(hexNodes)
+	if err != nil {
+		return nil, err
+	}
+	return rlp.EncodeToBytes(nodes)
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xpaulio/eth-sf-ens-rr/verifier"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+)
+
+var (
+	registryAddr = common.HexToAddress("0xE933897412cc2164331e542B2a2Be491612C233F")
+	ownerSlot    = common.BytesToHash(getoSLOForOwner(common.HexToHash("0x01")))
+	ownerValue   = common.HexToHash("0x000000000000000000000000000000000000000000000000000000000000beef")
+)
+
+// newTestState commits a state holding the registry with a single owner slot
+// set, plus an unrelated account so the state trie has more than one leaf.
+func newTestState(t *testing.T) (common.Hash, *state.StateDB) {
+	t.Helper()
+	db := state.NewDatabase(rawdb.NewMemoryDatabase())
+	statedb, err := state.New(common.Hash{}, db, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	statedb.SetNonce(registryAddr, 1)
+	statedb.SetState(registryAddr, ownerSlot, ownerValue)
+	statedb.SetBalance(common.HexToAddress("0x01"), big.NewInt(1))
+	root, err := statedb.Commit(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	statedb, err = state.New(root, db, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return root, statedb
+}
+
+// getProof builds the eth_getProof response a node would return.
+func getProof(t *testing.T, statedb *state.StateDB, addr common.Address, slot common.Hash) *gethclient.AccountResult {
+	t.Helper()
+	accountProof, err := statedb.GetProof(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Nodes return an empty storage proof for accounts that do not exist.
+	var storageProof [][]byte
+	if statedb.Exist(addr) {
+		storageProof, err = statedb.GetStorageProof(addr, slot)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return &gethclient.AccountResult{
+		Address:      addr,
+		AccountProof: toHex(accountProof),
+		StorageHash:  storageHash(statedb, addr),
+		StorageProof: []gethclient.StorageResult{{
+			Key:   slot.Hex(),
+			Value: statedb.GetState(addr, slot).Big(),
+			Proof: toHex(storageProof),
+		}},
+	}
+}
+
+func storageHash(statedb *state.StateDB, addr common.Address) common.Hash {
+	if tr := statedb.StorageTrie(addr); tr != nil {
+		return tr.Hash()
+	}
+	return types.EmptyRootHash
+}
+
+func toHex(nodes [][]byte) []string {
+	out := make([]string, len(nodes))
+	for i, node := range nodes {
+		out[i] = hexutil.Encode(node)
+	}
+	return out
+}
+
+func TestCheckProofInclusion(t *testing.T) {
+	root, statedb := newTestState(t)
+	res := getProof(t, statedb, registryAddr, ownerSlot)
+	value, absence, err := checkProof(root, res, ownerSlot.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if absence != AbsenceNone {
+		t.Fatalf("absence = %q, want none", absence)
+	}
+	if value != ownerValue {
+		t.Fatalf("value = %s, want %s", value, ownerValue)
+	}
+}
+
+func TestCheckProofStorageDNE(t *testing.T) {
+	root, statedb := newTestState(t)
+	slot := common.BytesToHash(getoSLOForOwner(common.HexToHash("0x02")))
+	res := getProof(t, statedb, registryAddr, slot)
+	value, absence, err := checkProof(root, res, slot.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if absence != AbsenceStorage {
+		t.Fatalf("absence = %q, want %q", absence, AbsenceStorage)
+	}
+	if value != (common.Hash{}) {
+		t.Fatalf("value = %s, want zero", value)
+	}
+}
+
+func TestCheckProofAccountDNE(t *testing.T) {
+	root, statedb := newTestState(t)
+	addr := common.HexToAddress("0x02")
+	res := getProof(t, statedb, addr, ownerSlot)
+	_, absence, err := checkProof(root, res, ownerSlot.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if absence != AbsenceAccount {
+		t.Fatalf("absence = %q, want %q", absence, AbsenceAccount)
+	}
+}
+
+func TestCheckProofRejectsMismatchedValue(t *testing.T) {
+	root, statedb := newTestState(t)
+	res := getProof(t, statedb, registryAddr, ownerSlot)
+	res.StorageProof[0].Value = big.NewInt(1)
+	if _, _, err := checkProof(root, res, ownerSlot.Bytes()); err == nil {
+		t.Fatal("expected error for mismatched storage value")
+	}
+}
+
+func TestCheckProofRejectsExclusionWithValue(t *testing.T) {
+	root, statedb := newTestState(t)
+	slot := common.BytesToHash(getoSLOForOwner(common.HexToHash("0x02")))
+	res := getProof(t, statedb, registryAddr, slot)
+	res.StorageProof[0].Value = big.NewInt(1)
+	if _, _, err := checkProof(root, res, slot.Bytes()); err == nil {
+		t.Fatal("expected error for exclusion proof reporting a value")
+	}
+}
+
+func TestCheckProofRejectsWrongRoot(t *testing.T) {
+	_, statedb := newTestState(t)
+	res := getProof(t, statedb, registryAddr, ownerSlot)
+	if _, _, err := checkProof(common.HexToHash("0x01"), res, ownerSlot.Bytes()); err == nil {
+		t.Fatal("expected error for proof against the wrong state root")
+	}
+}
+
+func TestEncodedProofRoundTrip(t *testing.T) {
+	root, statedb := newTestState(t)
+	res := getProof(t, statedb, registryAddr, ownerSlot)
+	encoded, err := (&Gateway{}).encodeWitnesses(newTestStateRootProof(root), res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, err := verifier.Verify(encoded, registryAddr, ownerSlot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != ownerValue {
+		t.Fatalf("value = %s, want %s", value, ownerValue)
+	}
+}
+
+// newTestStateRootProof puts root second in a batch of two at index 7.
+func newTestStateRootProof(root common.Hash) *StateRootProof {
+	sibling := common.HexToHash("0x02")
+	stateRootProof := &StateRootProof{StateRoot: root.Hex()}
+	stateRootProof.StateRootBatchHeader = StateRootBatchHeader{
+		BatchIndex:        BigNumber{Type: "BigNumber", Hex: "0x07"},
+		BatchRoot:         crypto.Keccak256Hash(sibling[:], root[:]).Hex(),
+		BatchSize:         BigNumber{Type: "BigNumber", Hex: "0x02"},
+		PrevTotalElements: BigNumber{Type: "BigNumber", Hex: "0x10"},
+		ExtraData:         "0x",
+	}
+	stateRootProof.StateRootProof.Index = 1
+	stateRootProof.StateRootProof.Siblings = append(stateRootProof.StateRootProof.Siblings, struct {
+		Type string `json:"type"`
+		Data []byte `json:"data"`
+	}{Type: "Buffer", Data: sibling[:]})
+	return stateRootProof
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// lateProofTimeout bounds how long the responses still outstanding once the
+// quorum outcome is decided are waited for before checking them for
+// disagreement.
+const lateProofTimeout = 10 * time.Second
+
+var proofDisagreementsTotal = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: "gateway",
+	Name:      "proof_disagreements_total",
+	Help:      "eth_getProof requests where L2 RPC nodes returned conflicting results.",
+})
+
+type quorumResponse struct {
+	node string
+	res  *gethclient.AccountResult
+	err  error
+}
+
+// quorumGetProof requests the proof from every connected node at the same
+// block and returns it only when at least p.quorum of them agree on the
+// account fields, storage hash and storage values, and no other group of
+// nodes agrees on a different proof as often.
+func (p *L2Pool) quorumGetProof(ctx context.Context, account common.Address, keys []string, blockNumber *big.Int) (*gethclient.AccountResult, error) {
+	if blockNumber == nil {
+		return nil, fmt.Errorf("eth_getProof quorum requires a pinned block number")
+	}
+	// Requests still in flight once the outcome is decided are left to
+	// finish, so a node that only disagrees late is still reported. They
+	// outlive ctx but not lateProofTimeout.
+	callCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), lateProofTimeout)
+
+	responses := make(chan quorumResponse, len(p.nodes))
+	total := 0
+	for _, n := range p.nodes {
+		_, geth, ok := n.clients()
+		if !ok {
+			continue
+		}
+		total++
+		go func(n *l2Node, geth *gethclient.Client) {
+			res, err := geth.GetProof(callCtx, account, keys, blockNumber)
+			if err != nil && callCtx.Err() == nil {
+				observeUpstreamError(upstreamL2, "eth_getProof")
+			}
+			responses <- quorumResponse{node: n.name, res: res, err: err}
+		}(n, geth)
+	}
+
+	best, groups, errs, received := tallyProofs(ctx, p.quorum, total, responses)
+	var err error
+	if best == nil {
+		largest, second := groupSizes(groups)
+		if largest >= p.quorum && largest == second {
+			err = fmt.Errorf("eth_getProof quorum not reached at block %s: L2 RPC nodes split evenly between proofs (errors: %s)",
+				blockNumber, strings.Join(errs, "; "))
+		} else {
+			err = fmt.Errorf("eth_getProof quorum not reached at block %s: %d of %d required nodes agree (errors: %s)",
+				blockNumber, largest, p.quorum, strings.Join(errs, "; "))
+		}
+	}
+	go func() {
+		defer cancel()
+		settleProofs(ctx, account, keys, blockNumber, groups, total-received, responses)
+	}()
+	if err != nil {
+		return nil, err
+	}
+	return best[0].res, nil
+}
+
+// settleProofs waits for the pending responses and reports whether the
+// responding nodes disagree on the proof, logging and counting it if so.
+func settleProofs(ctx context.Context, account common.Address, keys []string, blockNumber *big.Int, groups map[string][]quorumResponse, pending int, responses <-chan quorumResponse) bool {
+	for ; pending > 0; pending-- {
+		if resp := <-responses; resp.err == nil {
+			key := proofFingerprint(resp.res)
+			groups[key] = append(groups[key], resp)
+		}
+	}
+	if len(groups) < 2 {
+		return false
+	}
+	proofDisagreementsTotal.Inc()
+	logProofDisagreement(ctx, account, keys, blockNumber, groups)
+	return true
+}
+
+// tallyProofs reads up to total responses and groups them by proof. It
+// returns the group that reached quorum as soon as no other group can still
+// match its size, or nil once every response is in or ctx is done and none
+// has, along with the number of responses read.
+func tallyProofs(ctx context.Context, quorum, total int, responses <-chan quorumResponse) ([]quorumResponse, map[string][]quorumResponse, []string, int) {
+	groups := make(map[string][]quorumResponse)
+	var errs []string
+	for received := 1; received <= total; received++ {
+		var resp quorumResponse
+		select {
+		case resp = <-responses:
+		case <-ctx.Done():
+			return nil, groups, append(errs, ctx.Err().Error()), received - 1
+		}
+		if resp.err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", resp.node, resp.err))
+		} else {
+			key := proofFingerprint(resp.res)
+			groups[key] = append(groups[key], resp)
+		}
+		if best := decidedGroup(groups, quorum, total-received); best != nil {
+			return best, groups, errs, received
+		}
+	}
+	return nil, groups, errs, total
+}
+
+// decidedGroup returns the largest group if it meets quorum and stays the
+// only largest group whatever the pending responses turn out to be.
+func decidedGroup(groups map[string][]quorumResponse, quorum, pending int) []quorumResponse {
+	var best []quorumResponse
+	for _, group := range groups {
+		if len(group) > len(best) {
+			best = group
+		}
+	}
+	largest, second := groupSizes(groups)
+	if largest < quorum || largest <= second+pending {
+		return nil
+	}
+	return best
+}
+
+// groupSizes returns the sizes of the two largest groups.
+func groupSizes(groups map[string][]quorumResponse) (largest, second int) {
+	for _, group := range groups {
+		switch n := len(group); {
+		case n > largest:
+			largest, second = n, largest
+		case n > second:
+			second = n
+		}
+	}
+	return largest, second
+}
+
+// proofFingerprint identifies the values a proof attests to. Proof nodes are
+// left out since they are checked against the state root separately.
+func proofFingerprint(res *gethclient.AccountResult) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s|%d|%s|%s|%s", res.Address, res.Nonce, res.Balance, res.CodeHash, res.StorageHash)
+	for _, s := range res.StorageProof {
+		fmt.Fprintf(&b, "|%s=%s", common.HexToHash(s.Key), s.Value)
+	}
+	return b.String()
+}
+
+func logProofDisagreement(ctx context.Context, account common.Address, keys []string, blockNumber *big.Int, groups map[string][]quorumResponse) {
+	var views []string
+	for key, group := range groups {
+		nodes := make([]string, len(group))
+		for i, resp := range group {
+			nodes[i] = resp.node
+		}
+		sort.Strings(nodes)
+		views = append(views, fmt.Sprintf("[%s] => %s", strings.Join(nodes, ", "), key))
+	}
+	sort.Strings(views)
+	requestLogger(ctx).Warn().
+		Str("event", "security").
+		Str("account", account.Hex()).
+		Strs("keys", keys).
+		Str("block", blockNumber.String()).
+		Strs("views", views).
+		Msg("L2 RPC nodes disagree on eth_getProof")
+}
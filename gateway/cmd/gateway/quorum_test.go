@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+)
+
+func TestTallyProofs(t *testing.T) {
+	// proof n and proof m differ in their nonce, so they never agree.
+	proof := func(nonce uint64) quorumResponse {
+		return quorumResponse{node: fmt.Sprint("node", nonce), res: &gethclient.AccountResult{Nonce: nonce}}
+	}
+	failed := quorumResponse{node: "down", err: errors.New("timeout")}
+
+	tests := []struct {
+		name   string
+		quorum int
+		// total is the number of nodes asked. Only the responses listed are
+		// ever sent, so a tally that waits for more would block.
+		total     int
+		responses []quorumResponse
+		want      *uint64
+	}{
+		{name: "agree", quorum: 2, total: 3, responses: []quorumResponse{proof(1), proof(1), proof(1)}, want: newUint64(1)},
+		{name: "majority over dissent", quorum: 2, total: 3, responses: []quorumResponse{proof(1), proof(2), proof(1)}, want: newUint64(1)},
+		{name: "returns once decided", quorum: 2, total: 3, responses: []quorumResponse{proof(1), proof(1)}, want: newUint64(1)},
+		{name: "tie", quorum: 2, total: 4, responses: []quorumResponse{proof(1), proof(2), proof(2), proof(1)}},
+		{name: "tie with quorum of one", quorum: 1, total: 2, responses: []quorumResponse{proof(1), proof(2)}},
+		{name: "short of quorum", quorum: 3, total: 3, responses: []quorumResponse{proof(1), failed, proof(1)}},
+		{name: "all failed", quorum: 2, total: 2, responses: []quorumResponse{failed, failed}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responses := make(chan quorumResponse, len(tt.responses))
+			for _, resp := range tt.responses {
+				responses <- resp
+			}
+			best, _, _, _ := tallyProofs(context.Background(), tt.quorum, tt.total, responses)
+			switch {
+			case tt.want == nil && best != nil:
+				t.Fatalf("got proof with nonce %d, want no quorum", best[0].res.Nonce)
+			case tt.want != nil && best == nil:
+				t.Fatalf("no quorum, want proof with nonce %d", *tt.want)
+			case tt.want != nil && best[0].res.Nonce != *tt.want:
+				t.Fatalf("got proof with nonce %d, want %d", best[0].res.Nonce, *tt.want)
+			}
+		})
+	}
+}
+
+func TestTallyProofsCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	responses := make(chan quorumResponse, 1)
+	responses <- quorumResponse{node: "node1", res: &gethclient.AccountResult{Nonce: 1}}
+	best, _, errs, received := tallyProofs(ctx, 1, 3, responses)
+	if best != nil || len(errs) == 0 {
+		t.Fatalf("got %v (errors %v), want no quorum once ctx is done", best, errs)
+	}
+	if received > 1 {
+		t.Fatalf("got %d responses received, want at most the one sent", received)
+	}
+}
+
+func TestSettleProofs(t *testing.T) {
+	proof := func(node string, nonce uint64) quorumResponse {
+		return quorumResponse{node: node, res: &gethclient.AccountResult{Nonce: nonce}}
+	}
+	tests := []struct {
+		name string
+		late []quorumResponse
+		want bool
+	}{
+		{name: "late agreement", late: []quorumResponse{proof("c", 1)}},
+		{name: "late failure", late: []quorumResponse{{node: "c", err: errors.New("timeout")}}},
+		{name: "late disagreement", late: []quorumResponse{proof("c", 1), proof("d", 2)}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// a and b decided the outcome before the late responses came in.
+			responses := make(chan quorumResponse, len(tt.late)+2)
+			responses <- proof("a", 1)
+			responses <- proof("b", 1)
+			for _, resp := range tt.late {
+				responses <- resp
+			}
+			best, groups, _, received := tallyProofs(context.Background(), 2, len(tt.late)+2, responses)
+			if best == nil {
+				t.Fatal("no quorum, want a and b to decide it")
+			}
+			got := settleProofs(context.Background(), common.Address{}, nil, big.NewInt(1), groups, len(tt.late)+2-received, responses)
+			if got != tt.want {
+				t.Fatalf("got disagreement %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func newUint64(n uint64) *uint64 { return &n }
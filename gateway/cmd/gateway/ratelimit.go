@@ -0,0 +1,308 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// rateLimitKeys bounds the number of buckets kept per scope. The least
+	// recently used bucket is dropped first, which only ever resets a client
+	// to a full bucket.
+	rateLimitKeys = 100_000
+	// maxGatewayBody bounds the POST body read to find the sender.
+	maxGatewayBody = 1 << 20
+)
+
+var rateLimitedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "gateway",
+	Name:      "rate_limited_total",
+	Help:      "Requests rejected by the rate limiter by scope (ip, sender or api_key).",
+}, []string{"scope"})
+
+// RateTier is a token bucket refilled at Rate tokens per second holding at
+// most Burst tokens.
+type RateTier struct {
+	Rate  rate.Limit
+	Burst int
+}
+
+func (t RateTier) enabled() bool {
+	return t.Rate > 0
+}
+
+// RateLimitConfig configures gateway rate limiting. Anonymous requests are
+// limited per client IP, and per EIP-3668 sender from each client IP.
+// Requests carrying a known X-API-Key header are only limited by the quota of
+// their key's tier.
+type RateLimitConfig struct {
+	IP RateTier
+	// Sender limits each client IP's requests for one sender. The sender is
+	// the resolver contract every client shares, so it is never limited on
+	// its own.
+	Sender RateTier
+	// APIKeys maps an API key to its tier.
+	APIKeys map[string]RateTier
+	// ProxyHops is the number of trusted reverse proxies in front of the
+	// gateway. When positive, the client IP is the X-Forwarded-For entry
+	// appended by the outermost of them; entries further left are set by the
+	// client and ignored.
+	ProxyHops int
+}
+
+// rateLimitConfigFromEnv reads:
+//
+//	RATE_LIMIT_IP=5:10                      per IP rate:burst, 0 disables
+//	RATE_LIMIT_SENDER=20:40                 per IP and sender rate:burst, 0 disables
+//	RATE_LIMIT_TIERS=partner=100:200,...    named tiers for API keys
+//	RATE_LIMIT_API_KEYS=<key>=partner,...   API key to tier
+//	RATE_LIMIT_PROXY_HOPS=0                 trusted reverse proxies in front
+//	RATE_LIMIT_TRUST_PROXY=false            shorthand for one proxy hop
+func rateLimitConfigFromEnv() (RateLimitConfig, error) {
+	var (
+		config RateLimitConfig
+		err    error
+	)
+	if config.IP, err = parseRateTier(GetOrDefault("RATE_LIMIT_IP", "5:10")); err != nil {
+		return config, fmt.Errorf("parsing RATE_LIMIT_IP: %w", err)
+	}
+	if config.Sender, err = parseRateTier(GetOrDefault("RATE_LIMIT_SENDER", "20:40")); err != nil {
+		return config, fmt.Errorf("parsing RATE_LIMIT_SENDER: %w", err)
+	}
+	tiers := make(map[string]RateTier)
+	for name, spec := range parseKeyValues(GetOrDefault("RATE_LIMIT_TIERS", "")) {
+		if tiers[name], err = parseEnabledRateTier(spec); err != nil {
+			return config, fmt.Errorf("parsing RATE_LIMIT_TIERS tier %s: %w", name, err)
+		}
+	}
+	config.APIKeys = make(map[string]RateTier)
+	for key, name := range parseKeyValues(GetOrDefault("RATE_LIMIT_API_KEYS", "")) {
+		tier, ok := tiers[name]
+		if !ok {
+			return config, fmt.Errorf("parsing RATE_LIMIT_API_KEYS: unknown tier %q", name)
+		}
+		config.APIKeys[key] = tier
+	}
+	trustProxy, err := strconv.ParseBool(GetOrDefault("RATE_LIMIT_TRUST_PROXY", "false"))
+	if err != nil {
+		return config, fmt.Errorf("parsing RATE_LIMIT_TRUST_PROXY: %w", err)
+	}
+	hops := "0"
+	if trustProxy {
+		hops = "1"
+	}
+	if config.ProxyHops, err = strconv.Atoi(GetOrDefault("RATE_LIMIT_PROXY_HOPS", hops)); err != nil || config.ProxyHops < 0 {
+		return config, fmt.Errorf("parsing RATE_LIMIT_PROXY_HOPS: invalid count %q", GetOrDefault("RATE_LIMIT_PROXY_HOPS", hops))
+	}
+	return config, nil
+}
+
+// parseRateTier parses "rate:burst". "0" disables the tier.
+func parseRateTier(s string) (RateTier, error) {
+	if s == "0" || s == "" {
+		return RateTier{}, nil
+	}
+	return parseEnabledRateTier(s)
+}
+
+// parseEnabledRateTier parses "rate:burst" with a positive rate, for API key
+// tiers, which can't be disabled.
+func parseEnabledRateTier(s string) (RateTier, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 2 {
+		return RateTier{}, fmt.Errorf("invalid tier %q, want rate:burst", s)
+	}
+	r, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil || r <= 0 {
+		return RateTier{}, fmt.Errorf("invalid rate %q", parts[0])
+	}
+	burst, err := strconv.Atoi(parts[1])
+	if err != nil || burst < 1 {
+		return RateTier{}, fmt.Errorf("invalid burst %q", parts[1])
+	}
+	return RateTier{Rate: rate.Limit(r), Burst: burst}, nil
+}
+
+// parseKeyValues parses "a=1,b=2".
+func parseKeyValues(s string) map[string]string {
+	kv := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if ok {
+			kv[key] = value
+		}
+	}
+	return kv
+}
+
+// RateLimiter holds the token buckets for every scope.
+type RateLimiter struct {
+	config  RateLimitConfig
+	ips     *lru.Cache
+	senders *lru.Cache
+	apiKeys *lru.Cache
+}
+
+func NewRateLimiter(config RateLimitConfig) (*RateLimiter, error) {
+	for _, tier := range config.APIKeys {
+		if !tier.enabled() || tier.Burst < 1 {
+			// A zero tier would reject every request with the key. The key
+			// itself is a secret, so it is left out.
+			return nil, fmt.Errorf("an API key has tier %v:%d, want a positive rate and burst", tier.Rate, tier.Burst)
+		}
+	}
+	l := &RateLimiter{config: config}
+	var err error
+	for _, cache := range []**lru.Cache{&l.ips, &l.senders, &l.apiKeys} {
+		if *cache, err = lru.New(rateLimitKeys); err != nil {
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+func (l *RateLimiter) limiter(cache *lru.Cache, key string, tier RateTier) *rate.Limiter {
+	if lim, ok := cache.Get(key); ok {
+		return lim.(*rate.Limiter)
+	}
+	lim := rate.NewLimiter(tier.Rate, tier.Burst)
+	cache.Add(key, lim)
+	return lim
+}
+
+type rateLimitBucket struct {
+	scope   string
+	limiter *rate.Limiter
+}
+
+// Middleware limits the gateway routes. It must be mounted after routing so
+// the {sender} URL parameter is available.
+func (l *RateLimiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var buckets []rateLimitBucket
+		if apiKey := r.Header.Get("X-API-Key"); apiKey != "" {
+			tier, ok := l.config.APIKeys[apiKey]
+			if !ok {
+				render.Render(w, r, ErrUnauthorized(fmt.Errorf("unknown API key")))
+				return
+			}
+			buckets = append(buckets, rateLimitBucket{"api_key", l.limiter(l.apiKeys, apiKey, tier)})
+		} else {
+			ip := clientIP(r, l.config.ProxyHops)
+			if l.config.IP.enabled() {
+				buckets = append(buckets, rateLimitBucket{"ip", l.limiter(l.ips, ip, l.config.IP)})
+			}
+			if l.config.Sender.enabled() {
+				sender, err := requestSender(r)
+				if err != nil {
+					render.Render(w, r, ErrInvalidRequest(err))
+					return
+				}
+				if sender != "" {
+					buckets = append(buckets, rateLimitBucket{"sender", l.limiter(l.senders, ip+"|"+sender, l.config.Sender)})
+				}
+			}
+		}
+
+		if delay, scope, ok := reserve(buckets); !ok {
+			rateLimitedTotal.WithLabelValues(scope).Inc()
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+			render.Render(w, r, ErrTooManyRequests(fmt.Errorf("%s rate limit exceeded", scope)))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// reserve takes a token from every bucket, or from none of them. When a
+// bucket is empty it returns the longest wait and the scope that caused it.
+func reserve(buckets []rateLimitBucket) (time.Duration, string, bool) {
+	var (
+		reservations = make([]*rate.Reservation, 0, len(buckets))
+		maxDelay     time.Duration
+		scope        string
+	)
+	now := time.Now()
+	for _, b := range buckets {
+		res := b.limiter.ReserveN(now, 1)
+		reservations = append(reservations, res)
+		delay := res.DelayFrom(now)
+		if !res.OK() {
+			delay = time.Second
+		}
+		if delay > maxDelay {
+			maxDelay, scope = delay, b.scope
+		}
+	}
+	if maxDelay == 0 {
+		return 0, "", true
+	}
+	for _, res := range reservations {
+		res.CancelAt(now)
+	}
+	return maxDelay, scope, false
+}
+
+// requestSender returns the EIP-3668 sender from the URL or, for POST
+// requests, from the JSON body, which is restored for the handler.
+func requestSender(r *http.Request) (string, error) {
+	if sender := chi.URLParam(r, "sender"); sender != "" {
+		return strings.ToLower(sender), nil
+	}
+	if r.Method != http.MethodPost || r.Body == nil {
+		return "", nil
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxGatewayBody))
+	if err != nil {
+		return "", fmt.Errorf("reading body: %w", err)
+	}
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	var req GatewayRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		// Leave it to the handler to report the malformed body.
+		return "", nil
+	}
+	return strings.ToLower(req.Sender), nil
+}
+
+// clientIP returns the address of the client behind proxyHops trusted
+// proxies. Each proxy appends the address it was connected from to
+// X-Forwarded-For, so the client is the entry proxyHops from the right.
+// Requests that did not pass through every proxy are keyed on the peer.
+func clientIP(r *http.Request, proxyHops int) string {
+	if proxyHops > 0 {
+		var forwarded []string
+		for _, header := range r.Header.Values("X-Forwarded-For") {
+			for _, ip := range strings.Split(header, ",") {
+				forwarded = append(forwarded, strings.TrimSpace(ip))
+			}
+		}
+		if len(forwarded) >= proxyHops {
+			return forwarded[len(forwarded)-proxyHops]
+		}
+		if ip := r.Header.Get("X-Real-IP"); ip != "" && proxyHops == 1 && len(forwarded) == 0 {
+			return ip
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
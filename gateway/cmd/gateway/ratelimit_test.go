@@ -0,0 +1,138 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	const sender = "0x1111111111111111111111111111111111111111"
+	type request struct {
+		ip     string
+		sender string
+		apiKey string
+		// want is the expected status, and retryAfter the expected
+		// Retry-After header of a 429.
+		want       int
+		retryAfter string
+	}
+	tests := []struct {
+		name     string
+		config   RateLimitConfig
+		requests []request
+	}{
+		{
+			name:   "ip",
+			config: RateLimitConfig{IP: RateTier{Rate: 0.5, Burst: 2}},
+			requests: []request{
+				{ip: "10.0.0.1", sender: sender, want: http.StatusOK},
+				{ip: "10.0.0.1", sender: sender, want: http.StatusOK},
+				{ip: "10.0.0.1", sender: sender, want: http.StatusTooManyRequests, retryAfter: "2"},
+				{ip: "10.0.0.2", sender: sender, want: http.StatusOK},
+			},
+		},
+		{
+			name:   "sender per ip",
+			config: RateLimitConfig{Sender: RateTier{Rate: 0.25, Burst: 1}},
+			requests: []request{
+				{ip: "10.0.0.1", sender: sender, want: http.StatusOK},
+				{ip: "10.0.0.1", sender: sender, want: http.StatusTooManyRequests, retryAfter: "4"},
+				// Another client of the same resolver has its own bucket.
+				{ip: "10.0.0.2", sender: sender, want: http.StatusOK},
+				{ip: "10.0.0.1", sender: "0x2222222222222222222222222222222222222222", want: http.StatusOK},
+			},
+		},
+		{
+			name: "api key",
+			config: RateLimitConfig{
+				IP:      RateTier{Rate: 1, Burst: 1},
+				APIKeys: map[string]RateTier{"partner": {Rate: 1, Burst: 3}},
+			},
+			requests: []request{
+				{ip: "10.0.0.1", sender: sender, apiKey: "partner", want: http.StatusOK},
+				{ip: "10.0.0.1", sender: sender, apiKey: "partner", want: http.StatusOK},
+				{ip: "10.0.0.1", sender: sender, apiKey: "partner", want: http.StatusOK},
+				{ip: "10.0.0.1", sender: sender, apiKey: "partner", want: http.StatusTooManyRequests, retryAfter: "1"},
+				{ip: "10.0.0.1", sender: sender, apiKey: "unknown", want: http.StatusUnauthorized},
+				{ip: "10.0.0.1", sender: sender, want: http.StatusOK},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, err := NewRateLimiter(tt.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := chi.NewRouter()
+			r.With(l.Middleware).Get("/gateway/{sender}/{data}.json", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+			for i, req := range tt.requests {
+				httpReq := httptest.NewRequest(http.MethodGet, "/gateway/"+req.sender+"/0x.json", nil)
+				httpReq.RemoteAddr = req.ip + ":1234"
+				if req.apiKey != "" {
+					httpReq.Header.Set("X-API-Key", req.apiKey)
+				}
+				rec := httptest.NewRecorder()
+				r.ServeHTTP(rec, httpReq)
+				if rec.Code != req.want {
+					t.Fatalf("request %d: got status %d, want %d", i, rec.Code, req.want)
+				}
+				if got := rec.Header().Get("Retry-After"); got != req.retryAfter {
+					t.Errorf("request %d: got Retry-After %q, want %q", i, got, req.retryAfter)
+				}
+			}
+		})
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	tests := []struct {
+		name      string
+		forwarded []string
+		realIP    string
+		hops      int
+		want      string
+	}{
+		{"no proxy ignores headers", []string{"203.0.113.7"}, "203.0.113.8", 0, "10.0.0.1"},
+		{"one proxy takes the entry it appended", []string{"198.51.100.1, 203.0.113.7"}, "", 1, "203.0.113.7"},
+		{"spoofed entries are ignored", []string{"1.2.3.4, 5.6.7.8, 203.0.113.7"}, "", 1, "203.0.113.7"},
+		{"two proxies", []string{"1.2.3.4, 203.0.113.7", "192.0.2.1"}, "", 2, "203.0.113.7"},
+		{"fewer entries than proxies", []string{"203.0.113.7"}, "", 2, "10.0.0.1"},
+		{"real ip from one proxy", nil, "203.0.113.9", 1, "203.0.113.9"},
+		{"no headers", nil, "", 1, "10.0.0.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = "10.0.0.1:1234"
+			for _, fwd := range tt.forwarded {
+				r.Header.Add("X-Forwarded-For", fwd)
+			}
+			if tt.realIP != "" {
+				r.Header.Set("X-Real-IP", tt.realIP)
+			}
+			if ip := clientIP(r, tt.hops); ip != tt.want {
+				t.Errorf("got %s, want %s", ip, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimitTiers(t *testing.T) {
+	for _, spec := range []string{"0:10", "-1:10", "1:0", "1", "x:1"} {
+		if tier, err := parseEnabledRateTier(spec); err == nil {
+			t.Errorf("parseEnabledRateTier(%q) = %+v, want an error", spec, tier)
+		}
+	}
+	if tier, err := parseRateTier("0"); err != nil || tier.enabled() {
+		t.Errorf("parseRateTier(0) = %+v, %v; want a disabled tier", tier, err)
+	}
+	if _, err := NewRateLimiter(RateLimitConfig{APIKeys: map[string]RateTier{"partner": {}}}); err == nil {
+		t.Error("NewRateLimiter accepted an API key with a zero tier")
+	}
+}
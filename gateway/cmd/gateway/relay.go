@@ -0,0 +1,436 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/0xpaulio/eth-sf-ens-rr/bindings"
+	"github.com/ethereum/go-ethereum"
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	// relayReceiptPollInterval is the time between receipt checks of a
+	// relayed transaction.
+	relayReceiptPollInterval = 2 * time.Second
+	// relayTrackedTxs bounds the relayed transactions remembered for status
+	// lookups.
+	relayTrackedTxs = 4096
+)
+
+// Relay statuses.
+const (
+	RelayPending  = "pending"
+	RelaySuccess  = "success"
+	RelayReverted = "reverted"
+	RelayTimeout  = "timeout"
+	RelayUnknown  = "unknown"
+)
+
+var relayTransactionsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "gateway",
+	Name:      "relay_transactions_total",
+	Help:      "Relayed L2 registry writes by setter and outcome.",
+}, []string{"method", "status"})
+
+// relaySetters unpack the L1 registry setters that revert with
+// StorageHandledByL2, checking their encoding.
+var relaySetters = map[string]func([]byte) (CallArgs, error){
+	"setOwner":          unpackArgs(bindings.UnpackL1ENSRegistrySetOwnerArgs),
+	"setRecord":         unpackArgs(bindings.UnpackL1ENSRegistrySetRecordArgs),
+	"setSubnodeOwner":   unpackArgs(bindings.UnpackL1ENSRegistrySetSubnodeOwnerArgs),
+	"setSubnodeRecord":  unpackArgs(bindings.UnpackL1ENSRegistrySetSubnodeRecordArgs),
+	"setResolver":       unpackArgs(bindings.UnpackL1ENSRegistrySetResolverArgs),
+	"setTTL":            unpackArgs(bindings.UnpackL1ENSRegistrySetTTLArgs),
+	"setApprovalForAll": unpackArgs(bindings.UnpackL1ENSRegistrySetApprovalForAllArgs),
+}
+
+func unpackArgs[T CallArgs](unpack func([]byte) (T, error)) func([]byte) (CallArgs, error) {
+	return func(data []byte) (CallArgs, error) {
+		args, err := unpack(data)
+		return args, err
+	}
+}
+
+// RelayConfig configures the write relay.
+type RelayConfig struct {
+	Enabled bool
+	// ReceiptTimeout bounds how long a relayed transaction's receipt is
+	// waited for.
+	ReceiptTimeout time.Duration
+	// MaxGas is the highest gas limit a relayed transaction may have.
+	MaxGas uint64
+}
+
+func relayConfigFromEnv() (RelayConfig, error) {
+	var (
+		config RelayConfig
+		err    error
+	)
+	if config.Enabled, err = strconv.ParseBool(GetOrDefault("RELAY_ENABLED", "false")); err != nil {
+		return config, fmt.Errorf("parsing RELAY_ENABLED: %w", err)
+	}
+	if config.ReceiptTimeout, err = time.ParseDuration(GetOrDefault("RELAY_RECEIPT_TIMEOUT", "10m")); err != nil {
+		return config, fmt.Errorf("parsing RELAY_RECEIPT_TIMEOUT: %w", err)
+	}
+	if config.MaxGas, err = strconv.ParseUint(GetOrDefault("RELAY_MAX_GAS", "500000"), 10, 64); err != nil {
+		return config, fmt.Errorf("parsing RELAY_MAX_GAS: %w", err)
+	}
+	return config, nil
+}
+
+// RelayClient is the subset of the L2 RPC the relay uses.
+type RelayClient interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+	TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error)
+	TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error)
+}
+
+// Relay submits user-signed L2 registry writes for L1 setter calls that the
+// L1 registry defers to L2 with StorageHandledByL2.
+type Relay struct {
+	ctx      context.Context
+	client   RelayClient
+	registry common.Address
+	l2ABI    *ethabi.ABI
+	config   RelayConfig
+	// tracked holds the *relayedTx of recently relayed transactions.
+	tracked *lru.Cache
+}
+
+// NewRelay creates a relay submitting to the registry at registry. Receipts
+// are watched until ctx is done.
+func NewRelay(ctx context.Context, client RelayClient, registry common.Address, config RelayConfig) (*Relay, error) {
+	tracked, err := lru.New(relayTrackedTxs)
+	if err != nil {
+		return nil, err
+	}
+	l2ABI, err := bindings.L2ENSRegistryMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return &Relay{
+		ctx:      ctx,
+		client:   client,
+		registry: registry,
+		l2ABI:    l2ABI,
+		config:   config,
+		tracked:  tracked,
+	}, nil
+}
+
+// relayedTx is a relayed transaction and its last known status.
+type relayedTx struct {
+	mu          sync.Mutex
+	method      string
+	sender      common.Address
+	submittedAt time.Time
+	status      string
+}
+
+// RelayRequest is the POST /v1/relay body.
+type RelayRequest struct {
+	// Calldata is the L1 registry setter call that reverted with
+	// StorageHandledByL2.
+	Calldata string `json:"calldata"`
+	// SignedTx is the raw signed L2 transaction performing the same call on
+	// the L2 registry.
+	SignedTx string `json:"signedTx"`
+	// RevertData is the StorageHandledByL2 revert of the L1 call, if the
+	// client has it. It must name the L2 chain and registry the relay
+	// submits to.
+	RevertData string `json:"revertData,omitempty"`
+}
+
+func (req *RelayRequest) Bind(r *http.Request) error {
+	if req.Calldata == "" || req.SignedTx == "" {
+		return errors.New("calldata and signedTx are required")
+	}
+	return nil
+}
+
+// RelayStatus reports a relayed transaction.
+type RelayStatus struct {
+	TxHash      common.Hash     `json:"txHash"`
+	Method      string          `json:"method,omitempty"`
+	Sender      *common.Address `json:"sender,omitempty"`
+	Status      string          `json:"status"`
+	BlockNumber *big.Int        `json:"blockNumber,omitempty"`
+	BlockHash   *common.Hash    `json:"blockHash,omitempty"`
+	GasUsed     *hexutil.Uint64 `json:"gasUsed,omitempty"`
+}
+
+func (RelayStatus) Render(w http.ResponseWriter, r *http.Request) error {
+	w.Header().Set("Cache-Control", "no-store")
+	return nil
+}
+
+// checkedRelay is a signed L2 transaction matched to its L1 setter call.
+type checkedRelay struct {
+	method string
+	tx     *types.Transaction
+	sender common.Address
+}
+
+// check verifies that signedTx performs the L1 setter call on the L2
+// registry of the gateway's L2: same function, same arguments, signed for
+// the L2's chain and sending no value.
+func (rl *Relay) check(ctx context.Context, calldataHex, signedTxHex string) (*checkedRelay, error) {
+	calldata, err := DecodeHex(calldataHex)
+	if err != nil {
+		return nil, badRequest(fmt.Errorf("decoding calldata: %w", err))
+	}
+	if len(calldata) < 4 {
+		return nil, badRequest(fmt.Errorf("calldata too short: %d bytes", len(calldata)))
+	}
+	method, err := abi.MethodById(calldata[:4])
+	if err != nil {
+		return nil, badRequest(fmt.Errorf("unknown function signature: 0x%x", calldata[:4]))
+	}
+	unpack, ok := relaySetters[method.Name]
+	if !ok {
+		return nil, badRequest(fmt.Errorf("%s is not a registry write deferred to L2", method.Name))
+	}
+	args, err := unpack(calldata[4:])
+	if err != nil {
+		return nil, badRequest(err)
+	}
+
+	raw, err := DecodeHex(signedTxHex)
+	if err != nil {
+		return nil, badRequest(fmt.Errorf("decoding signedTx: %w", err))
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(raw); err != nil {
+		return nil, badRequest(fmt.Errorf("decoding signedTx: %w", err))
+	}
+	chainID, err := rl.client.ChainID(ctx)
+	if err != nil {
+		return nil, badGateway(fmt.Errorf("getting L2 chain ID: %w", err))
+	}
+	if !tx.Protected() || tx.ChainId().Cmp(chainID) != 0 {
+		return nil, badRequest(fmt.Errorf("transaction is not signed for L2 chain %s", chainID))
+	}
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), tx)
+	if err != nil {
+		return nil, badRequest(fmt.Errorf("recovering transaction sender: %w", err))
+	}
+	if tx.To() == nil || *tx.To() != rl.registry {
+		return nil, badRequest(fmt.Errorf("transaction must be sent to the L2 registry %s", rl.registry))
+	}
+	if tx.Value().Sign() != 0 {
+		return nil, badRequest(errors.New("transaction must not send value"))
+	}
+	if tx.Gas() > rl.config.MaxGas {
+		return nil, badRequest(fmt.Errorf("transaction gas limit %d exceeds %d", tx.Gas(), rl.config.MaxGas))
+	}
+	expected, err := rl.l2ABI.Pack(method.Name, args.Values()...)
+	if err != nil {
+		return nil, fmt.Errorf("packing L2 %s calldata: %w", method.Name, err)
+	}
+	if !bytes.Equal(tx.Data(), expected) {
+		return nil, badRequest(fmt.Errorf("transaction does not call %s with the L1 call's arguments", method.Name))
+	}
+	return &checkedRelay{method: method.Name, tx: tx, sender: sender}, nil
+}
+
+// checkDeferral verifies that the L1 registry's StorageHandledByL2 revert
+// defers to the L2 chain and registry this relay submits to.
+func (rl *Relay) checkDeferral(ctx context.Context, revertDataHex string) error {
+	revertData, err := DecodeHex(revertDataHex)
+	if err != nil {
+		return badRequest(fmt.Errorf("decoding revertData: %w", err))
+	}
+	deferral, err := bindings.UnpackL1ENSRegistryStorageHandledByL2(revertData)
+	if err != nil {
+		return badRequest(err)
+	}
+	chainID, err := rl.client.ChainID(ctx)
+	if err != nil {
+		return badGateway(fmt.Errorf("getting L2 chain ID: %w", err))
+	}
+	if deferral.ChainId.Cmp(chainID) != 0 || deferral.ContractAddress != rl.registry {
+		return badRequest(fmt.Errorf("L1 registry defers to %s on chain %s, this relay submits to %s on chain %s",
+			deferral.ContractAddress, deferral.ChainId, rl.registry, chainID))
+	}
+	return nil
+}
+
+// submit sends the checked transaction and starts watching for its receipt.
+func (rl *Relay) submit(ctx context.Context, c *checkedRelay) error {
+	if err := rl.client.SendTransaction(ctx, c.tx); err != nil {
+		var rpcErr rpc.Error
+		switch {
+		case errors.As(err, &rpcErr) && strings.Contains(strings.ToLower(err.Error()), "already known"):
+		case errors.As(err, &rpcErr):
+			return badRequest(fmt.Errorf("L2 rejected the transaction: %w", err))
+		default:
+			return badGateway(fmt.Errorf("submitting transaction: %w", err))
+		}
+	}
+	relayed := &relayedTx{
+		method:      c.method,
+		sender:      c.sender,
+		submittedAt: time.Now(),
+		status:      RelayPending,
+	}
+	if ok, _ := rl.tracked.ContainsOrAdd(c.tx.Hash(), relayed); ok {
+		// Already relayed and being watched.
+		return nil
+	}
+	go rl.watch(c.tx.Hash(), relayed)
+	return nil
+}
+
+// watch polls for the receipt of a relayed transaction until it is mined or
+// ReceiptTimeout passes.
+func (rl *Relay) watch(hash common.Hash, relayed *relayedTx) {
+	ctx, cancel := context.WithTimeout(rl.ctx, rl.config.ReceiptTimeout)
+	defer cancel()
+	ticker := time.NewTicker(relayReceiptPollInterval)
+	defer ticker.Stop()
+	log := logger.With().Str("tx", hash.Hex()).Str("method", relayed.method).Logger()
+	for {
+		select {
+		case <-ctx.Done():
+			if rl.ctx.Err() != nil {
+				return
+			}
+			relayed.mu.Lock()
+			relayed.status = RelayTimeout
+			relayed.mu.Unlock()
+			relayTransactionsTotal.WithLabelValues(relayed.method, RelayTimeout).Inc()
+			log.Warn().Dur("timeout", rl.config.ReceiptTimeout).Msg("relayed transaction not mined")
+			return
+		case <-ticker.C:
+		}
+		receipt, err := rl.client.TransactionReceipt(ctx, hash)
+		if errors.Is(err, ethereum.NotFound) {
+			continue
+		}
+		if err != nil {
+			log.Debug().Err(err).Msg("getting relayed transaction receipt")
+			continue
+		}
+		status := receiptStatus(receipt)
+		relayed.mu.Lock()
+		relayed.status = status
+		relayed.mu.Unlock()
+		relayTransactionsTotal.WithLabelValues(relayed.method, status).Inc()
+		log.Info().Str("status", status).Str("block", receipt.BlockNumber.String()).
+			Dur("latency", time.Since(relayed.submittedAt)).
+			Msg("relayed transaction mined")
+		return
+	}
+}
+
+// status reports hash from its receipt, falling back to what the relay
+// remembers of it and to the L2's transaction pool.
+func (rl *Relay) status(ctx context.Context, hash common.Hash) (*RelayStatus, error) {
+	status := &RelayStatus{TxHash: hash, Status: RelayUnknown}
+	if v, ok := rl.tracked.Get(hash); ok {
+		relayed := v.(*relayedTx)
+		relayed.mu.Lock()
+		status.Method, status.Status = relayed.method, relayed.status
+		sender := relayed.sender
+		status.Sender = &sender
+		relayed.mu.Unlock()
+	}
+	receipt, err := rl.client.TransactionReceipt(ctx, hash)
+	switch {
+	case err == nil:
+		status.Status = receiptStatus(receipt)
+		blockHash, gasUsed := receipt.BlockHash, hexutil.Uint64(receipt.GasUsed)
+		status.BlockNumber, status.BlockHash, status.GasUsed = receipt.BlockNumber, &blockHash, &gasUsed
+		return status, nil
+	case !errors.Is(err, ethereum.NotFound):
+		return nil, badGateway(fmt.Errorf("getting receipt: %w", err))
+	}
+	if status.Status == RelayUnknown {
+		if _, _, err := rl.client.TransactionByHash(ctx, hash); err == nil {
+			status.Status = RelayPending
+		} else if !errors.Is(err, ethereum.NotFound) {
+			return nil, badGateway(fmt.Errorf("getting transaction: %w", err))
+		}
+	}
+	return status, nil
+}
+
+func receiptStatus(receipt *types.Receipt) string {
+	if receipt.Status == types.ReceiptStatusSuccessful {
+		return RelaySuccess
+	}
+	return RelayReverted
+}
+
+// postRelay checks and submits a signed L2 registry write.
+func (g *Gateway) postRelay(w http.ResponseWriter, r *http.Request) {
+	req := &RelayRequest{}
+	if err := render.Bind(r, req); err != nil {
+		render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+	var err error
+	if req.RevertData != "" {
+		err = g.relay.checkDeferral(r.Context(), req.RevertData)
+	}
+	var checked *checkedRelay
+	if err == nil {
+		checked, err = g.relay.check(r.Context(), req.Calldata, req.SignedTx)
+	}
+	if err == nil {
+		setLogFields(r.Context(), map[string]interface{}{
+			"method": checked.method,
+			"sender": checked.sender.Hex(),
+			"tx":     checked.tx.Hash().Hex(),
+		})
+		err = g.relay.submit(r.Context(), checked)
+	}
+	if err != nil {
+		errResponse := ErrRender(err)
+		requestLogger(r.Context()).WithLevel(statusLevel(errResponse.HTTPStatusCode)).Err(err).Msg("relaying failed")
+		render.Render(w, r, errResponse)
+		return
+	}
+	sender := checked.sender
+	render.Status(r, http.StatusAccepted)
+	render.Render(w, r, RelayStatus{
+		TxHash: checked.tx.Hash(),
+		Method: checked.method,
+		Sender: &sender,
+		Status: RelayPending,
+	})
+}
+
+// getRelay reports the status of an L2 transaction.
+func (g *Gateway) getRelay(w http.ResponseWriter, r *http.Request) {
+	hash, err := parseHash(chi.URLParam(r, "txHash"))
+	if err != nil {
+		render.Render(w, r, ErrInvalidRequest(fmt.Errorf("parsing txHash: %w", err)))
+		return
+	}
+	status, err := g.relay.status(r.Context(), hash)
+	if err != nil {
+		render.Render(w, r, ErrRender(err))
+		return
+	}
+	render.Render(w, r, status)
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/0xpaulio/eth-sf-ens-rr/bindings"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+type fakeRelayClient struct{ chainID *big.Int }
+
+func (c fakeRelayClient) ChainID(ctx context.Context) (*big.Int, error) { return c.chainID, nil }
+
+func (c fakeRelayClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	return nil
+}
+
+func (c fakeRelayClient) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	return nil, false, ethereum.NotFound
+}
+
+func (c fakeRelayClient) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	return nil, ethereum.NotFound
+}
+
+func TestRelayCheck(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	chainID := big.NewInt(420)
+	rl, err := NewRelay(context.Background(), fakeRelayClient{chainID}, registryAddr, RelayConfig{MaxGas: 100000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	calldata, err := bindings.L1ENSRegistrySetOwnerArgs{Node: [32]byte{1}, Owner: common.HexToAddress("0xbeef")}.Pack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := bindings.L1ENSRegistrySetOwnerArgs{Node: [32]byte{2}, Owner: common.HexToAddress("0xbeef")}.Pack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sign := func(chainID *big.Int, to common.Address, gas uint64, data []byte) string {
+		tx, err := types.SignNewTx(key, types.LatestSignerForChainID(chainID), &types.DynamicFeeTx{
+			ChainID:   chainID,
+			To:        &to,
+			Gas:       gas,
+			GasFeeCap: big.NewInt(1),
+			Data:      data,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		raw, err := tx.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return hexutil.Encode(raw)
+	}
+
+	checked, err := rl.check(context.Background(), hexutil.Encode(calldata), sign(chainID, registryAddr, 50000, calldata))
+	if err != nil {
+		t.Fatalf("check failed: %v", err)
+	}
+	if checked.method != "setOwner" || checked.sender != crypto.PubkeyToAddress(key.PublicKey) {
+		t.Errorf("check = %s from %s, want setOwner from the signer", checked.method, checked.sender)
+	}
+
+	for name, signedTx := range map[string]string{
+		"wrong chain":     sign(big.NewInt(1), registryAddr, 50000, calldata),
+		"wrong contract":  sign(chainID, common.HexToAddress("0x01"), 50000, calldata),
+		"gas over limit":  sign(chainID, registryAddr, 200000, calldata),
+		"other arguments": sign(chainID, registryAddr, 50000, other),
+	} {
+		if _, err := rl.check(context.Background(), hexutil.Encode(calldata), signedTx); err == nil {
+			t.Errorf("%s: check succeeded, want an error", name)
+		}
+	}
+}
+
+func TestRelayCheckDeferral(t *testing.T) {
+	chainID := big.NewInt(420)
+	rl, err := NewRelay(context.Background(), fakeRelayClient{chainID}, registryAddr, RelayConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The L1 registry reverts with selector ++ abi.encode(chainId, contractAddress).
+	storageHandledByL2 := func(chainID *big.Int, registry common.Address) string {
+		l1ABI, err := bindings.L1ENSRegistryMetaData.GetAbi()
+		if err != nil {
+			t.Fatal(err)
+		}
+		args, err := l1ABI.Errors["StorageHandledByL2"].Inputs.Pack(chainID, registry)
+		if err != nil {
+			t.Fatal(err)
+		}
+		selector := bindings.L1ENSRegistryErrorSelectors["StorageHandledByL2(uint256,address)"]
+		return hexutil.Encode(append(selector[:], args...))
+	}
+
+	if err := rl.checkDeferral(context.Background(), storageHandledByL2(chainID, registryAddr)); err != nil {
+		t.Fatalf("checkDeferral failed: %v", err)
+	}
+	for name, revertData := range map[string]string{
+		"other chain":    storageHandledByL2(big.NewInt(10), registryAddr),
+		"other registry": storageHandledByL2(chainID, common.HexToAddress("0x01")),
+		"other error":    hexutil.Encode(crypto.Keccak256([]byte("StorageDNE()"))[:4]),
+		"truncated":      storageHandledByL2(chainID, registryAddr)[:40],
+	} {
+		if err := rl.checkDeferral(context.Background(), revertData); err == nil {
+			t.Errorf("%s: checkDeferral succeeded, want an error", name)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+// GatewayRequest is the EIP-3668 POST body, optionally extended with a
+// historical proof selector.
+type GatewayRequest struct {
+	Sender     string `json:"sender"`
+	Data       string `json:"data"`
+	Block      string `json:"block,omitempty"`
+	BatchIndex string `json:"batchIndex,omitempty"`
+	StateRoot  string `json:"stateRoot,omitempty"`
+}
+
+func (g *GatewayRequest) Bind(r *http.Request) error {
+	if g.Data == "" {
+		return errors.New("missing data")
+	}
+	return nil
+}
+
+// gatewayRequestFromURL reads a GET request's {sender}/{data} path and
+// selector query parameters.
+func gatewayRequestFromURL(r *http.Request) *GatewayRequest {
+	query := r.URL.Query()
+	return &GatewayRequest{
+		Sender:     chi.URLParam(r, "sender"),
+		Data:       chi.URLParam(r, "data"),
+		Block:      query.Get("block"),
+		BatchIndex: query.Get("batchIndex"),
+		StateRoot:  query.Get("stateRoot"),
+	}
+}
+
+// gatewayRequestFromBody reads a POST request's JSON body. Browsers send
+// simple POSTs as text/plain to skip the preflight, so the body is decoded as
+// JSON regardless of its Content-Type.
+func gatewayRequestFromBody(r *http.Request) (*GatewayRequest, error) {
+	req := &GatewayRequest{}
+	if err := render.DecodeJSON(r.Body, req); err != nil {
+		return nil, err
+	}
+	if err := req.Bind(r); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// ProofSelector pins a proof to a historical committed state root. The zero
+// value selects the latest L2 head.
+type ProofSelector struct {
+	Block      *big.Int
+	BatchIndex *big.Int
+	StateRoot  *common.Hash
+}
+
+// ParseProofSelector parses the optional block, batchIndex and stateRoot
+// parameters. Numbers may be decimal or 0x-prefixed hex. At most one may be
+// set.
+func ParseProofSelector(block, batchIndex, stateRoot string) (ProofSelector, error) {
+	var (
+		selector ProofSelector
+		set      int
+		err      error
+	)
+	if block != "" {
+		set++
+		if selector.Block, err = parseUint(block); err != nil {
+			return ProofSelector{}, fmt.Errorf("parsing block: %w", err)
+		}
+	}
+	if batchIndex != "" {
+		set++
+		if selector.BatchIndex, err = parseUint(batchIndex); err != nil {
+			return ProofSelector{}, fmt.Errorf("parsing batchIndex: %w", err)
+		}
+	}
+	if stateRoot != "" {
+		set++
+		root, err := DecodeHex(stateRoot)
+		if err != nil || len(root) != common.HashLength {
+			return ProofSelector{}, fmt.Errorf("parsing stateRoot: invalid 32 byte hex %q", stateRoot)
+		}
+		hash := common.BytesToHash(root)
+		selector.StateRoot = &hash
+	}
+	if set > 1 {
+		return ProofSelector{}, errors.New("only one of block, batchIndex and stateRoot may be set")
+	}
+	return selector, nil
+}
+
+// IsLatest reports whether the selector targets the latest L2 head.
+func (s ProofSelector) IsLatest() bool {
+	return s.Block == nil && s.BatchIndex == nil && s.StateRoot == nil
+}
+
+func (s ProofSelector) String() string {
+	switch {
+	case s.Block != nil:
+		return "block:" + s.Block.String()
+	case s.BatchIndex != nil:
+		return "batch:" + s.BatchIndex.String()
+	case s.StateRoot != nil:
+		return "root:" + s.StateRoot.Hex()
+	default:
+		return "latest"
+	}
+}
+
+func (s ProofSelector) stateRootProofInput(resolverAddr, addrSlot string) StateRootProofInput {
+	input := StateRootProofInput{
+		ResolverAddr: resolverAddr,
+		AddrSlot:     addrSlot,
+	}
+	if s.Block != nil {
+		input.BlockNumber = s.Block.String()
+	}
+	if s.BatchIndex != nil {
+		input.BatchIndex = s.BatchIndex.String()
+	}
+	if s.StateRoot != nil {
+		input.StateRoot = s.StateRoot.Hex()
+	}
+	return input
+}
+
+func parseInt(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid integer %q", s)
+	}
+	return n, nil
+}
+
+func parseUint(s string) (*big.Int, error) {
+	n, ok := new(big.Int).SetString(s, 0)
+	if !ok || n.Sign() < 0 {
+		return nil, fmt.Errorf("invalid unsigned integer %q", s)
+	}
+	return n, nil
+}
+
+// isMissingStateErr reports whether an RPC error means the node has pruned the
+// state for the requested block.
+func isMissingStateErr(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, s := range []string{
+		"missing trie node",
+		"header not found",
+		"historical state",
+		"state is not available",
+		"pruned",
+	} {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
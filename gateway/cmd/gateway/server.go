@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// ServerConfig configures the HTTP server.
+type ServerConfig struct {
+	ListenAddr        string
+	ReadTimeout       time.Duration
+	ReadHeaderTimeout time.Duration
+	// WriteTimeout bounds a whole proof request, so it has to cover the
+	// state root provider retries and L2 RPC failover.
+	WriteTimeout    time.Duration
+	IdleTimeout     time.Duration
+	MaxHeaderBytes  int
+	ShutdownTimeout time.Duration
+
+	// TLSCertFile and TLSKeyFile enable TLS when both are set. The pair is
+	// reloaded when either file changes, checked every TLSReloadInterval.
+	TLSCertFile       string
+	TLSKeyFile        string
+	TLSReloadInterval time.Duration
+}
+
+func serverConfigFromEnv() (ServerConfig, error) {
+	config := ServerConfig{
+		ListenAddr:  GetOrDefault("LISTEN_ADDR", ":41234"),
+		TLSCertFile: GetOrDefault("TLS_CERT_FILE", ""),
+		TLSKeyFile:  GetOrDefault("TLS_KEY_FILE", ""),
+	}
+	for _, d := range []struct {
+		env string
+		def string
+		dst *time.Duration
+	}{
+		{"HTTP_READ_TIMEOUT", "10s", &config.ReadTimeout},
+		{"HTTP_READ_HEADER_TIMEOUT", "5s", &config.ReadHeaderTimeout},
+		{"HTTP_WRITE_TIMEOUT", "60s", &config.WriteTimeout},
+		{"HTTP_IDLE_TIMEOUT", "120s", &config.IdleTimeout},
+		{"SHUTDOWN_TIMEOUT", "30s", &config.ShutdownTimeout},
+		{"TLS_RELOAD_INTERVAL", "1m", &config.TLSReloadInterval},
+	} {
+		v, err := time.ParseDuration(GetOrDefault(d.env, d.def))
+		if err != nil {
+			return config, fmt.Errorf("parsing %s: %w", d.env, err)
+		}
+		*d.dst = v
+	}
+	var err error
+	if config.MaxHeaderBytes, err = parseInt(GetOrDefault("HTTP_MAX_HEADER_BYTES", "65536")); err != nil {
+		return config, fmt.Errorf("parsing HTTP_MAX_HEADER_BYTES: %w", err)
+	}
+	if (config.TLSCertFile == "") != (config.TLSKeyFile == "") {
+		return config, errors.New("TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+	}
+	if config.TLSCertFile != "" && config.TLSReloadInterval <= 0 {
+		return config, fmt.Errorf("TLS_RELOAD_INTERVAL must be positive, got %s", config.TLSReloadInterval)
+	}
+	return config, nil
+}
+
+// serve runs the server until ctx is done, then stops accepting connections
+// and waits up to ShutdownTimeout for in-flight requests to finish.
+func serve(ctx context.Context, config ServerConfig, handler http.Handler) error {
+	server := &http.Server{
+		Addr:              config.ListenAddr,
+		Handler:           handler,
+		ReadTimeout:       config.ReadTimeout,
+		ReadHeaderTimeout: config.ReadHeaderTimeout,
+		WriteTimeout:      config.WriteTimeout,
+		IdleTimeout:       config.IdleTimeout,
+		MaxHeaderBytes:    config.MaxHeaderBytes,
+	}
+
+	serveErr := make(chan error, 1)
+	if config.TLSCertFile != "" {
+		certs, err := newCertReloader(config.TLSCertFile, config.TLSKeyFile)
+		if err != nil {
+			return err
+		}
+		go certs.watch(ctx, config.TLSReloadInterval)
+		server.TLSConfig = &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: certs.GetCertificate,
+		}
+		go func() { serveErr <- server.ListenAndServeTLS("", "") }()
+	} else {
+		go func() { serveErr <- server.ListenAndServe() }()
+	}
+	logger.Info().Str("addr", config.ListenAddr).Bool("tls", config.TLSCertFile != "").Msg("listening")
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	logger.Info().Dur("timeout", config.ShutdownTimeout).Msg("shutting down, draining in-flight requests")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), config.ShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("shutting down server: %w", err)
+	}
+	if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// certReloader serves the current certificate and swaps in a new one when the
+// files on disk change.
+type certReloader struct {
+	certFile, keyFile string
+
+	mu      sync.RWMutex
+	cert    *tls.Certificate
+	modTime time.Time
+}
+
+func newCertReloader(certFile, keyFile string) (*certReloader, error) {
+	c := &certReloader{certFile: certFile, keyFile: keyFile}
+	if err := c.reload(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *certReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.cert, nil
+}
+
+func (c *certReloader) reload() error {
+	modTime, err := c.latestModTime()
+	if err != nil {
+		return err
+	}
+	cert, err := tls.LoadX509KeyPair(c.certFile, c.keyFile)
+	if err != nil {
+		return fmt.Errorf("loading TLS key pair: %w", err)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cert = &cert
+	c.modTime = modTime
+	return nil
+}
+
+func (c *certReloader) latestModTime() (time.Time, error) {
+	var latest time.Time
+	for _, file := range []string{c.certFile, c.keyFile} {
+		info, err := os.Stat(file)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("stat %s: %w", file, err)
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+// watch reloads the key pair whenever the files change. A failed reload keeps
+// the previous certificate.
+func (c *certReloader) watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		modTime, err := c.latestModTime()
+		if err != nil {
+			logger.Error().Err(err).Msg("checking TLS certificate")
+			continue
+		}
+		c.mu.RLock()
+		changed := modTime.After(c.modTime)
+		c.mu.RUnlock()
+		if !changed {
+			continue
+		}
+		if err := c.reload(); err != nil {
+			logger.Error().Err(err).Msg("reloading TLS certificate")
+			continue
+		}
+		logger.Info().Str("cert_file", c.certFile).Msg("reloaded TLS certificate")
+	}
+}
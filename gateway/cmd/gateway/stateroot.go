@@ -0,0 +1,350 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// StateRootProvider supplies the state commitment chain half of a proof: the
+// committed state root, its batch header and the batch inclusion proof.
+type StateRootProvider interface {
+	StateRootProof(ctx context.Context, input StateRootProofInput) (*StateRootProof, error)
+	LatestStateBatch(ctx context.Context) (*StateRootBatchHeader, error)
+}
+
+// ErrCircuitOpen is returned while the provider is failing fast after too many
+// consecutive errors.
+var ErrCircuitOpen = errors.New("state root provider circuit open")
+
+var stateRootCircuitOpen = promauto.NewGauge(prometheus.GaugeOpts{
+	Namespace: "gateway",
+	Name:      "state_root_provider_circuit_open",
+	Help:      "Whether the state root provider circuit breaker is open.",
+})
+
+// StateBatch is the latest state batch appended to the state commitment chain.
+type StateBatch struct {
+	Batch StateRootBatchHeader `json:"batch"`
+}
+
+type HTTPStateRootProviderConfig struct {
+	// BaseURL is the sidecar address, e.g. http://localhost:41235.
+	BaseURL string
+	// Timeout bounds each attempt. Attempts are also bound by the caller's
+	// context.
+	Timeout time.Duration
+	// Retries is the number of attempts after the first.
+	Retries int
+	// Backoff is the delay before the first retry, doubled for each one
+	// after it.
+	Backoff time.Duration
+	// BreakerThreshold is the number of consecutive failed requests that
+	// opens the circuit breaker.
+	BreakerThreshold int
+	// BreakerCooldown is how long the breaker stays open before a trial
+	// request is let through.
+	BreakerCooldown time.Duration
+}
+
+func httpStateRootProviderConfigFromEnv() (HTTPStateRootProviderConfig, error) {
+	config := HTTPStateRootProviderConfig{
+		BaseURL: strings.TrimSuffix(GetOrDefault("STATE_ROOT_PROVIDER_URL", "http://localhost:41235"), "/"),
+	}
+	var err error
+	if config.Timeout, err = time.ParseDuration(GetOrDefault("STATE_ROOT_PROVIDER_TIMEOUT", "10s")); err != nil {
+		return config, fmt.Errorf("parsing STATE_ROOT_PROVIDER_TIMEOUT: %w", err)
+	}
+	if config.Retries, err = parseInt(GetOrDefault("STATE_ROOT_PROVIDER_RETRIES", "2")); err != nil {
+		return config, fmt.Errorf("parsing STATE_ROOT_PROVIDER_RETRIES: %w", err)
+	}
+	if config.Backoff, err = time.ParseDuration(GetOrDefault("STATE_ROOT_PROVIDER_BACKOFF", "250ms")); err != nil {
+		return config, fmt.Errorf("parsing STATE_ROOT_PROVIDER_BACKOFF: %w", err)
+	}
+	if config.BreakerThreshold, err = parseInt(GetOrDefault("STATE_ROOT_PROVIDER_BREAKER_THRESHOLD", "5")); err != nil {
+		return config, fmt.Errorf("parsing STATE_ROOT_PROVIDER_BREAKER_THRESHOLD: %w", err)
+	}
+	if config.BreakerCooldown, err = time.ParseDuration(GetOrDefault("STATE_ROOT_PROVIDER_BREAKER_COOLDOWN", "30s")); err != nil {
+		return config, fmt.Errorf("parsing STATE_ROOT_PROVIDER_BREAKER_COOLDOWN: %w", err)
+	}
+	switch {
+	case config.Timeout <= 0:
+		return config, fmt.Errorf("STATE_ROOT_PROVIDER_TIMEOUT must be positive, got %s", config.Timeout)
+	case config.Retries < 0:
+		return config, fmt.Errorf("STATE_ROOT_PROVIDER_RETRIES must not be negative, got %d", config.Retries)
+	case config.Backoff < 0:
+		return config, fmt.Errorf("STATE_ROOT_PROVIDER_BACKOFF must not be negative, got %s", config.Backoff)
+	case config.BreakerThreshold <= 0:
+		return config, fmt.Errorf("STATE_ROOT_PROVIDER_BREAKER_THRESHOLD must be positive, got %d", config.BreakerThreshold)
+	case config.BreakerCooldown <= 0:
+		return config, fmt.Errorf("STATE_ROOT_PROVIDER_BREAKER_COOLDOWN must be positive, got %s", config.BreakerCooldown)
+	}
+	return config, nil
+}
+
+// HTTPStateRootProvider fetches state root proofs from the cross-chain
+// messenger sidecar.
+type HTTPStateRootProvider struct {
+	config  HTTPStateRootProviderConfig
+	client  *http.Client
+	breaker *circuitBreaker
+}
+
+func NewHTTPStateRootProvider(config HTTPStateRootProviderConfig) *HTTPStateRootProvider {
+	return &HTTPStateRootProvider{
+		config:  config,
+		client:  tracedHTTPClient(),
+		breaker: &circuitBreaker{threshold: config.BreakerThreshold, cooldown: config.BreakerCooldown},
+	}
+}
+
+func (p *HTTPStateRootProvider) StateRootProof(ctx context.Context, input StateRootProofInput) (*StateRootProof, error) {
+	jsonBody, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling stateRootProof input: %w", err)
+	}
+	stateRootProof := &StateRootProof{}
+	err = p.do(ctx, "storage_proof", http.MethodPost, "/storage_proof", jsonBody, func(body []byte) error {
+		*stateRootProof = StateRootProof{}
+		if err := json.Unmarshal(body, stateRootProof); err != nil {
+			return fmt.Errorf("unmarshaling stateRootProof body: %w", err)
+		}
+		return stateRootProof.Validate()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stateRootProof, nil
+}
+
+func (p *HTTPStateRootProvider) LatestStateBatch(ctx context.Context) (*StateRootBatchHeader, error) {
+	stateBatch := &StateBatch{}
+	err := p.do(ctx, "state_batch", http.MethodGet, "/state_batch/latest", nil, func(body []byte) error {
+		*stateBatch = StateBatch{}
+		if err := json.Unmarshal(body, stateBatch); err != nil {
+			return fmt.Errorf("unmarshaling state batch body: %w", err)
+		}
+		return stateBatch.Batch.Validate()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &stateBatch.Batch, nil
+}
+
+// do sends the request through the circuit breaker and settles the breaker
+// with the outcome. The sidecar rejecting the request is reported as a bad
+// request and does not count against the sidecar, and neither does the caller
+// giving up on it.
+func (p *HTTPStateRootProvider) do(ctx context.Context, call, method, path string, body []byte, decode func([]byte) error) error {
+	if err := p.breaker.allow(); err != nil {
+		return badGateway(err)
+	}
+	err := p.retry(ctx, call, method, path, body, decode)
+	if err == nil || isBadRequest(err) {
+		p.breaker.success()
+		return err
+	}
+	if ctx.Err() != nil {
+		p.breaker.release()
+		return badGateway(fmt.Errorf("%s: %w", call, err))
+	}
+	p.breaker.failure()
+	return badGateway(fmt.Errorf("%s: %w", call, err))
+}
+
+// retry sends the request, retrying with exponential backoff until decode
+// accepts a response, a failure is not worth retrying, the attempts run out
+// or ctx is done.
+func (p *HTTPStateRootProvider) retry(ctx context.Context, call, method, path string, body []byte, decode func([]byte) error) error {
+	backoff := p.config.Backoff
+	var err error
+	for attempt := 0; attempt <= p.config.Retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("%w (last error: %s)", ctx.Err(), err)
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+		var retry bool
+		retry, err = p.attempt(ctx, method, path, body, decode)
+		if err == nil {
+			return nil
+		}
+		if isBadRequest(err) {
+			return err
+		}
+		observeUpstreamError(upstreamStateRoot, call)
+		if !retry || ctx.Err() != nil {
+			break
+		}
+	}
+	if err == nil {
+		return fmt.Errorf("no attempts made with %d retries", p.config.Retries)
+	}
+	return err
+}
+
+func isBadRequest(err error) bool {
+	var gwErr *gatewayError
+	return errors.As(err, &gwErr) && gwErr.status == http.StatusBadRequest
+}
+
+// attempt makes a single request. It reports whether a failure is worth
+// retrying.
+func (p *HTTPStateRootProvider) attempt(ctx context.Context, method, path string, body []byte, decode func([]byte) error) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.config.Timeout)
+	defer cancel()
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, p.config.BaseURL+path, bodyReader)
+	if err != nil {
+		return false, fmt.Errorf("building request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("requesting %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return true, fmt.Errorf("reading %s body: %w", path, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		err := fmt.Errorf("%s request failed: %s: %s", path, resp.Status, respBody)
+		if resp.StatusCode >= http.StatusBadRequest && resp.StatusCode < http.StatusInternalServerError && resp.StatusCode != http.StatusTooManyRequests {
+			// The sidecar rejected the request itself, e.g. an unknown batch
+			// index or state root, so asking again won't help.
+			return false, badRequest(err)
+		}
+		return resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests, err
+	}
+	// The sidecar builds proofs from live L1 and L2 reads, so a malformed
+	// response is usually a transient inconsistency and worth a retry.
+	return true, decode(respBody)
+}
+
+// Validate checks the proof is well formed before it is used to build a
+// response.
+func (p *StateRootProof) Validate() error {
+	if err := validateHash("stateRoot", p.StateRoot); err != nil {
+		return err
+	}
+	if err := p.StateRootBatchHeader.Validate(); err != nil {
+		return err
+	}
+	batchSize := p.StateRootBatchHeader.BatchSize.Int()
+	if p.StateRootProof.Index < 0 || big.NewInt(int64(p.StateRootProof.Index)).Cmp(batchSize) >= 0 {
+		return fmt.Errorf("stateRootProof.index %d out of range for batch size %s", p.StateRootProof.Index, batchSize)
+	}
+	for i, sibling := range p.StateRootProof.Siblings {
+		if len(sibling.Data) != common.HashLength {
+			return fmt.Errorf("stateRootProof.siblings[%d] is %d bytes, want %d", i, len(sibling.Data), common.HashLength)
+		}
+	}
+	return nil
+}
+
+func (h *StateRootBatchHeader) Validate() error {
+	if err := validateHash("batchRoot", h.BatchRoot); err != nil {
+		return err
+	}
+	for name, n := range map[string]BigNumber{
+		"batchIndex":        h.BatchIndex,
+		"batchSize":         h.BatchSize,
+		"prevTotalElements": h.PrevTotalElements,
+	} {
+		if _, err := DecodeHex(n.Hex); err != nil || !strings.HasPrefix(n.Hex, "0x") {
+			return fmt.Errorf("%s: invalid BigNumber hex %q", name, n.Hex)
+		}
+	}
+	if h.BatchSize.Int().Sign() == 0 {
+		return errors.New("batchSize is zero")
+	}
+	if _, err := DecodeHex(h.ExtraData); err != nil {
+		return fmt.Errorf("extraData: invalid hex %q", h.ExtraData)
+	}
+	return nil
+}
+
+func validateHash(name, h string) error {
+	b, err := DecodeHex(h)
+	if err != nil || len(b) != common.HashLength || !strings.HasPrefix(h, "0x") {
+		return fmt.Errorf("%s: invalid 32 byte hex %q", name, h)
+	}
+	return nil
+}
+
+// circuitBreaker fails fast after threshold consecutive failures, letting a
+// single trial request through once cooldown has passed.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	trial     bool
+}
+
+func (b *circuitBreaker) allow() error {
+	if b.threshold <= 0 {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.failures < b.threshold {
+		return nil
+	}
+	if time.Now().Before(b.openUntil) || b.trial {
+		return ErrCircuitOpen
+	}
+	b.trial = true
+	return nil
+}
+
+func (b *circuitBreaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.trial = false
+	stateRootCircuitOpen.Set(0)
+}
+
+// release ends a trial without settling the breaker, so the next request is
+// let through as a new trial.
+func (b *circuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trial = false
+}
+
+func (b *circuitBreaker) failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	b.trial = false
+	if b.threshold > 0 && b.failures >= b.threshold {
+		b.openUntil = time.Now().Add(b.cooldown)
+		stateRootCircuitOpen.Set(1)
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	b := &circuitBreaker{threshold: 2, cooldown: 20 * time.Millisecond}
+	for i := 0; i < 2; i++ {
+		if err := b.allow(); err != nil {
+			t.Fatalf("closed breaker refused request %d: %v", i, err)
+		}
+		b.failure()
+	}
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("got %v after %d failures, want ErrCircuitOpen", err, b.threshold)
+	}
+
+	time.Sleep(b.cooldown)
+	if err := b.allow(); err != nil {
+		t.Fatalf("trial refused after cooldown: %v", err)
+	}
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("got %v during the trial, want ErrCircuitOpen", err)
+	}
+	b.failure()
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("got %v after a failed trial, want ErrCircuitOpen", err)
+	}
+
+	time.Sleep(b.cooldown)
+	if err := b.allow(); err != nil {
+		t.Fatalf("second trial refused: %v", err)
+	}
+	b.success()
+	for i := 0; i < 2; i++ {
+		if err := b.allow(); err != nil {
+			t.Fatalf("breaker not closed after a successful trial: %v", err)
+		}
+	}
+}
+
+// newTestStateRootProvider serves every request with status and counts them.
+func newTestStateRootProvider(t *testing.T, status int, config HTTPStateRootProviderConfig) (*HTTPStateRootProvider, *int32) {
+	t.Helper()
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		http.Error(w, "state batch 99 not found", status)
+	}))
+	t.Cleanup(ts.Close)
+	config.BaseURL = ts.URL
+	if config.Timeout == 0 {
+		config.Timeout = time.Second
+	}
+	return NewHTTPStateRootProvider(config), &requests
+}
+
+func TestHTTPStateRootProviderTrialTimesOut(t *testing.T) {
+	p, _ := newTestStateRootProvider(t, http.StatusInternalServerError, HTTPStateRootProviderConfig{
+		Retries:          1,
+		Backoff:          time.Hour,
+		BreakerThreshold: 1,
+		BreakerCooldown:  20 * time.Millisecond,
+	})
+	p.breaker.failure()
+	if err := p.breaker.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("got %v, want ErrCircuitOpen", err)
+	}
+
+	// The trial fails its first attempt and its context ends during the
+	// backoff before the retry.
+	time.Sleep(p.config.BreakerCooldown)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := p.LatestStateBatch(ctx); err == nil || errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("got %v, want the trial to run and fail", err)
+	}
+
+	// The trial must be settled so another is let through after cooldown.
+	time.Sleep(p.config.BreakerCooldown)
+	if err := p.breaker.allow(); err != nil {
+		t.Fatalf("breaker stuck after a timed out trial: %v", err)
+	}
+}
+
+func TestHTTPStateRootProviderRejected(t *testing.T) {
+	p, requests := newTestStateRootProvider(t, http.StatusNotFound, HTTPStateRootProviderConfig{
+		Retries:          2,
+		Backoff:          time.Millisecond,
+		BreakerThreshold: 1,
+		BreakerCooldown:  time.Hour,
+	})
+	for i := 0; i < 2; i++ {
+		_, err := p.StateRootProof(context.Background(), StateRootProofInput{})
+		if got := ErrRender(err).HTTPStatusCode; got != http.StatusBadRequest {
+			t.Fatalf("got status %d (%v), want %d", got, err, http.StatusBadRequest)
+		}
+	}
+	if n := atomic.LoadInt32(requests); n != 2 {
+		t.Errorf("sidecar got %d requests, want 2: rejections must not be retried or open the breaker", n)
+	}
+}
+
+func TestHTTPStateRootProviderCancelled(t *testing.T) {
+	p, _ := newTestStateRootProvider(t, http.StatusInternalServerError, HTTPStateRootProviderConfig{
+		Backoff:          time.Millisecond,
+		BreakerThreshold: 1,
+		BreakerCooldown:  time.Hour,
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for i := 0; i < 2; i++ {
+		if _, err := p.LatestStateBatch(ctx); err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("got %v, want the request to run and fail", err)
+		}
+	}
+	if err := p.breaker.allow(); err != nil {
+		t.Fatalf("breaker opened by cancelled requests: %v", err)
+	}
+
+	// A cancelled trial is released rather than failed, so the next request
+	// is a trial without waiting out another cooldown.
+	p.breaker.failure()
+	p.breaker.openUntil = time.Time{}
+	if _, err := p.LatestStateBatch(ctx); err == nil || errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("got %v, want the trial to run and fail", err)
+	}
+	if err := p.breaker.allow(); err != nil {
+		t.Fatalf("cancelled trial reopened the breaker: %v", err)
+	}
+}
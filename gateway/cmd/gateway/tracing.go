@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/0xpaulio/eth-sf-ens-rr/cmd/gateway")
+
+// Span attribute keys for the proof pipeline.
+var (
+	attrMethod     = attribute.Key("gateway.method")
+	attrNode       = attribute.Key("gateway.node")
+	attrName       = attribute.Key("gateway.name")
+	attrSlot       = attribute.Key("gateway.slot")
+	attrBlock      = attribute.Key("gateway.block_number")
+	attrBatchIndex = attribute.Key("gateway.batch_index")
+)
+
+// setupTracing installs the global tracer provider selected by
+// OTEL_TRACES_EXPORTER: "otlp" exports over OTLP/HTTP, configured through the
+// standard OTEL_EXPORTER_OTLP_* variables, "stdout" pretty prints spans for
+// local runs, and "none" (the default) disables tracing. The returned function
+// flushes and stops the provider.
+func setupTracing(ctx context.Context) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var (
+		exporter sdktrace.SpanExporter
+		err      error
+	)
+	switch kind := GetOrDefault("OTEL_TRACES_EXPORTER", "none"); kind {
+	case "none":
+		return func(context.Context) error { return nil }, nil
+	case "otlp":
+		exporter, err = otlptracehttp.New(ctx)
+	case "stdout":
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout), stdouttrace.WithPrettyPrint())
+	default:
+		return nil, fmt.Errorf("unknown OTEL_TRACES_EXPORTER %q", kind)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("creating trace exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceNameKey.String(GetOrDefault("OTEL_SERVICE_NAME", "ens-gateway")),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("creating trace resource: %w", err)
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// tracedHTTPClient returns an HTTP client that propagates the trace context of
+// outbound requests.
+func tracedHTTPClient() *http.Client {
+	return &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)}
+}
+
+// startPhase starts the span for a pipeline phase. The returned function ends
+// it, recording err on the span and the phase latency.
+func startPhase(ctx context.Context, phase string, attrs ...attribute.KeyValue) (context.Context, trace.Span, func(error)) {
+	start := time.Now()
+	ctx, span := tracer.Start(ctx, phase, trace.WithAttributes(attrs...))
+	return ctx, span, func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+		observePhase(phase, start)
+		event := requestLogger(ctx).Debug()
+		if err != nil {
+			event = event.Err(err)
+		}
+		event.Str("phase", phase).
+			Float64("latency_ms", float64(time.Since(start).Microseconds())/1000).
+			Msg("phase complete")
+	}
+}
+
+// dialL2 connects to the L2 RPC. HTTP endpoints go through a traced client so
+// JSON-RPC calls carry the caller's trace context.
+func dialL2(url string) (*rpc.Client, error) {
+	if strings.HasPrefix(url, "http://") || strings.HasPrefix(url, "https://") {
+		return rpc.DialHTTPWithClient(url, tracedHTTPClient())
+	}
+	return rpc.Dial(url)
+}
+
+// traceRequests starts a server span per request, continuing any trace
+// propagated by the caller.
+func traceRequests(next http.Handler) http.Handler {
+	return otelhttp.NewHandler(next, "gateway")
+}
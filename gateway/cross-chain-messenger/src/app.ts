@@ -27,17 +27,28 @@ const crossChainMessenger = new optimismSDK.CrossChainMessenger({
 app.post('/storage_proof', async (req, res) => {
   console.log(req.body);
   try {
+    const blockTag = await getBlockTag(req.body);
     let storageProof = await crossChainMessenger.getStorageProof(req.body["resolver_addr"], req.body["addr_slot"], {
-      blockTag:'latest'
+      blockTag
     });
     res.json(storageProof);
   } catch(e) {
     console.log("getStorageProof", e)
-    res.status(400);
+    if (e instanceof UnknownSelectorError) {
+      res.status(404);
+      res.send(e.message);
+      return;
+    }
+    res.status(502);
     res.send('getStorageProof failed');
   }
 });
 
+// UnknownSelectorError is thrown when the historical selector in a request
+// names a batch or state root the state commitment chain doesn't have. It is
+// the caller's mistake, so it is answered with a 4xx rather than a 502.
+class UnknownSelectorError extends Error {}
+
 const ADDRESS_MANAGER_ADDRESS = '0xa6f73589243a6A7a9023b1Fa0651b1d89c177111';
 
 
@@ -105,6 +116,63 @@ async function getLatestStateBatchHeader(): Promise<{batch: StateRootBatchHeader
     throw Error("No state root batches found");
 }
 
+// getBlockTag maps the optional historical selector sent by the gateway to the
+// L2 block whose committed state root the proof should be built against.
+async function getBlockTag(body: any): Promise<string | number> {
+    if (body["block_number"] !== undefined) {
+        return BigNumber.from(body["block_number"]).toNumber();
+    }
+    if (body["batch_index"] !== undefined) {
+        const event = await crossChainMessenger.getStateBatchAppendedEventByBatchIndex(BigNumber.from(body["batch_index"]));
+        if (event === null) {
+            throw new UnknownSelectorError(`state batch ${body["batch_index"]} not found`);
+        }
+        // The last state root in the batch is for block prevTotalElements + batchSize
+        return event.args._prevTotalElements.add(event.args._batchSize).toNumber();
+    }
+    if (body["state_root"] !== undefined) {
+        return getBlockNumberForStateRoot(body["state_root"]);
+    }
+    return 'latest';
+}
+
+// STATE_ROOT_SEARCH_DEPTH bounds how many of the most recent state batches are
+// searched for a state_root selector. Older roots are rejected.
+const stateRootSearchDepth = parseInt(process.env.STATE_ROOT_SEARCH_DEPTH || '1000', 10);
+
+async function getBlockNumberForStateRoot(stateRoot: string): Promise<number> {
+    const ovmStateCommitmentChain = await loadContractFromManager('StateCommitmentChain', ovmAddressManager, l1_provider);
+
+    // Walk batches newest first by their indexed batch index instead of
+    // scanning L1 block ranges.
+    const totalBatches: BigNumber = await ovmStateCommitmentChain.getTotalBatches();
+    const lowest = totalBatches.sub(stateRootSearchDepth);
+    for (let batchIndex = totalBatches.sub(1); batchIndex.gte(0) && batchIndex.gte(lowest); batchIndex = batchIndex.sub(1)) {
+        const event = await crossChainMessenger.getStateBatchAppendedEventByBatchIndex(batchIndex);
+        if (event === null) {
+            throw Error(`state batch ${batchIndex} not found`);
+        }
+        const tx = await l1_provider.getTransaction(event.transactionHash);
+        const [ stateRoots ] = ovmStateCommitmentChain.interface.decodeFunctionData('appendStateBatch', tx.data);
+        const index = stateRoots.findIndex((root: string) => root.toLowerCase() === stateRoot.toLowerCase());
+        if (index >= 0) {
+            // Element i of the state commitment chain is the state root of L2 block i+1
+            return event.args._prevTotalElements.add(index + 1).toNumber();
+        }
+    }
+    throw new UnknownSelectorError(`state root ${stateRoot} not found in the last ${stateRootSearchDepth} state batches`);
+}
+
+app.get('/state_batch/latest', async (req, res) => {
+  try {
+    res.json(await getLatestStateBatchHeader());
+  } catch(e) {
+    console.log("getLatestStateBatchHeader", e)
+    res.status(503);
+    res.send('getLatestStateBatchHeader failed');
+  }
+});
+
 // app.post('/storage_proof', async (req, res) => {
 //   const stateBatchHeader = await getLatestStateBatchHeader();
 //   // The l2 block number we'll use is the last one in the state batch
@@ -0,0 +1,342 @@
+// Package indexer follows the L2 registry's events into a local store, so
+// the gateway can answer enumeration queries (a name's subnodes, an owner's
+// names and operators) that storage proofs cannot.
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/0xpaulio/eth-sf-ens-rr/bindings"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog"
+)
+
+// ErrReorgTooDeep is returned when the indexed chain diverges from the L2
+// further back than the configured reorg depth. The index has to be rebuilt.
+var ErrReorgTooDeep = errors.New("reorg deeper than the indexer's reorg depth")
+
+var (
+	headBlock = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "gateway",
+		Name:      "indexer_head_block",
+		Help:      "Last L2 block applied to the registry index.",
+	})
+	eventsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gateway",
+		Name:      "indexer_events_total",
+		Help:      "L2 registry events applied to the index by event name.",
+	}, []string{"event"})
+	reorgsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "gateway",
+		Name:      "indexer_reorgs_total",
+		Help:      "L2 reorgs the index was rewound for.",
+	})
+)
+
+// Client is the subset of the L2 RPC the indexer reads from.
+type Client interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+	TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error)
+}
+
+// Config configures the indexer.
+type Config struct {
+	// Registry is the L2 registry whose events are indexed.
+	Registry common.Address
+	// StartBlock is the first block indexed, usually the registry's
+	// deployment block.
+	StartBlock uint64
+	// Confirmations is how far behind the L2 head the indexer stays.
+	Confirmations uint64
+	// BlockRange is the most blocks requested in one eth_getLogs call.
+	BlockRange uint64
+	// ReorgDepth is how many blocks of undo journal are kept. Reorgs deeper
+	// than this fail with ErrReorgTooDeep.
+	ReorgDepth uint64
+	// PollInterval is the time between catching up with the L2 head.
+	PollInterval time.Duration
+	// Dictionary holds known labels for rebuilding names.
+	Dictionary Dictionary
+	// CalldataLabels enables looking for the labels of new subnodes in the
+	// calldata of the transactions that created them.
+	CalldataLabels bool
+}
+
+// Indexer applies L2 registry events to a Store.
+type Indexer struct {
+	store    *Store
+	client   Client
+	config   Config
+	logger   zerolog.Logger
+	filterer *bindings.L2ENSRegistryFilterer
+	events   map[common.Hash]string
+}
+
+// New creates an indexer writing to store.
+func New(store *Store, client Client, config Config, logger zerolog.Logger) (*Indexer, error) {
+	if config.BlockRange == 0 {
+		return nil, errors.New("block range must be positive")
+	}
+	if config.PollInterval <= 0 {
+		return nil, errors.New("poll interval must be positive")
+	}
+	filterer, err := bindings.NewL2ENSRegistryFilterer(config.Registry, nil)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := bindings.L2ENSRegistryMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	events := make(map[common.Hash]string)
+	for name, event := range parsed.Events {
+		events[event.ID] = name
+	}
+	return &Indexer{
+		store:    store,
+		client:   client,
+		config:   config,
+		logger:   logger.With().Str("component", "indexer").Logger(),
+		filterer: filterer,
+		events:   events,
+	}, nil
+}
+
+// Store returns the store the indexer writes to.
+func (ix *Indexer) Store() *Store {
+	return ix.store
+}
+
+// Run keeps the index caught up with the L2 until ctx is done.
+func (ix *Indexer) Run(ctx context.Context) {
+	ticker := time.NewTicker(ix.config.PollInterval)
+	defer ticker.Stop()
+	for {
+		if err := ix.Sync(ctx); err != nil && ctx.Err() == nil {
+			ix.logger.Error().Err(err).Msg("syncing registry index")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync indexes up to Confirmations blocks behind the current L2 head,
+// rewinding first if the indexed blocks are no longer canonical.
+func (ix *Indexer) Sync(ctx context.Context) error {
+	latest, err := ix.client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("getting L2 head: %w", err)
+	}
+	if latest < ix.config.Confirmations {
+		return nil
+	}
+	target := latest - ix.config.Confirmations
+	for {
+		head, hash, ok, err := ix.store.Head()
+		if err != nil {
+			return err
+		}
+		from := ix.config.StartBlock
+		if ok {
+			canonical, err := ix.canonicalHash(ctx, head)
+			if err != nil {
+				return err
+			}
+			if canonical != hash {
+				if err := ix.rewind(ctx, head); err != nil {
+					return err
+				}
+				continue
+			}
+			from = head + 1
+		}
+		if from > target {
+			return nil
+		}
+		to := from + ix.config.BlockRange - 1
+		if to > target {
+			to = target
+		}
+		if err := ix.indexRange(ctx, from, to); err != nil {
+			return err
+		}
+	}
+}
+
+// indexRange applies the registry's logs in [from, to] as one write.
+func (ix *Indexer) indexRange(ctx context.Context, from, to uint64) error {
+	logs, err := ix.client.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to),
+		Addresses: []common.Address{ix.config.Registry},
+	})
+	if err != nil {
+		return fmt.Errorf("getting registry logs for blocks %d-%d: %w", from, to, err)
+	}
+	// The logs may come from different forks of the range if the L2
+	// reorged under the query, so every block they come from is checked
+	// against the canonical chain, read after them.
+	hashes := make(map[uint64]common.Hash)
+	for _, log := range logs {
+		if log.Removed {
+			continue
+		}
+		if _, ok := hashes[log.BlockNumber]; !ok {
+			if hashes[log.BlockNumber], err = ix.canonicalHash(ctx, log.BlockNumber); err != nil {
+				return err
+			}
+		}
+		if log.BlockHash != hashes[log.BlockNumber] {
+			return fmt.Errorf("block %d changed while indexing", log.BlockNumber)
+		}
+	}
+	toHash, ok := hashes[to]
+	if !ok {
+		if toHash, err = ix.canonicalHash(ctx, to); err != nil {
+			return err
+		}
+	}
+
+	u := ix.store.newUpdate()
+	var labels labelRecovery
+	counts := make(map[string]int)
+	for _, log := range logs {
+		if log.Removed {
+			continue
+		}
+		if !u.inBlock || log.BlockNumber != u.number {
+			if err := u.startBlock(log.BlockNumber, log.BlockHash); err != nil {
+				return err
+			}
+		}
+		name, err := ix.apply(u, &labels, log)
+		if err != nil {
+			return fmt.Errorf("applying log %d of tx %s: %w", log.Index, log.TxHash, err)
+		}
+		if name != "" {
+			counts[name]++
+		}
+	}
+	if !u.inBlock || u.number != to {
+		if err := u.startBlock(to, toHash); err != nil {
+			return err
+		}
+	}
+	if err := ix.recoverLabels(ctx, u, &labels); err != nil {
+		return err
+	}
+	keepFrom := uint64(0)
+	if to > ix.config.ReorgDepth {
+		keepFrom = to - ix.config.ReorgDepth
+	}
+	if err := u.commit(keepFrom); err != nil {
+		return fmt.Errorf("writing blocks %d-%d: %w", from, to, err)
+	}
+
+	for name, n := range counts {
+		eventsTotal.WithLabelValues(name).Add(float64(n))
+	}
+	headBlock.Set(float64(to))
+	ix.logger.Debug().Uint64("from", from).Uint64("to", to).Int("logs", len(logs)).Msg("indexed blocks")
+	return nil
+}
+
+// apply applies one registry log and returns its event name, or "" for
+// events the index does not track.
+func (ix *Indexer) apply(u *update, labels *labelRecovery, log types.Log) (string, error) {
+	if len(log.Topics) == 0 {
+		return "", nil
+	}
+	name := ix.events[log.Topics[0]]
+	switch name {
+	case "NewOwner":
+		ev, err := ix.filterer.ParseNewOwner(log)
+		if err != nil {
+			return name, err
+		}
+		if err := ix.noteLabel(labels, ev.Label, log.TxHash); err != nil {
+			return name, err
+		}
+		return name, u.newOwner(ev.Node, ev.Label, ev.Owner)
+	case "Transfer":
+		ev, err := ix.filterer.ParseTransfer(log)
+		if err != nil {
+			return name, err
+		}
+		return name, u.transfer(ev.Node, ev.Owner)
+	case "NewResolver":
+		ev, err := ix.filterer.ParseNewResolver(log)
+		if err != nil {
+			return name, err
+		}
+		return name, u.setResolver(ev.Node, ev.Resolver)
+	case "NewTTL":
+		ev, err := ix.filterer.ParseNewTTL(log)
+		if err != nil {
+			return name, err
+		}
+		return name, u.setTTL(ev.Node, ev.Ttl)
+	case "ApprovalForAll":
+		ev, err := ix.filterer.ParseApprovalForAll(log)
+		if err != nil {
+			return name, err
+		}
+		return name, u.setApproval(ev.Owner, ev.Operator, ev.Approved)
+	default:
+		return "", nil
+	}
+}
+
+// rewind walks back from head to the newest journalled block that is still
+// canonical and undoes everything after it.
+func (ix *Indexer) rewind(ctx context.Context, head uint64) error {
+	floor := ix.config.StartBlock
+	if head > ix.config.ReorgDepth && head-ix.config.ReorgDepth > floor {
+		floor = head - ix.config.ReorgDepth
+	}
+	for n := head; n >= floor && n <= head; n-- {
+		hash, ok, err := ix.store.checkpoint(n)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		canonical, err := ix.canonicalHash(ctx, n)
+		if err != nil {
+			return err
+		}
+		if canonical != hash {
+			continue
+		}
+		if err := ix.store.rewind(head, n, hash); err != nil {
+			return fmt.Errorf("rewinding index to block %d: %w", n, err)
+		}
+		reorgsTotal.Inc()
+		headBlock.Set(float64(n))
+		ix.logger.Warn().Uint64("from", head).Uint64("to", n).Msg("rewound index for L2 reorg")
+		return nil
+	}
+	return fmt.Errorf("%w: no canonical block found between %d and %d", ErrReorgTooDeep, floor, head)
+}
+
+func (ix *Indexer) canonicalHash(ctx context.Context, number uint64) (common.Hash, error) {
+	header, err := ix.client.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("getting L2 header %d: %w", number, err)
+	}
+	return header.Hash(), nil
+}
@@ -0,0 +1,299 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xpaulio/eth-sf-ens-rr/bindings"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/rs/zerolog"
+)
+
+var registry = common.HexToAddress("0xE933897412cc2164331e542B2a2Be491612C233F")
+
+// fakeChain is an L2 whose blocks can be replaced to simulate a reorg.
+type fakeChain struct {
+	headers []*types.Header
+	logs    map[uint64][]types.Log
+	txs     map[common.Hash]*types.Transaction
+	// afterFilterLogs, when set, runs once the logs of a query are read.
+	afterFilterLogs func()
+}
+
+func newFakeChain(n int) *fakeChain {
+	c := &fakeChain{logs: make(map[uint64][]types.Log), txs: make(map[common.Hash]*types.Transaction)}
+	for i := 0; i < n; i++ {
+		c.setBlock(uint64(i), 0)
+	}
+	return c
+}
+
+// setBlock replaces block number and drops the blocks after it. fork
+// distinguishes the replacement's hash.
+func (c *fakeChain) setBlock(number uint64, fork byte) {
+	header := &types.Header{Number: new(big.Int).SetUint64(number), Extra: []byte{fork}, Difficulty: common.Big0}
+	if number > 0 {
+		header.ParentHash = c.headers[number-1].Hash()
+	}
+	c.headers = append(c.headers[:number], header)
+	for n := range c.logs {
+		if n >= number {
+			delete(c.logs, n)
+		}
+	}
+}
+
+func (c *fakeChain) addLog(number uint64, topics []common.Hash, data []byte) {
+	c.logs[number] = append(c.logs[number], types.Log{
+		Address:     registry,
+		Topics:      topics,
+		Data:        data,
+		BlockNumber: number,
+		BlockHash:   c.headers[number].Hash(),
+		Index:       uint(len(c.logs[number])),
+	})
+}
+
+func (c *fakeChain) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	tx, ok := c.txs[hash]
+	if !ok {
+		return nil, false, ethereum.NotFound
+	}
+	return tx, false, nil
+}
+
+func (c *fakeChain) BlockNumber(ctx context.Context) (uint64, error) {
+	return uint64(len(c.headers) - 1), nil
+}
+
+func (c *fakeChain) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if number.Uint64() >= uint64(len(c.headers)) {
+		return nil, ethereum.NotFound
+	}
+	return c.headers[number.Uint64()], nil
+}
+
+func (c *fakeChain) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	var logs []types.Log
+	for n := q.FromBlock.Uint64(); n <= q.ToBlock.Uint64(); n++ {
+		logs = append(logs, c.logs[n]...)
+	}
+	if c.afterFilterLogs != nil {
+		c.afterFilterLogs()
+	}
+	return logs, nil
+}
+
+func newOwnerLog(t *testing.T, c *fakeChain, number uint64, parent common.Hash, label string, owner common.Address) common.Hash {
+	t.Helper()
+	parsed, err := bindings.L2ENSRegistryMetaData.GetAbi()
+	if err != nil {
+		t.Fatal(err)
+	}
+	labelHash := crypto.Keccak256Hash([]byte(label))
+	data, err := parsed.Events["NewOwner"].Inputs.NonIndexed().Pack(owner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.addLog(number, []common.Hash{parsed.Events["NewOwner"].ID, parent, labelHash}, data)
+	// Each NewOwner comes from its own transaction, keyed by its labelhash.
+	logs := c.logs[number]
+	logs[len(logs)-1].TxHash = crypto.Keccak256Hash([]byte(label))
+	return crypto.Keccak256Hash(parent[:], labelHash[:])
+}
+
+func approvalLog(t *testing.T, c *fakeChain, number uint64, owner, operator common.Address, approved bool) {
+	t.Helper()
+	parsed, err := bindings.L2ENSRegistryMetaData.GetAbi()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := parsed.Events["ApprovalForAll"].Inputs.NonIndexed().Pack(approved)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.addLog(number, []common.Hash{parsed.Events["ApprovalForAll"].ID, common.BytesToHash(owner[:]), common.BytesToHash(operator[:])}, data)
+}
+
+func TestIndexerRewindsReorgs(t *testing.T) {
+	var (
+		alice = common.HexToAddress("0xa11ce")
+		bob   = common.HexToAddress("0xb0b")
+		ctx   = context.Background()
+	)
+	chain := newFakeChain(6)
+	eth := newOwnerLog(t, chain, 1, common.Hash{}, "eth", alice)
+	sub := newOwnerLog(t, chain, 3, eth, "sub", alice)
+	approvalLog(t, chain, 4, alice, bob, true)
+
+	store := NewStore(memorydb.New())
+	ix, err := New(store, chain, Config{Registry: registry, BlockRange: 2, ReorgDepth: 16, PollInterval: time.Second}, zerolog.Nop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ix.Sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if head, _, _, _ := store.Head(); head != 5 {
+		t.Fatalf("head = %d, want 5", head)
+	}
+	owned, err := store.OwnedBy(alice, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(owned) != 2 {
+		t.Fatalf("alice owns %d nodes, want 2", len(owned))
+	}
+	subnodes, err := store.Subnodes(eth, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subnodes) != 1 || subnodes[0].Node != sub || subnodes[0].Parent != eth {
+		t.Fatalf("subnodes of eth = %+v, want %s", subnodes, sub)
+	}
+	if operators, _ := store.Operators(alice); len(operators) != 1 || operators[0] != bob {
+		t.Fatalf("operators = %v, want [%s]", operators, bob)
+	}
+
+	// Replace blocks 3 onwards: sub goes to bob instead and the approval
+	// never happens.
+	chain.setBlock(3, 1)
+	newOwnerLog(t, chain, 3, eth, "sub", bob)
+	chain.setBlock(4, 1)
+	chain.setBlock(5, 1)
+	chain.setBlock(6, 1)
+	if err := ix.Sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	head, hash, _, _ := store.Head()
+	if head != 6 || hash != chain.headers[6].Hash() {
+		t.Fatalf("head = %d %s, want 6 %s", head, hash, chain.headers[6].Hash())
+	}
+	rec, err := store.Record(sub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.Owner != bob {
+		t.Fatalf("sub owner = %s, want %s", rec.Owner, bob)
+	}
+	if owned, _ := store.OwnedBy(alice, nil, 0); len(owned) != 1 || owned[0].Node != eth {
+		t.Fatalf("alice owns %+v, want only eth", owned)
+	}
+	if operators, _ := store.Operators(alice); len(operators) != 0 {
+		t.Fatalf("operators = %v, want none", operators)
+	}
+}
+
+func TestIndexerRecoversNames(t *testing.T) {
+	alice := common.HexToAddress("0xa11ce")
+	chain := newFakeChain(3)
+	eth := newOwnerLog(t, chain, 1, common.Hash{}, "eth", alice)
+	sub := newOwnerLog(t, chain, 2, eth, "alice", alice)
+	hidden := newOwnerLog(t, chain, 2, sub, "hidden", alice)
+
+	// alice.eth was registered through a contract taking the plain name,
+	// say register(string,address), wrapped in a multicall.
+	stringType, _ := abi.NewType("string", "", nil)
+	addressType, _ := abi.NewType("address", "", nil)
+	bytesType, _ := abi.NewType("bytes", "", nil)
+	inner, err := abi.Arguments{{Type: stringType}, {Type: addressType}}.Pack("alice.eth", alice)
+	if err != nil {
+		t.Fatal(err)
+	}
+	outer, err := abi.Arguments{{Type: bytesType}}.Pack(append([]byte{1, 2, 3, 4}, inner...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := types.NewTx(&types.LegacyTx{Data: append([]byte{5, 6, 7, 8}, outer...)})
+	chain.txs[crypto.Keccak256Hash([]byte("alice"))] = tx
+	chain.txs[crypto.Keccak256Hash([]byte("hidden"))] = types.NewTx(&types.LegacyTx{})
+
+	ix, err := New(NewStore(memorydb.New()), chain, Config{
+		Registry:       registry,
+		BlockRange:     10,
+		ReorgDepth:     16,
+		PollInterval:   time.Second,
+		Dictionary:     NewDictionary([]string{"eth"}),
+		CalldataLabels: true,
+	}, zerolog.Nop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ix.Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		node     common.Hash
+		name     string
+		complete bool
+	}{
+		{sub, "alice.eth", true},
+		{hidden, fmt.Sprintf("[%x].alice.eth", crypto.Keccak256([]byte("hidden"))), false},
+	} {
+		name, complete, ok, err := ix.Name(tt.node)
+		if err != nil || !ok {
+			t.Fatalf("Name(%s) = %v, %v", tt.node, ok, err)
+		}
+		if name != tt.name || complete != tt.complete {
+			t.Errorf("Name(%s) = %q complete=%v, want %q complete=%v", tt.node, name, complete, tt.name, tt.complete)
+		}
+	}
+}
+
+// A reorg landing between eth_getLogs and the header reads must not leave
+// logs of the old fork in the index, even for blocks before the range's end.
+func TestIndexerRejectsMidRangeReorg(t *testing.T) {
+	var (
+		alice = common.HexToAddress("0xa11ce")
+		bob   = common.HexToAddress("0xb0b")
+		ctx   = context.Background()
+	)
+	chain := newFakeChain(6)
+	eth := newOwnerLog(t, chain, 2, common.Hash{}, "eth", alice)
+	chain.afterFilterLogs = func() {
+		chain.afterFilterLogs = nil
+		chain.setBlock(2, 1)
+		newOwnerLog(t, chain, 2, common.Hash{}, "eth", bob)
+		for n := uint64(3); n <= 5; n++ {
+			chain.setBlock(n, 1)
+		}
+	}
+
+	store := NewStore(memorydb.New())
+	ix, err := New(store, chain, Config{Registry: registry, BlockRange: 10, ReorgDepth: 16, PollInterval: time.Second}, zerolog.Nop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ix.Sync(ctx); err == nil {
+		t.Fatal("Sync indexed logs of a replaced block")
+	}
+	if _, _, ok, _ := store.Head(); ok {
+		t.Fatal("the rejected range was written")
+	}
+	if err := ix.Sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	rec, err := store.Record(eth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.Owner != bob {
+		t.Fatalf("eth owner = %s, want %s", rec.Owner, bob)
+	}
+}
+
+func TestNewRejectsPollInterval(t *testing.T) {
+	for _, interval := range []time.Duration{0, -time.Second} {
+		if _, err := New(NewStore(memorydb.New()), newFakeChain(1), Config{Registry: registry, BlockRange: 1, PollInterval: interval}, zerolog.Nop()); err == nil {
+			t.Errorf("New accepted a poll interval of %s", interval)
+		}
+	}
+}
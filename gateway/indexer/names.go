@@ -0,0 +1,242 @@
+package indexer
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	// maxLabelLength bounds the strings considered as labels in calldata.
+	maxLabelLength = 255
+	// maxNameDepth bounds the parent walk when building a name.
+	maxNameDepth = 128
+)
+
+// l ++ labelhash -> label. Preimages hold on every fork, so unlike the rest
+// of the index they are not journalled.
+var labelPrefix = []byte("l")
+
+var (
+	labelsRecoveredTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gateway",
+		Name:      "indexer_labels_recovered_total",
+		Help:      "Labelhash preimages the indexer recovered, by source.",
+	}, []string{"source"})
+	labelsUnknownTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "gateway",
+		Name:      "indexer_labels_unknown_total",
+		Help:      "Labelhashes of new subnodes whose preimage was not recovered.",
+	})
+)
+
+// Dictionary maps labelhashes to known labels.
+type Dictionary map[common.Hash]string
+
+// NewDictionary hashes labels.
+func NewDictionary(labels []string) Dictionary {
+	dict := make(Dictionary, len(labels))
+	for _, label := range labels {
+		if validLabel([]byte(label)) {
+			dict[crypto.Keccak256Hash([]byte(label))] = label
+		}
+	}
+	return dict
+}
+
+// LoadDictionary reads a label dictionary with one label per line. Blank
+// lines and lines starting with # are skipped.
+func LoadDictionary(path string) (Dictionary, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var labels []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		labels = append(labels, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return NewDictionary(labels), nil
+}
+
+// Label returns the recovered preimage of labelhash.
+func (s *Store) Label(labelhash common.Hash) (string, bool, error) {
+	v, err := s.get(labelKey(labelhash))
+	if err != nil || v == nil {
+		return "", false, err
+	}
+	return string(v), true, nil
+}
+
+func (u *update) putLabel(labelhash common.Hash, label string) {
+	u.batch.Put(labelKey(labelhash), []byte(label))
+}
+
+// Name rebuilds node's name from the labels of it and its ancestors. Labels
+// without a known preimage are written as [labelhash], and complete is
+// false. ok is false when the index does not know how node descends from the
+// root, for example when it was only seen in a Transfer.
+func (ix *Indexer) Name(node common.Hash) (name string, complete, ok bool, err error) {
+	var labels []string
+	complete = true
+	for depth := 0; node != (common.Hash{}); depth++ {
+		if depth == maxNameDepth {
+			return "", false, false, nil
+		}
+		rec, err := ix.store.Record(node)
+		if err == ErrNotFound {
+			return "", false, false, nil
+		}
+		if err != nil {
+			return "", false, false, err
+		}
+		if rec.Label == (common.Hash{}) {
+			return "", false, false, nil
+		}
+		label, known, err := ix.label(rec.Label)
+		if err != nil {
+			return "", false, false, err
+		}
+		if !known {
+			label = fmt.Sprintf("[%x]", rec.Label[:])
+			complete = false
+		}
+		labels = append(labels, label)
+		node = rec.Parent
+	}
+	return strings.Join(labels, "."), complete, true, nil
+}
+
+func (ix *Indexer) label(labelhash common.Hash) (string, bool, error) {
+	if label, ok := ix.config.Dictionary[labelhash]; ok {
+		return label, true, nil
+	}
+	return ix.store.Label(labelhash)
+}
+
+// labelRecovery collects the labelhashes of a range's new subnodes so their
+// preimages can be looked for in the calldata of the transactions that
+// created them.
+type labelRecovery struct {
+	wanted map[common.Hash]map[common.Hash]bool
+}
+
+// noteLabel records labelhash as created in tx, unless its preimage is
+// already known.
+func (ix *Indexer) noteLabel(r *labelRecovery, labelhash, tx common.Hash) error {
+	if _, ok := ix.config.Dictionary[labelhash]; ok {
+		labelsRecoveredTotal.WithLabelValues("dictionary").Inc()
+		return nil
+	}
+	if _, known, err := ix.store.Label(labelhash); err != nil || known {
+		return err
+	}
+	if !ix.config.CalldataLabels {
+		labelsUnknownTotal.Inc()
+		return nil
+	}
+	if r.wanted == nil {
+		r.wanted = make(map[common.Hash]map[common.Hash]bool)
+	}
+	if r.wanted[tx] == nil {
+		r.wanted[tx] = make(map[common.Hash]bool)
+	}
+	r.wanted[tx][labelhash] = true
+	return nil
+}
+
+// recoverLabels fetches each noted transaction and stores any preimages
+// found in its calldata. setSubnodeOwner itself only takes the labelhash, so
+// this finds labels when the registry was called through a contract that
+// takes the plain label, such as a registrar or a multicall wrapping one.
+func (ix *Indexer) recoverLabels(ctx context.Context, u *update, r *labelRecovery) error {
+	for txHash, wanted := range r.wanted {
+		tx, _, err := ix.client.TransactionByHash(ctx, txHash)
+		if err != nil {
+			return fmt.Errorf("getting tx %s: %w", txHash, err)
+		}
+		found := labelsInCalldata(tx.Data(), wanted)
+		for labelhash, label := range found {
+			u.putLabel(labelhash, label)
+		}
+		labelsRecoveredTotal.WithLabelValues("calldata").Add(float64(len(found)))
+		labelsUnknownTotal.Add(float64(len(wanted) - len(found)))
+	}
+	return nil
+}
+
+// labelsInCalldata looks for ABI-encoded strings or bytes in data whose
+// keccak256, or the keccak256 of one of their dot-separated parts, is
+// wanted. Nested calldata is not word aligned, so every offset is tried as
+// the start of a length word.
+func labelsInCalldata(data []byte, wanted map[common.Hash]bool) map[common.Hash]string {
+	found := make(map[common.Hash]string)
+	for off := 0; off+32 < len(data); off++ {
+		word := data[off : off+32]
+		if !isZero(word[:24]) {
+			continue
+		}
+		length := binary.BigEndian.Uint64(word[24:])
+		if length == 0 || length > maxLabelLength || uint64(len(data)-off-32) < length {
+			continue
+		}
+		value := data[off+32 : off+32+int(length)]
+		for _, candidate := range append([][]byte{value}, splitLabels(value)...) {
+			h := crypto.Keccak256Hash(candidate)
+			if wanted[h] && validLabel(candidate) {
+				found[h] = string(candidate)
+			}
+		}
+		if len(found) == len(wanted) {
+			break
+		}
+	}
+	return found
+}
+
+func splitLabels(b []byte) [][]byte {
+	s := string(b)
+	if !strings.Contains(s, ".") {
+		return nil
+	}
+	var parts [][]byte
+	for _, part := range strings.Split(s, ".") {
+		parts = append(parts, []byte(part))
+	}
+	return parts
+}
+
+// validLabel reports whether b can be shown as one label of a name.
+func validLabel(b []byte) bool {
+	return len(b) > 0 && utf8.Valid(b) && !strings.ContainsAny(string(b), ".\x00")
+}
+
+func isZero(b []byte) bool {
+	for _, c := range b {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func labelKey(labelhash common.Hash) []byte {
+	return append(append([]byte{}, labelPrefix...), labelhash[:]...)
+}
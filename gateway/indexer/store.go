@@ -0,0 +1,460 @@
+package indexer
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/leveldb"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// ErrNotFound is returned for nodes the indexer has not seen.
+var ErrNotFound = errors.New("node not indexed")
+
+// Key layout. Block numbers are 8 byte big endian so keys sort in block
+// order.
+var (
+	// headKey holds the number and hash of the last indexed block.
+	headKey = []byte("head")
+	// r ++ node -> rlp(Record)
+	recordPrefix = []byte("r")
+	// s ++ parent ++ labelhash -> node
+	subnodePrefix = []byte("s")
+	// o ++ owner ++ node -> empty
+	ownedPrefix = []byte("o")
+	// a ++ owner ++ operator -> empty
+	operatorPrefix = []byte("a")
+	// b ++ number -> block hash, for blocks within the reorg window
+	checkpointPrefix = []byte("b")
+	// u ++ number -> rlp([]undoEntry), for blocks within the reorg window
+	undoPrefix = []byte("u")
+)
+
+// Record is what the L2 registry's events say about a node.
+type Record struct {
+	// Parent and Label are set for nodes created by setSubnodeOwner or
+	// setSubnodeRecord, and zero for nodes only seen through other events.
+	Parent   common.Hash
+	Label    common.Hash
+	Owner    common.Address
+	Resolver common.Address
+	TTL      uint64
+}
+
+// Node is an indexed node and its record.
+type Node struct {
+	Node common.Hash
+	Record
+}
+
+// undoEntry restores one key to its value before a block was applied.
+type undoEntry struct {
+	Key     []byte
+	Value   []byte
+	Existed bool
+}
+
+// Store persists the index in a key-value database.
+type Store struct {
+	db ethdb.KeyValueStore
+}
+
+// NewStore wraps db.
+func NewStore(db ethdb.KeyValueStore) *Store {
+	return &Store{db: db}
+}
+
+// OpenStore opens the LevelDB store at path, or an in-memory store when path
+// is empty.
+func OpenStore(path string) (*Store, error) {
+	if path == "" {
+		return NewStore(memorydb.New()), nil
+	}
+	db, err := leveldb.New(path, 16, 16, "gateway/indexer/", false)
+	if err != nil {
+		return nil, fmt.Errorf("opening index at %s: %w", path, err)
+	}
+	return NewStore(db), nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Head returns the last indexed block. ok is false before the first block is
+// indexed.
+func (s *Store) Head() (number uint64, hash common.Hash, ok bool, err error) {
+	v, err := s.get(headKey)
+	if err != nil || v == nil {
+		return 0, common.Hash{}, false, err
+	}
+	if len(v) != 8+common.HashLength {
+		return 0, common.Hash{}, false, fmt.Errorf("corrupt index head %x", v)
+	}
+	return binary.BigEndian.Uint64(v), common.BytesToHash(v[8:]), true, nil
+}
+
+// Record returns the indexed record of node.
+func (s *Store) Record(node common.Hash) (*Record, error) {
+	v, err := s.get(recordKey(node))
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, ErrNotFound
+	}
+	return decodeRecord(v)
+}
+
+// Subnodes lists the indexed children of parent in labelhash order, starting
+// after the after labelhash when it is set.
+func (s *Store) Subnodes(parent common.Hash, after *common.Hash, limit int) ([]Node, error) {
+	var nodes []Node
+	err := s.list(append(append([]byte{}, subnodePrefix...), parent[:]...), after, limit, func(_, value []byte) error {
+		node := common.BytesToHash(value)
+		rec, err := s.Record(node)
+		if err != nil {
+			return fmt.Errorf("reading subnode %s: %w", node, err)
+		}
+		nodes = append(nodes, Node{Node: node, Record: *rec})
+		return nil
+	})
+	return nodes, err
+}
+
+// OwnedBy lists the nodes owner currently owns in node order, starting after
+// the after node when it is set.
+func (s *Store) OwnedBy(owner common.Address, after *common.Hash, limit int) ([]Node, error) {
+	var nodes []Node
+	err := s.list(append(append([]byte{}, ownedPrefix...), owner[:]...), after, limit, func(suffix, _ []byte) error {
+		node := common.BytesToHash(suffix)
+		rec, err := s.Record(node)
+		if err != nil {
+			return fmt.Errorf("reading owned node %s: %w", node, err)
+		}
+		nodes = append(nodes, Node{Node: node, Record: *rec})
+		return nil
+	})
+	return nodes, err
+}
+
+// Operators lists the addresses owner has approved for all of its nodes.
+func (s *Store) Operators(owner common.Address) ([]common.Address, error) {
+	var operators []common.Address
+	err := s.list(append(append([]byte{}, operatorPrefix...), owner[:]...), nil, 0, func(suffix, _ []byte) error {
+		operators = append(operators, common.BytesToAddress(suffix))
+		return nil
+	})
+	return operators, err
+}
+
+// list calls fn with the key suffix and value of up to limit entries under
+// prefix, skipping entries up to and including after. A limit of zero lists
+// everything.
+func (s *Store) list(prefix []byte, after *common.Hash, limit int, fn func(suffix, value []byte) error) error {
+	var start []byte
+	if after != nil {
+		start = after[:]
+	}
+	it := s.db.NewIterator(prefix, start)
+	defer it.Release()
+	for n := 0; it.Next() && (limit == 0 || n < limit); {
+		suffix := it.Key()[len(prefix):]
+		if after != nil && common.BytesToHash(suffix) == *after {
+			continue
+		}
+		if err := fn(suffix, it.Value()); err != nil {
+			return err
+		}
+		n++
+	}
+	return it.Error()
+}
+
+func (s *Store) get(key []byte) ([]byte, error) {
+	ok, err := s.db.Has(key)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return s.db.Get(key)
+}
+
+func (s *Store) checkpoint(number uint64) (common.Hash, bool, error) {
+	v, err := s.get(blockKey(checkpointPrefix, number))
+	if err != nil || v == nil {
+		return common.Hash{}, false, err
+	}
+	return common.BytesToHash(v), true, nil
+}
+
+// rewind undoes every block after number and makes it the head.
+func (s *Store) rewind(head, number uint64, hash common.Hash) error {
+	batch := s.db.NewBatch()
+	for n := head; n > number; n-- {
+		v, err := s.get(blockKey(undoPrefix, n))
+		if err != nil {
+			return err
+		}
+		if v != nil {
+			var undo []undoEntry
+			if err := rlp.DecodeBytes(v, &undo); err != nil {
+				return fmt.Errorf("decoding undo journal of block %d: %w", n, err)
+			}
+			for i := len(undo) - 1; i >= 0; i-- {
+				if undo[i].Existed {
+					batch.Put(undo[i].Key, undo[i].Value)
+				} else {
+					batch.Delete(undo[i].Key)
+				}
+			}
+		}
+		batch.Delete(blockKey(undoPrefix, n))
+		batch.Delete(blockKey(checkpointPrefix, n))
+	}
+	batch.Put(headKey, headValue(number, hash))
+	return batch.Write()
+}
+
+// update applies a range of blocks in one batch, journalling the prior value
+// of every key each block touches so the block can be rewound.
+type update struct {
+	store   *Store
+	batch   ethdb.Batch
+	pending map[string][]byte
+	deleted map[string]bool
+
+	inBlock bool
+	number  uint64
+	hash    common.Hash
+	undo    []undoEntry
+	touched map[string]bool
+}
+
+func (s *Store) newUpdate() *update {
+	return &update{
+		store:   s,
+		batch:   s.db.NewBatch(),
+		pending: make(map[string][]byte),
+		deleted: make(map[string]bool),
+	}
+}
+
+// startBlock journals the following writes against block number.
+func (u *update) startBlock(number uint64, hash common.Hash) error {
+	if err := u.finishBlock(); err != nil {
+		return err
+	}
+	u.inBlock, u.number, u.hash = true, number, hash
+	u.undo, u.touched = nil, make(map[string]bool)
+	return nil
+}
+
+func (u *update) finishBlock() error {
+	if !u.inBlock {
+		return nil
+	}
+	if len(u.undo) > 0 {
+		enc, err := rlp.EncodeToBytes(u.undo)
+		if err != nil {
+			return err
+		}
+		u.batch.Put(blockKey(undoPrefix, u.number), enc)
+	}
+	u.batch.Put(blockKey(checkpointPrefix, u.number), u.hash[:])
+	u.inBlock = false
+	return nil
+}
+
+// commit writes the batch with the last started block as the new head and
+// drops journals older than keepFrom.
+func (u *update) commit(keepFrom uint64) error {
+	if err := u.finishBlock(); err != nil {
+		return err
+	}
+	u.batch.Put(headKey, headValue(u.number, u.hash))
+	for _, prefix := range [][]byte{undoPrefix, checkpointPrefix} {
+		if err := u.prune(prefix, keepFrom); err != nil {
+			return err
+		}
+	}
+	return u.batch.Write()
+}
+
+func (u *update) prune(prefix []byte, keepFrom uint64) error {
+	it := u.store.db.NewIterator(prefix, nil)
+	defer it.Release()
+	for it.Next() {
+		if binary.BigEndian.Uint64(it.Key()[len(prefix):]) >= keepFrom {
+			break
+		}
+		u.batch.Delete(common.CopyBytes(it.Key()))
+	}
+	return it.Error()
+}
+
+func (u *update) get(key []byte) ([]byte, error) {
+	if u.deleted[string(key)] {
+		return nil, nil
+	}
+	if v, ok := u.pending[string(key)]; ok {
+		return v, nil
+	}
+	return u.store.get(key)
+}
+
+// journal records key's current value the first time the block touches it.
+func (u *update) journal(key []byte) error {
+	if u.touched[string(key)] {
+		return nil
+	}
+	v, err := u.get(key)
+	if err != nil {
+		return err
+	}
+	u.touched[string(key)] = true
+	u.undo = append(u.undo, undoEntry{Key: common.CopyBytes(key), Value: v, Existed: v != nil})
+	return nil
+}
+
+func (u *update) put(key, value []byte) error {
+	if err := u.journal(key); err != nil {
+		return err
+	}
+	delete(u.deleted, string(key))
+	u.pending[string(key)] = value
+	u.batch.Put(key, value)
+	return nil
+}
+
+func (u *update) delete(key []byte) error {
+	if err := u.journal(key); err != nil {
+		return err
+	}
+	delete(u.pending, string(key))
+	u.deleted[string(key)] = true
+	u.batch.Delete(key)
+	return nil
+}
+
+func (u *update) record(node common.Hash) (*Record, error) {
+	v, err := u.get(recordKey(node))
+	if err != nil || v == nil {
+		return &Record{}, err
+	}
+	return decodeRecord(v)
+}
+
+func (u *update) putRecord(node common.Hash, rec *Record) error {
+	enc, err := rlp.EncodeToBytes(rec)
+	if err != nil {
+		return err
+	}
+	return u.put(recordKey(node), enc)
+}
+
+// setOwner moves node between owners' indexes.
+func (u *update) setOwner(node common.Hash, rec *Record, owner common.Address) error {
+	if rec.Owner != (common.Address{}) {
+		if err := u.delete(ownedKey(rec.Owner, node)); err != nil {
+			return err
+		}
+	}
+	if owner != (common.Address{}) {
+		if err := u.put(ownedKey(owner, node), []byte{}); err != nil {
+			return err
+		}
+	}
+	rec.Owner = owner
+	return u.putRecord(node, rec)
+}
+
+// newOwner applies NewOwner(parent, label, owner).
+func (u *update) newOwner(parent, label common.Hash, owner common.Address) error {
+	node := crypto.Keccak256Hash(parent[:], label[:])
+	rec, err := u.record(node)
+	if err != nil {
+		return err
+	}
+	rec.Parent, rec.Label = parent, label
+	if err := u.put(subnodeKey(parent, label), node[:]); err != nil {
+		return err
+	}
+	return u.setOwner(node, rec, owner)
+}
+
+// transfer applies Transfer(node, owner).
+func (u *update) transfer(node common.Hash, owner common.Address) error {
+	rec, err := u.record(node)
+	if err != nil {
+		return err
+	}
+	return u.setOwner(node, rec, owner)
+}
+
+// setResolver applies NewResolver(node, resolver).
+func (u *update) setResolver(node common.Hash, resolver common.Address) error {
+	rec, err := u.record(node)
+	if err != nil {
+		return err
+	}
+	rec.Resolver = resolver
+	return u.putRecord(node, rec)
+}
+
+// setTTL applies NewTTL(node, ttl).
+func (u *update) setTTL(node common.Hash, ttl uint64) error {
+	rec, err := u.record(node)
+	if err != nil {
+		return err
+	}
+	rec.TTL = ttl
+	return u.putRecord(node, rec)
+}
+
+// setApproval applies ApprovalForAll(owner, operator, approved).
+func (u *update) setApproval(owner, operator common.Address, approved bool) error {
+	key := append(append(append([]byte{}, operatorPrefix...), owner[:]...), operator[:]...)
+	if approved {
+		return u.put(key, []byte{})
+	}
+	return u.delete(key)
+}
+
+func decodeRecord(v []byte) (*Record, error) {
+	rec := new(Record)
+	if err := rlp.DecodeBytes(v, rec); err != nil {
+		return nil, fmt.Errorf("decoding record: %w", err)
+	}
+	return rec, nil
+}
+
+func recordKey(node common.Hash) []byte {
+	return append(append([]byte{}, recordPrefix...), node[:]...)
+}
+
+func subnodeKey(parent, label common.Hash) []byte {
+	return append(append(append([]byte{}, subnodePrefix...), parent[:]...), label[:]...)
+}
+
+func ownedKey(owner common.Address, node common.Hash) []byte {
+	return append(append(append([]byte{}, ownedPrefix...), owner[:]...), node[:]...)
+}
+
+func blockKey(prefix []byte, number uint64) []byte {
+	key := make([]byte, len(prefix)+8)
+	copy(key, prefix)
+	binary.BigEndian.PutUint64(key[len(prefix):], number)
+	return key
+}
+
+func headValue(number uint64, hash common.Hash) []byte {
+	v := make([]byte, 8+common.HashLength)
+	binary.BigEndian.PutUint64(v, number)
+	copy(v[8:], hash[:])
+	return v
+}
@@ -0,0 +1,321 @@
+package relayer
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/leveldb"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// ErrNotFound is returned for requests the outbox does not hold.
+var ErrNotFound = errors.New("request not found")
+
+// Request statuses.
+const (
+	// StatusQueued requests wait for their first transaction.
+	StatusQueued = "queued"
+	// StatusSent requests have a transaction in flight.
+	StatusSent = "sent"
+	// StatusMined requests were executed and their registry call succeeded.
+	StatusMined = "mined"
+	// StatusFailed requests were dropped, or executed without the registry
+	// call succeeding.
+	StatusFailed = "failed"
+)
+
+// Key layout. Ids and times are 8 byte big endian so keys sort in order.
+var (
+	// seqKey holds the next entry id.
+	seqKey = []byte("seq")
+	// nonceKey holds the relayer account's next unassigned nonce.
+	nonceKey = []byte("nonce")
+	// e ++ id -> rlp(Entry)
+	entryPrefix = []byte("e")
+	// p ++ id -> empty, for queued and sent entries
+	pendingPrefix = []byte("p")
+	// f ++ from ++ id -> forwarder nonce, for queued and sent entries
+	senderPrefix = []byte("f")
+	// d ++ digest -> id
+	digestPrefix = []byte("d")
+	// q ++ from ++ created ++ id -> empty, for entries within the quota window
+	quotaPrefix = []byte("q")
+	// t ++ tx hash -> id
+	txPrefix = []byte("t")
+	// c ++ created ++ id -> cost, for entries within the quota window
+	spendPrefix = []byte("c")
+)
+
+// Entry is a request in the outbox and the transactions sent for it.
+type Entry struct {
+	ID      uint64
+	Digest  common.Hash
+	Method  string
+	Request Request
+	// Created and SentAt are unix times.
+	Created uint64
+	Status  string
+	// Nonce is the relayer account nonce of the entry's transactions, set
+	// once the first is sent. Every transaction of an entry replaces the
+	// previous one.
+	Nonce uint64
+	// GasTipCap is nil for legacy transactions, whose gas price is
+	// GasFeeCap.
+	GasTipCap *big.Int `rlp:"nil"`
+	GasFeeCap *big.Int
+	TxHashes  []common.Hash
+	SentAt    uint64
+	Attempts  uint64
+	// TxHash and BlockNumber identify the mined transaction.
+	TxHash      common.Hash
+	BlockNumber uint64
+	Error       string
+	// Cost is the wei counted against the relayer's spend cap.
+	Cost *big.Int `rlp:"optional"`
+}
+
+// Done reports whether the entry will not change any more.
+func (e *Entry) Done() bool {
+	return e.Status == StatusMined || e.Status == StatusFailed
+}
+
+// Outbox persists relay requests in a key-value database, so they survive
+// restarts until their transactions are mined.
+type Outbox struct {
+	db ethdb.KeyValueStore
+}
+
+// NewOutbox wraps db.
+func NewOutbox(db ethdb.KeyValueStore) *Outbox {
+	return &Outbox{db: db}
+}
+
+// OpenOutbox opens the LevelDB outbox at path, or an in-memory outbox when
+// path is empty.
+func OpenOutbox(path string) (*Outbox, error) {
+	if path == "" {
+		return NewOutbox(memorydb.New()), nil
+	}
+	db, err := leveldb.New(path, 16, 16, "gateway/relayer/", false)
+	if err != nil {
+		return nil, fmt.Errorf("opening outbox at %s: %w", path, err)
+	}
+	return NewOutbox(db), nil
+}
+
+func (o *Outbox) Close() error {
+	return o.db.Close()
+}
+
+// Get returns the entry with id.
+func (o *Outbox) Get(id uint64) (*Entry, error) {
+	v, err := o.get(idKey(entryPrefix, id))
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, ErrNotFound
+	}
+	entry := new(Entry)
+	if err := rlp.DecodeBytes(v, entry); err != nil {
+		return nil, fmt.Errorf("decoding entry %d: %w", id, err)
+	}
+	return entry, nil
+}
+
+// ByDigest returns the entry of the request with digest.
+func (o *Outbox) ByDigest(digest common.Hash) (*Entry, error) {
+	return o.lookup(append(append([]byte{}, digestPrefix...), digest[:]...))
+}
+
+// ByTx returns the entry a transaction was sent for.
+func (o *Outbox) ByTx(hash common.Hash) (*Entry, error) {
+	return o.lookup(append(append([]byte{}, txPrefix...), hash[:]...))
+}
+
+func (o *Outbox) lookup(key []byte) (*Entry, error) {
+	v, err := o.get(key)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, ErrNotFound
+	}
+	return o.Get(binary.BigEndian.Uint64(v))
+}
+
+// Pending returns the queued and sent entries in the order they were added.
+func (o *Outbox) Pending() ([]*Entry, error) {
+	var entries []*Entry
+	it := o.db.NewIterator(pendingPrefix, nil)
+	defer it.Release()
+	for it.Next() {
+		entry, err := o.Get(binary.BigEndian.Uint64(it.Key()[len(pendingPrefix):]))
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, it.Error()
+}
+
+// NextNonce returns the forwarder nonce following from's pending requests.
+// ok is false when from has none.
+func (o *Outbox) NextNonce(from common.Address) (next uint64, ok bool, err error) {
+	prefix := append(append([]byte{}, senderPrefix...), from[:]...)
+	it := o.db.NewIterator(prefix, nil)
+	defer it.Release()
+	for it.Next() {
+		if nonce := binary.BigEndian.Uint64(it.Value()); !ok || nonce >= next {
+			next, ok = nonce+1, true
+		}
+	}
+	return next, ok, it.Error()
+}
+
+// HasNonce reports whether one of from's pending requests uses the
+// forwarder nonce.
+func (o *Outbox) HasNonce(from common.Address, nonce uint64) (bool, error) {
+	prefix := append(append([]byte{}, senderPrefix...), from[:]...)
+	it := o.db.NewIterator(prefix, nil)
+	defer it.Release()
+	for it.Next() {
+		if binary.BigEndian.Uint64(it.Value()) == nonce {
+			return true, nil
+		}
+	}
+	return false, it.Error()
+}
+
+// CountSince counts from's requests added at or after the unix time since,
+// forgetting older ones.
+func (o *Outbox) CountSince(from common.Address, since uint64) (int, error) {
+	prefix := append(append([]byte{}, quotaPrefix...), from[:]...)
+	batch := o.db.NewBatch()
+	count := 0
+	it := o.db.NewIterator(prefix, nil)
+	defer it.Release()
+	for it.Next() {
+		if binary.BigEndian.Uint64(it.Key()[len(prefix):]) < since {
+			batch.Delete(common.CopyBytes(it.Key()))
+			continue
+		}
+		count++
+	}
+	if err := it.Error(); err != nil {
+		return 0, err
+	}
+	return count, batch.Write()
+}
+
+// SpentSince sums the cost of the requests added at or after the unix time
+// since, forgetting older ones.
+func (o *Outbox) SpentSince(since uint64) (*big.Int, error) {
+	batch := o.db.NewBatch()
+	spent := new(big.Int)
+	it := o.db.NewIterator(spendPrefix, nil)
+	defer it.Release()
+	for it.Next() {
+		if binary.BigEndian.Uint64(it.Key()[len(spendPrefix):]) < since {
+			batch.Delete(common.CopyBytes(it.Key()))
+			continue
+		}
+		spent.Add(spent, new(big.Int).SetBytes(it.Value()))
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	return spent, batch.Write()
+}
+
+// Add assigns entry an id and stores it.
+func (o *Outbox) Add(entry *Entry) error {
+	v, err := o.get(seqKey)
+	if err != nil {
+		return err
+	}
+	if v != nil {
+		entry.ID = binary.BigEndian.Uint64(v)
+	}
+	batch := o.db.NewBatch()
+	batch.Put(seqKey, uint64Bytes(entry.ID+1))
+	id := uint64Bytes(entry.ID)
+	batch.Put(append(append([]byte{}, digestPrefix...), entry.Digest[:]...), id)
+	batch.Put(append(append(append(append([]byte{}, quotaPrefix...), entry.Request.From[:]...), uint64Bytes(entry.Created)...), id...), []byte{})
+	if err := o.put(batch, entry); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// Update stores a changed entry.
+func (o *Outbox) Update(entry *Entry) error {
+	batch := o.db.NewBatch()
+	if err := o.put(batch, entry); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// put writes entry and its indexes to batch.
+func (o *Outbox) put(batch ethdb.Batch, entry *Entry) error {
+	enc, err := rlp.EncodeToBytes(entry)
+	if err != nil {
+		return err
+	}
+	id := uint64Bytes(entry.ID)
+	batch.Put(idKey(entryPrefix, entry.ID), enc)
+	senderKey := append(append(append([]byte{}, senderPrefix...), entry.Request.From[:]...), id...)
+	if entry.Done() {
+		batch.Delete(idKey(pendingPrefix, entry.ID))
+		batch.Delete(senderKey)
+	} else {
+		batch.Put(idKey(pendingPrefix, entry.ID), []byte{})
+		batch.Put(senderKey, uint64Bytes(entry.Request.Nonce))
+	}
+	for _, hash := range entry.TxHashes {
+		batch.Put(append(append([]byte{}, txPrefix...), hash[:]...), id)
+	}
+	if entry.Cost != nil {
+		batch.Put(append(append(append([]byte{}, spendPrefix...), uint64Bytes(entry.Created)...), id...), entry.Cost.Bytes())
+	}
+	return nil
+}
+
+// RelayerNonce returns the relayer account's next unassigned nonce, and
+// false if none has been stored.
+func (o *Outbox) RelayerNonce() (uint64, bool, error) {
+	v, err := o.get(nonceKey)
+	if err != nil || v == nil {
+		return 0, false, err
+	}
+	return binary.BigEndian.Uint64(v), true, nil
+}
+
+// SetRelayerNonce stores the relayer account's next unassigned nonce.
+func (o *Outbox) SetRelayerNonce(nonce uint64) error {
+	return o.db.Put(nonceKey, uint64Bytes(nonce))
+}
+
+func (o *Outbox) get(key []byte) ([]byte, error) {
+	ok, err := o.db.Has(key)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return o.db.Get(key)
+}
+
+func idKey(prefix []byte, id uint64) []byte {
+	return append(append([]byte{}, prefix...), uint64Bytes(id)...)
+}
+
+func uint64Bytes(n uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, n)
+	return b
+}
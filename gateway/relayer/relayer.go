@@ -0,0 +1,754 @@
+// Package relayer submits EIP-712 signed L2 registry writes through the
+// registry's trusted forwarder, paying their gas so users need no L2 ETH.
+//
+// Requests are checked as the forwarder would check them, then kept in a
+// persistent outbox until their transaction is mined. Transactions that are
+// not mined in time are replaced at the same nonce with higher fees.
+package relayer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/0xpaulio/eth-sf-ens-rr/bindings"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog"
+)
+
+var (
+	// ErrInvalidRequest is returned for requests the forwarder would reject
+	// or the relayer does not forward.
+	ErrInvalidRequest = errors.New("invalid forward request")
+	// ErrDuplicate is returned for requests already in the outbox.
+	ErrDuplicate = errors.New("request already submitted")
+	// ErrQuotaExceeded is returned when a sender has used its quota.
+	ErrQuotaExceeded = errors.New("relay quota exceeded")
+)
+
+var (
+	requestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gateway",
+		Name:      "relayer_requests_total",
+		Help:      "Forward requests by registry setter and status: queued, mined or failed.",
+	}, []string{"method", "status"})
+	pendingRequests = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "gateway",
+		Name:      "relayer_pending_requests",
+		Help:      "Forward requests queued or awaiting their transaction.",
+	})
+	gasBumpsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "gateway",
+		Name:      "relayer_gas_bumps_total",
+		Help:      "Relayer transactions replaced with higher fees.",
+	})
+)
+
+// Client is the subset of the L2 RPC the relayer uses.
+type Client interface {
+	bind.ContractCaller
+	ChainID(ctx context.Context) (*big.Int, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+	TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error)
+}
+
+// Config configures the relayer.
+type Config struct {
+	// Registry is the L2 registry requests must call.
+	Registry common.Address
+	// Forwarder is the forwarder the registry trusts.
+	Forwarder common.Address
+	// MaxGas is the most gas a request may ask for.
+	MaxGas uint64
+	// GasOverhead is added to a request's gas for the forwarder's own work.
+	GasOverhead uint64
+	// Quota is how many requests a sender may submit per QuotaWindow. Zero
+	// disables the quota.
+	Quota       int
+	QuotaWindow time.Duration
+	// SpendCap is the most wei the relayer commits to per QuotaWindow across
+	// all senders. Requests count at their gas limit and MaxFeeCap until
+	// mined, then at their gas used. Nil or zero disables the cap.
+	SpendCap *big.Int
+	// BumpAfter is how long a transaction may stay unmined before it is
+	// replaced with fees raised by BumpPercent, up to MaxFeeCap.
+	BumpAfter   time.Duration
+	BumpPercent uint64
+	MaxFeeCap   *big.Int
+	// MaxAttempts is how many times a request's first transaction may be
+	// rejected before the request fails.
+	MaxAttempts uint64
+	// PollInterval is the time between outbox passes.
+	PollInterval time.Duration
+}
+
+// Relayer forwards signed requests from its outbox.
+type Relayer struct {
+	// mu serialises outbox changes. It is never held across RPCs.
+	mu sync.Mutex
+	// process serialises outbox passes, which assign the relayer account's
+	// nonces.
+	process   sync.Mutex
+	outbox    *Outbox
+	client    Client
+	key       *ecdsa.PrivateKey
+	address   common.Address
+	forwarder *bindings.L2ForwarderCaller
+	execute   *abi.ABI
+	config    Config
+	logger    zerolog.Logger
+	now       func() time.Time
+
+	chainIDMu sync.Mutex
+	chainID   *big.Int
+}
+
+// New creates a relayer sending from key's account.
+func New(outbox *Outbox, client Client, key *ecdsa.PrivateKey, config Config, logger zerolog.Logger) (*Relayer, error) {
+	if config.BumpPercent < 10 {
+		return nil, errors.New("bump percent must be at least 10 for replacements to be accepted")
+	}
+	if config.MaxFeeCap == nil || config.MaxFeeCap.Sign() <= 0 {
+		return nil, errors.New("max fee cap must be positive")
+	}
+	switch {
+	case config.MaxGas == 0:
+		return nil, errors.New("max gas must be positive")
+	case config.MaxAttempts == 0:
+		return nil, errors.New("max attempts must be positive")
+	case config.QuotaWindow <= 0:
+		return nil, errors.New("quota window must be positive")
+	case config.BumpAfter <= 0:
+		return nil, errors.New("bump after must be positive")
+	case config.PollInterval <= 0:
+		return nil, errors.New("poll interval must be positive")
+	}
+	forwarder, err := bindings.NewL2ForwarderCaller(config.Forwarder, client)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := bindings.L2ForwarderMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return &Relayer{
+		outbox:    outbox,
+		client:    client,
+		key:       key,
+		address:   crypto.PubkeyToAddress(key.PublicKey),
+		forwarder: forwarder,
+		execute:   parsed,
+		config:    config,
+		logger:    logger.With().Str("component", "relayer").Logger(),
+		now:       time.Now,
+	}, nil
+}
+
+// Outbox returns the relayer's outbox.
+func (r *Relayer) Outbox() *Outbox {
+	return r.outbox
+}
+
+// Config returns the relayer's configuration.
+func (r *Relayer) Config() Config {
+	return r.config
+}
+
+// Address returns the account the relayer pays gas from.
+func (r *Relayer) Address() common.Address {
+	return r.address
+}
+
+// Domain returns the EIP-712 domain requests are signed for.
+func (r *Relayer) Domain(ctx context.Context) (apitypes.TypedDataDomain, error) {
+	chainID, err := r.getChainID(ctx)
+	if err != nil {
+		return apitypes.TypedDataDomain{}, err
+	}
+	return Domain(chainID, r.config.Forwarder), nil
+}
+
+func (r *Relayer) getChainID(ctx context.Context) (*big.Int, error) {
+	r.chainIDMu.Lock()
+	defer r.chainIDMu.Unlock()
+	if r.chainID == nil {
+		chainID, err := r.client.ChainID(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("getting L2 chain ID: %w", err)
+		}
+		r.chainID = chainID
+	}
+	return r.chainID, nil
+}
+
+// Nonce returns the forwarder nonce from's next request must use: the
+// forwarder's, or the one after from's pending requests.
+func (r *Relayer) Nonce(ctx context.Context, from common.Address) (uint64, error) {
+	onChain, err := r.forwarderNonce(ctx, from)
+	if err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.nextNonce(from, onChain)
+}
+
+// nextNonce returns the forwarder nonce after from's pending requests, or
+// onChain when it is higher.
+func (r *Relayer) nextNonce(from common.Address, onChain uint64) (uint64, error) {
+	next, ok, err := r.outbox.NextNonce(from)
+	if err != nil {
+		return 0, err
+	}
+	if ok && next > onChain {
+		return next, nil
+	}
+	return onChain, nil
+}
+
+func (r *Relayer) forwarderNonce(ctx context.Context, from common.Address) (uint64, error) {
+	nonce, err := r.forwarder.GetNonce(&bind.CallOpts{Context: ctx}, from)
+	if err != nil {
+		return 0, fmt.Errorf("getting forwarder nonce: %w", err)
+	}
+	if !nonce.IsUint64() {
+		return 0, fmt.Errorf("forwarder nonce %s out of range", nonce)
+	}
+	return nonce.Uint64(), nil
+}
+
+// Used returns how many requests from submitted in the current quota window.
+func (r *Relayer) Used(from common.Address) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.used(from)
+}
+
+func (r *Relayer) used(from common.Address) (int, error) {
+	return r.outbox.CountSince(from, r.windowStart())
+}
+
+// Spent returns the wei counted against the spend cap in the current quota
+// window.
+func (r *Relayer) Spent() (*big.Int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.outbox.SpentSince(r.windowStart())
+}
+
+// windowStart is the unix time the current quota window started.
+func (r *Relayer) windowStart() uint64 {
+	since := r.now().Add(-r.config.QuotaWindow).Unix()
+	if since < 0 {
+		return 0
+	}
+	return uint64(since)
+}
+
+// Submit checks req and queues it. Requests must call one of Methods on the
+// registry without value, carry their sender's signature and use the nonce
+// Nonce returns. Requests at their sender's forwarder nonce must also
+// succeed when simulated; later ones are simulated before they are sent.
+func (r *Relayer) Submit(ctx context.Context, req *Request) (*Entry, error) {
+	if req.To != r.config.Registry {
+		return nil, fmt.Errorf("%w: must call the L2 registry %s", ErrInvalidRequest, r.config.Registry)
+	}
+	if req.value().Sign() != 0 {
+		return nil, fmt.Errorf("%w: must not send value", ErrInvalidRequest)
+	}
+	if req.Gas == 0 || req.Gas > r.config.MaxGas {
+		return nil, fmt.Errorf("%w: gas must be between 1 and %d", ErrInvalidRequest, r.config.MaxGas)
+	}
+	method, err := req.Method()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidRequest, err)
+	}
+	domain, err := r.Domain(ctx)
+	if err != nil {
+		return nil, err
+	}
+	digest, err := req.Verify(domain)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidRequest, err)
+	}
+	// Checked before simulating too, since a relayed request's nonce is
+	// used.
+	if err := r.checkDuplicate(digest); err != nil {
+		return nil, err
+	}
+	onChain, err := r.forwarderNonce(ctx, req.From)
+	if err != nil {
+		return nil, err
+	}
+	if req.Nonce == onChain {
+		if err := r.simulate(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.checkDuplicate(digest); err != nil {
+		return nil, err
+	}
+	nonce, err := r.nextNonce(req.From, onChain)
+	if err != nil {
+		return nil, err
+	}
+	if req.Nonce != nonce {
+		return nil, fmt.Errorf("%w: nonce %d, expected %d", ErrInvalidRequest, req.Nonce, nonce)
+	}
+	if r.config.Quota > 0 {
+		used, err := r.used(req.From)
+		if err != nil {
+			return nil, err
+		}
+		if used >= r.config.Quota {
+			return nil, fmt.Errorf("%w: %d requests per %s", ErrQuotaExceeded, r.config.Quota, r.config.QuotaWindow)
+		}
+	}
+	cost := new(big.Int).Mul(new(big.Int).SetUint64(r.txGas(req)), r.config.MaxFeeCap)
+	if r.config.SpendCap != nil && r.config.SpendCap.Sign() > 0 {
+		spent, err := r.outbox.SpentSince(r.windowStart())
+		if err != nil {
+			return nil, err
+		}
+		if spent.Add(spent, cost).Cmp(r.config.SpendCap) > 0 {
+			return nil, fmt.Errorf("%w: relayer spend cap of %s wei per %s reached", ErrQuotaExceeded, r.config.SpendCap, r.config.QuotaWindow)
+		}
+	}
+	entry := &Entry{
+		Digest:  digest,
+		Method:  method,
+		Request: *req,
+		Created: uint64(r.now().Unix()),
+		Status:  StatusQueued,
+		Cost:    cost,
+	}
+	if err := r.outbox.Add(entry); err != nil {
+		return nil, err
+	}
+	requestsTotal.WithLabelValues(method, StatusQueued).Inc()
+	r.logger.Info().Uint64("id", entry.ID).Str("from", req.From.Hex()).Str("method", method).
+		Uint64("nonce", req.Nonce).Msg("forward request queued")
+	return entry, nil
+}
+
+func (r *Relayer) checkDuplicate(digest common.Hash) error {
+	if _, err := r.outbox.ByDigest(digest); err == nil {
+		return ErrDuplicate
+	} else if !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
+// Run processes the outbox until ctx is done.
+func (r *Relayer) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.config.PollInterval)
+	defer ticker.Stop()
+	for {
+		if err := r.Process(ctx); err != nil && ctx.Err() == nil {
+			r.logger.Error().Err(err).Msg("processing relay outbox")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Process makes one pass over the pending entries: sending queued ones in
+// order, settling mined ones and replacing stuck ones. A queued entry that
+// cannot be sent holds back the ones after it, which may depend on its
+// forwarder nonce.
+//
+// Entries submitted during a pass are left to the next one.
+func (r *Relayer) Process(ctx context.Context) error {
+	r.process.Lock()
+	defer r.process.Unlock()
+	r.mu.Lock()
+	entries, err := r.outbox.Pending()
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	pendingRequests.Set(float64(len(entries)))
+	var errs []string
+	blocked := false
+	for _, entry := range entries {
+		if entry.Status == StatusQueued {
+			if blocked {
+				continue
+			}
+			if err := r.sendQueued(ctx, entry); err != nil {
+				blocked = true
+				errs = append(errs, fmt.Sprintf("request %d: %s", entry.ID, err))
+			}
+			continue
+		}
+		if err := r.checkSent(ctx, entry); err != nil {
+			errs = append(errs, fmt.Sprintf("request %d: %s", entry.ID, err))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// sendQueued sends an entry's first transaction.
+func (r *Relayer) sendQueued(ctx context.Context, entry *Entry) error {
+	onChain, err := r.forwarderNonce(ctx, entry.Request.From)
+	if err != nil {
+		return err
+	}
+	if onChain > entry.Request.Nonce {
+		return r.fail(entry, fmt.Sprintf("forwarder nonce %d already used", entry.Request.Nonce))
+	}
+	if onChain < entry.Request.Nonce {
+		// The requests before this one must still be pending, or the
+		// forwarder would reject it.
+		ok, err := r.outbox.HasNonce(entry.Request.From, entry.Request.Nonce-1)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return r.fail(entry, fmt.Sprintf("forwarder nonce %d was not relayed", entry.Request.Nonce-1))
+		}
+	} else {
+		// Requests behind pending ones of the same sender may depend on
+		// them, so only the first can be simulated.
+		if err := r.simulate(ctx, &entry.Request); errors.Is(err, ErrInvalidRequest) {
+			return r.fail(entry, err.Error())
+		} else if err != nil {
+			return err
+		}
+	}
+	tip, feeCap, err := r.fees(ctx)
+	if err != nil {
+		return err
+	}
+	nonce, err := r.relayerNonce(ctx)
+	if err != nil {
+		return err
+	}
+	entry.Nonce, entry.GasTipCap, entry.GasFeeCap = nonce, tip, feeCap
+	if err := r.setRelayerNonce(nonce + 1); err != nil {
+		return err
+	}
+	err = r.send(ctx, entry)
+	var rpcErr rpc.Error
+	if err == nil || !errors.As(err, &rpcErr) {
+		// Sent, or maybe sent: the nonce stays taken and, if the
+		// transaction was lost, its replacement will use it.
+		return err
+	}
+	// Rejected, so the nonce is still free.
+	if err := r.setRelayerNonce(nonce); err != nil {
+		return err
+	}
+	entry.Status, entry.Nonce, entry.TxHashes = StatusQueued, 0, nil
+	if entry.Attempts >= r.config.MaxAttempts {
+		return r.fail(entry, err.Error())
+	}
+	if err := r.update(entry); err != nil {
+		return err
+	}
+	return err
+}
+
+// checkSent settles a sent entry whose transaction was mined, or replaces
+// its transaction once it has waited BumpAfter.
+func (r *Relayer) checkSent(ctx context.Context, entry *Entry) error {
+	for i := len(entry.TxHashes) - 1; i >= 0; i-- {
+		receipt, err := r.client.TransactionReceipt(ctx, entry.TxHashes[i])
+		if errors.Is(err, ethereum.NotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("getting receipt: %w", err)
+		}
+		return r.settle(entry, receipt)
+	}
+	if r.now().Before(time.Unix(int64(entry.SentAt), 0).Add(r.config.BumpAfter)) {
+		return nil
+	}
+	confirmed, err := r.client.NonceAt(ctx, r.address, nil)
+	if err != nil {
+		return fmt.Errorf("getting relayer nonce: %w", err)
+	}
+	if confirmed > entry.Nonce {
+		// Some other transaction from the relayer account took the nonce.
+		return r.fail(entry, fmt.Sprintf("relayer nonce %d used by another transaction", entry.Nonce))
+	}
+	r.bump(entry)
+	gasBumpsTotal.Inc()
+	if err := r.send(ctx, entry); err != nil {
+		var rpcErr rpc.Error
+		if errors.As(err, &rpcErr) {
+			// Most likely underpriced or already mined; the next pass
+			// finds out.
+			r.logger.Warn().Err(err).Uint64("id", entry.ID).Msg("replacement transaction rejected")
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// send signs and submits entry's transaction at its nonce and fees. The
+// entry is stored as sent first, so the transaction is tracked even if the
+// gateway stops before the call returns.
+func (r *Relayer) send(ctx context.Context, entry *Entry) error {
+	tx, err := r.signTx(ctx, entry)
+	if err != nil {
+		return err
+	}
+	entry.Status = StatusSent
+	entry.TxHashes = append(entry.TxHashes, tx.Hash())
+	entry.SentAt = uint64(r.now().Unix())
+	entry.Attempts++
+	if err := r.update(entry); err != nil {
+		return err
+	}
+	err = r.client.SendTransaction(ctx, tx)
+	if err != nil && strings.Contains(strings.ToLower(err.Error()), "already known") {
+		err = nil
+	}
+	if err != nil {
+		return fmt.Errorf("sending transaction: %w", err)
+	}
+	r.logger.Info().Uint64("id", entry.ID).Str("tx", tx.Hash().Hex()).Uint64("nonce", entry.Nonce).
+		Str("feeCap", entry.GasFeeCap.String()).Uint64("attempt", entry.Attempts).
+		Msg("relay transaction sent")
+	return nil
+}
+
+func (r *Relayer) signTx(ctx context.Context, entry *Entry) (*types.Transaction, error) {
+	chainID, err := r.getChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	data, err := r.execute.Pack("execute", entry.Request.forwardRequest(), entry.Request.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("packing execute: %w", err)
+	}
+	gas := r.txGas(&entry.Request)
+	var txData types.TxData
+	if entry.GasTipCap == nil {
+		txData = &types.LegacyTx{
+			Nonce:    entry.Nonce,
+			GasPrice: entry.GasFeeCap,
+			Gas:      gas,
+			To:       &r.config.Forwarder,
+			Data:     data,
+		}
+	} else {
+		txData = &types.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     entry.Nonce,
+			GasTipCap: entry.GasTipCap,
+			GasFeeCap: entry.GasFeeCap,
+			Gas:       gas,
+			To:        &r.config.Forwarder,
+			Data:      data,
+		}
+	}
+	return types.SignNewTx(r.key, types.LatestSignerForChainID(chainID), txData)
+}
+
+// txGas is the gas limit of req's transactions. The forwarder requires a
+// 64th of the request's gas to be left after the call.
+func (r *Relayer) txGas(req *Request) uint64 {
+	return req.Gas*64/63 + r.config.GasOverhead
+}
+
+// simulate calls the forwarder's execute with req as the relayer's
+// transaction would. The forwarder does not revert when the forwarded call
+// fails, so its result is checked too. Either failing is ErrInvalidRequest.
+func (r *Relayer) simulate(ctx context.Context, req *Request) error {
+	data, err := r.execute.Pack("execute", req.forwardRequest(), req.Signature)
+	if err != nil {
+		return fmt.Errorf("packing execute: %w", err)
+	}
+	out, err := r.client.CallContract(ctx, ethereum.CallMsg{
+		From: r.address,
+		To:   &r.config.Forwarder,
+		Gas:  r.txGas(req),
+		Data: data,
+	}, nil)
+	if err != nil {
+		if isRevert(err) {
+			return fmt.Errorf("%w: forwarder rejected the request: %s", ErrInvalidRequest, err)
+		}
+		return fmt.Errorf("simulating request: %w", err)
+	}
+	results, err := r.execute.Unpack("execute", out)
+	if err != nil {
+		return fmt.Errorf("unpacking execute result: %w", err)
+	}
+	if success, _ := results[0].(bool); !success {
+		reason := "forwarded call reverts"
+		if ret, _ := results[1].([]byte); len(ret) > 0 {
+			if msg, err := abi.UnpackRevert(ret); err == nil {
+				reason += ": " + msg
+			}
+		}
+		return fmt.Errorf("%w: %s", ErrInvalidRequest, reason)
+	}
+	return nil
+}
+
+// isRevert reports whether an eth_call error is the call reverting rather
+// than the RPC failing.
+func isRevert(err error) bool {
+	var dataErr rpc.DataError
+	return errors.As(err, &dataErr) || strings.Contains(err.Error(), "execution reverted")
+}
+
+// fees returns the tip and fee cap for a new transaction. The tip is nil on
+// chains without a base fee, where the fee cap is the gas price.
+func (r *Relayer) fees(ctx context.Context) (tip, feeCap *big.Int, err error) {
+	head, err := r.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting L2 head: %w", err)
+	}
+	if head.BaseFee == nil {
+		price, err := r.client.SuggestGasPrice(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("getting gas price: %w", err)
+		}
+		return nil, r.capFee(price), nil
+	}
+	tip, err = r.client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting gas tip: %w", err)
+	}
+	feeCap = r.capFee(new(big.Int).Add(new(big.Int).Mul(head.BaseFee, common.Big2), tip))
+	if tip.Cmp(feeCap) > 0 {
+		tip = new(big.Int).Set(feeCap)
+	}
+	return tip, feeCap, nil
+}
+
+// bump raises entry's fees by BumpPercent, up to MaxFeeCap.
+func (r *Relayer) bump(entry *Entry) {
+	raise := func(v *big.Int) *big.Int {
+		raised := new(big.Int).Mul(v, new(big.Int).SetUint64(100+r.config.BumpPercent))
+		return raised.Add(raised.Div(raised, big.NewInt(100)), common.Big1)
+	}
+	entry.GasFeeCap = r.capFee(raise(entry.GasFeeCap))
+	if entry.GasTipCap != nil {
+		entry.GasTipCap = raise(entry.GasTipCap)
+		if entry.GasTipCap.Cmp(entry.GasFeeCap) > 0 {
+			entry.GasTipCap = new(big.Int).Set(entry.GasFeeCap)
+		}
+	}
+}
+
+func (r *Relayer) capFee(fee *big.Int) *big.Int {
+	if fee.Cmp(r.config.MaxFeeCap) > 0 {
+		return new(big.Int).Set(r.config.MaxFeeCap)
+	}
+	return fee
+}
+
+// update stores a changed entry.
+func (r *Relayer) update(entry *Entry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.outbox.Update(entry)
+}
+
+func (r *Relayer) setRelayerNonce(nonce uint64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.outbox.SetRelayerNonce(nonce)
+}
+
+// relayerNonce returns the next nonce to assign: the stored one, or the
+// account's pending nonce if that is higher because the account was used
+// elsewhere.
+func (r *Relayer) relayerNonce(ctx context.Context) (uint64, error) {
+	pending, err := r.client.PendingNonceAt(ctx, r.address)
+	if err != nil {
+		return 0, fmt.Errorf("getting relayer nonce: %w", err)
+	}
+	stored, ok, err := r.outbox.RelayerNonce()
+	if err != nil {
+		return 0, err
+	}
+	if ok && stored > pending {
+		return stored, nil
+	}
+	return pending, nil
+}
+
+// settle records entry's mined transaction. The forwarder does not revert
+// when the forwarded call fails, so success is judged by the registry
+// having emitted an event, which each of Methods does.
+func (r *Relayer) settle(entry *Entry, receipt *types.Receipt) error {
+	entry.TxHash, entry.BlockNumber = receipt.TxHash, receipt.BlockNumber.Uint64()
+	// Receipts here carry no effective gas price, so the fee cap bounds it.
+	entry.Cost = new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), entry.GasFeeCap)
+	entry.Status = StatusFailed
+	switch {
+	case receipt.Status != types.ReceiptStatusSuccessful:
+		entry.Error = "transaction reverted"
+	case !emitted(receipt, r.config.Registry):
+		entry.Error = "forwarded call reverted"
+	default:
+		entry.Status, entry.Error = StatusMined, ""
+	}
+	if err := r.update(entry); err != nil {
+		return err
+	}
+	requestsTotal.WithLabelValues(entry.Method, entry.Status).Inc()
+	r.logger.Info().Uint64("id", entry.ID).Str("tx", receipt.TxHash.Hex()).Str("status", entry.Status).
+		Str("error", entry.Error).Uint64("block", entry.BlockNumber).Msg("relay transaction mined")
+	return nil
+}
+
+func (r *Relayer) fail(entry *Entry, reason string) error {
+	entry.Status, entry.Error = StatusFailed, reason
+	if len(entry.TxHashes) == 0 {
+		// Nothing was sent, so nothing was spent.
+		entry.Cost = new(big.Int)
+	}
+	if err := r.update(entry); err != nil {
+		return err
+	}
+	requestsTotal.WithLabelValues(entry.Method, StatusFailed).Inc()
+	r.logger.Warn().Uint64("id", entry.ID).Str("error", reason).Msg("forward request failed")
+	return nil
+}
+
+func emitted(receipt *types.Receipt, address common.Address) bool {
+	for _, log := range receipt.Logs {
+		if log.Address == address {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,459 @@
+package relayer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xpaulio/eth-sf-ens-rr/bindings"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/rs/zerolog"
+)
+
+// simulatedL2 adds what the relayer needs to the simulated backend, and can
+// drop transactions to simulate ones lost before reaching a block. It runs
+// the L2 forwarder and a registry trusting it.
+type simulatedL2 struct {
+	*backends.SimulatedBackend
+	drop int
+	// sending, when set, is signalled by SendTransaction, which then waits
+	// for release.
+	sending, release chan struct{}
+
+	deployer  *bind.TransactOpts
+	forwarder common.Address
+	registry  common.Address
+	contract  *bindings.L2ENSRegistry
+}
+
+func (b *simulatedL2) ChainID(ctx context.Context) (*big.Int, error) {
+	return b.Blockchain().Config().ChainID, nil
+}
+
+func (b *simulatedL2) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if b.sending != nil {
+		b.sending <- struct{}{}
+		<-b.release
+	}
+	if b.drop > 0 {
+		b.drop--
+		return nil
+	}
+	return b.SimulatedBackend.SendTransaction(ctx, tx)
+}
+
+// giveNode makes owner the owner of the top level node with label and
+// returns the node.
+func (b *simulatedL2) giveNode(t *testing.T, label string, owner common.Address) [32]byte {
+	t.Helper()
+	labelHash := crypto.Keccak256Hash([]byte(label))
+	if _, err := b.contract.SetSubnodeOwner(b.deployer, [32]byte{}, labelHash, owner); err != nil {
+		t.Fatal(err)
+	}
+	b.Commit()
+	return crypto.Keccak256Hash(make([]byte, 32), labelHash[:])
+}
+
+// owner returns the registry owner of node.
+func (b *simulatedL2) owner(t *testing.T, node [32]byte) common.Address {
+	t.Helper()
+	owner, err := b.contract.Owner(nil, node)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return owner
+}
+
+func testConfig() Config {
+	return Config{
+		MaxGas:       200000,
+		GasOverhead:  100000,
+		Quota:        2,
+		QuotaWindow:  time.Hour,
+		BumpAfter:    time.Minute,
+		BumpPercent:  20,
+		MaxFeeCap:    big.NewInt(100 * params.GWei),
+		MaxAttempts:  3,
+		PollInterval: time.Second,
+	}
+}
+
+func newTestRelayer(t *testing.T, outbox *Outbox, l2 *simulatedL2, key *ecdsa.PrivateKey, now time.Time) *Relayer {
+	t.Helper()
+	config := testConfig()
+	config.Registry, config.Forwarder = l2.registry, l2.forwarder
+	r, err := New(outbox, l2, key, config, zerolog.Nop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.now = func() time.Time { return now }
+	return r
+}
+
+// newSimulatedL2 deploys the forwarder and the registry, owned by a
+// deployer account separate from the relayer's.
+func newSimulatedL2(t *testing.T, relayer common.Address) *simulatedL2 {
+	t.Helper()
+	deployerKey, _ := crypto.GenerateKey()
+	deployer := crypto.PubkeyToAddress(deployerKey.PublicKey)
+	funds := new(big.Int).Mul(big.NewInt(params.Ether), big.NewInt(100))
+	b := backends.NewSimulatedBackend(core.GenesisAlloc{
+		relayer:  {Balance: funds},
+		deployer: {Balance: funds},
+	}, 10_000_000)
+	t.Cleanup(func() { b.Close() })
+	l2 := &simulatedL2{SimulatedBackend: b}
+	var err error
+	if l2.deployer, err = bind.NewKeyedTransactorWithChainID(deployerKey, b.Blockchain().Config().ChainID); err != nil {
+		t.Fatal(err)
+	}
+	if l2.forwarder, _, _, err = bindings.DeployL2Forwarder(l2.deployer, b); err != nil {
+		t.Fatalf("deploying forwarder: %v", err)
+	}
+	if l2.registry, _, l2.contract, err = bindings.DeployL2ENSRegistry(l2.deployer, b, l2.forwarder); err != nil {
+		t.Fatalf("deploying registry: %v", err)
+	}
+	b.Commit()
+	return l2
+}
+
+// signedRequest returns a request signed by key setting the owner of node
+// to newOwner.
+func signedRequest(t *testing.T, r *Relayer, key *ecdsa.PrivateKey, node [32]byte, nonce uint64) *Request {
+	t.Helper()
+	data, err := bindings.L2ENSRegistrySetOwnerArgs{Node: node, Owner: newOwner}.Pack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := &Request{
+		From:  crypto.PubkeyToAddress(key.PublicKey),
+		To:    r.Config().Registry,
+		Gas:   100000,
+		Nonce: nonce,
+		Data:  data,
+	}
+	sign(t, r, key, req)
+	return req
+}
+
+var newOwner = common.HexToAddress("0xbeef")
+
+func sign(t *testing.T, r *Relayer, key *ecdsa.PrivateKey, req *Request) {
+	t.Helper()
+	domain, err := r.Domain(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest, err := req.Digest(domain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Signature, err = crypto.Sign(digest[:], key); err != nil {
+		t.Fatal(err)
+	}
+	req.Signature[64] += 27
+}
+
+func TestRelayerBumpsLostTransactions(t *testing.T) {
+	ctx := context.Background()
+	relayerKey, _ := crypto.GenerateKey()
+	userKey, _ := crypto.GenerateKey()
+	l2 := newSimulatedL2(t, crypto.PubkeyToAddress(relayerKey.PublicKey))
+	outbox := NewOutbox(memorydb.New())
+	start := time.Unix(1_700_000_000, 0)
+	r := newTestRelayer(t, outbox, l2, relayerKey, start)
+	node := l2.giveNode(t, "alice", crypto.PubkeyToAddress(userKey.PublicKey))
+
+	entry, err := r.Submit(ctx, signedRequest(t, r, userKey, node, 0))
+	if err != nil {
+		t.Fatalf("submitting: %v", err)
+	}
+	// The first transaction never reaches the chain.
+	l2.drop = 1
+	if err := r.Process(ctx); err != nil {
+		t.Fatal(err)
+	}
+	l2.Commit()
+	r.now = func() time.Time { return start.Add(10 * time.Second) }
+	if err := r.Process(ctx); err != nil {
+		t.Fatal(err)
+	}
+	sent, err := outbox.Get(entry.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sent.Status != StatusSent || len(sent.TxHashes) != 1 {
+		t.Fatalf("before BumpAfter: status %s with %d transactions, want sent with 1", sent.Status, len(sent.TxHashes))
+	}
+
+	// A relayer restarted on the same outbox picks the entry up and replaces
+	// its transaction.
+	r = newTestRelayer(t, outbox, l2, relayerKey, start.Add(time.Minute))
+	if err := r.Process(ctx); err != nil {
+		t.Fatal(err)
+	}
+	l2.Commit()
+	if err := r.Process(ctx); err != nil {
+		t.Fatal(err)
+	}
+	mined, err := outbox.Get(entry.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mined.Status != StatusMined {
+		t.Fatalf("status %s (%s), want mined", mined.Status, mined.Error)
+	}
+	if len(mined.TxHashes) != 2 || mined.TxHash != mined.TxHashes[1] {
+		t.Errorf("mined %s of %v, want the replacement", mined.TxHash, mined.TxHashes)
+	}
+	if mined.GasFeeCap.Cmp(sent.GasFeeCap) <= 0 || mined.GasTipCap.Cmp(sent.GasTipCap) <= 0 {
+		t.Errorf("replacement fees %s/%s not above %s/%s", mined.GasTipCap, mined.GasFeeCap, sent.GasTipCap, sent.GasFeeCap)
+	}
+	if byTx, err := outbox.ByTx(mined.TxHashes[0]); err != nil || byTx.ID != entry.ID {
+		t.Errorf("ByTx of the lost transaction = %v, %v", byTx, err)
+	}
+	if pending, err := outbox.Pending(); err != nil || len(pending) != 0 {
+		t.Errorf("Pending() = %d entries, %v; want none", len(pending), err)
+	}
+	if owner := l2.owner(t, node); owner != newOwner {
+		t.Errorf("owner after relaying = %s, want %s", owner, newOwner)
+	}
+}
+
+func TestRelayerChecksRequests(t *testing.T) {
+	ctx := context.Background()
+	relayerKey, _ := crypto.GenerateKey()
+	userKey, _ := crypto.GenerateKey()
+	otherKey, _ := crypto.GenerateKey()
+	l2 := newSimulatedL2(t, crypto.PubkeyToAddress(relayerKey.PublicKey))
+	r := newTestRelayer(t, NewOutbox(memorydb.New()), l2, relayerKey, time.Unix(1_700_000_000, 0))
+	user := crypto.PubkeyToAddress(userKey.PublicKey)
+	alice, bob, carol := l2.giveNode(t, "alice", user), l2.giveNode(t, "bob", user), l2.giveNode(t, "carol", user)
+
+	ttl, err := bindings.L1ENSRegistrySetTTLArgs{Node: [32]byte{1}, Ttl: 60}.Pack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	notOwned, err := bindings.L2ENSRegistrySetOwnerArgs{Node: l2.giveNode(t, "dave", common.HexToAddress("0xda7e")), Owner: newOwner}.Pack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, modify := range map[string]func(*Request){
+		"other contract":     func(req *Request) { req.To = common.HexToAddress("0x01") },
+		"value":              func(req *Request) { req.Value = big.NewInt(1) },
+		"too much gas":       func(req *Request) { req.Gas = 300000 },
+		"setter not relayed": func(req *Request) { req.Data = ttl },
+		"wrong nonce":        func(req *Request) { req.Nonce = 1 },
+		"call reverts":       func(req *Request) { req.Data = notOwned },
+		"wrong signer":       func(req *Request) { sign(t, r, otherKey, req) },
+	} {
+		req := signedRequest(t, r, userKey, alice, 0)
+		modify(req)
+		if name != "wrong signer" {
+			sign(t, r, userKey, req)
+		}
+		if _, err := r.Submit(ctx, req); !errors.Is(err, ErrInvalidRequest) {
+			t.Errorf("%s: Submit() = %v, want ErrInvalidRequest", name, err)
+		}
+	}
+
+	if _, err := r.Submit(ctx, signedRequest(t, r, userKey, alice, 0)); err != nil {
+		t.Fatalf("submitting nonce 0: %v", err)
+	}
+	if _, err := r.Submit(ctx, signedRequest(t, r, userKey, alice, 0)); !errors.Is(err, ErrDuplicate) {
+		t.Errorf("resubmitting: %v, want ErrDuplicate", err)
+	}
+	if nonce, err := r.Nonce(ctx, user); err != nil || nonce != 1 {
+		t.Errorf("Nonce() = %d, %v; want 1", nonce, err)
+	}
+	if _, err := r.Submit(ctx, signedRequest(t, r, userKey, bob, 1)); err != nil {
+		t.Fatalf("submitting nonce 1: %v", err)
+	}
+	if _, err := r.Submit(ctx, signedRequest(t, r, userKey, carol, 2)); !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("third request: %v, want ErrQuotaExceeded", err)
+	}
+
+	// Both are sent in order and mined in one block.
+	if err := r.Process(ctx); err != nil {
+		t.Fatal(err)
+	}
+	l2.Commit()
+	if err := r.Process(ctx); err != nil {
+		t.Fatal(err)
+	}
+	for id := uint64(0); id < 2; id++ {
+		entry, err := r.Outbox().Get(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if entry.Status != StatusMined || entry.Nonce != id {
+			t.Errorf("request %d: status %s at relayer nonce %d, want mined at %d", id, entry.Status, entry.Nonce, id)
+		}
+	}
+	for _, node := range [][32]byte{alice, bob} {
+		if owner := l2.owner(t, node); owner != newOwner {
+			t.Errorf("owner of %x = %s, want %s", node, owner, newOwner)
+		}
+	}
+	if owner := l2.owner(t, carol); owner != user {
+		t.Errorf("owner of the request over quota = %s, want %s", owner, user)
+	}
+}
+
+// A request whose forwarded call would revert by the time it is sent fails
+// without a transaction.
+func TestRelayerSimulatesBeforeSending(t *testing.T) {
+	ctx := context.Background()
+	relayerKey, _ := crypto.GenerateKey()
+	userKey, _ := crypto.GenerateKey()
+	l2 := newSimulatedL2(t, crypto.PubkeyToAddress(relayerKey.PublicKey))
+	r := newTestRelayer(t, NewOutbox(memorydb.New()), l2, relayerKey, time.Unix(1_700_000_000, 0))
+	node := l2.giveNode(t, "alice", crypto.PubkeyToAddress(userKey.PublicKey))
+
+	entry, err := r.Submit(ctx, signedRequest(t, r, userKey, node, 0))
+	if err != nil {
+		t.Fatalf("submitting: %v", err)
+	}
+	// The parent's owner takes the node back before the request is sent.
+	other := common.HexToAddress("0x0123")
+	l2.giveNode(t, "alice", other)
+	if err := r.Process(ctx); err != nil {
+		t.Fatal(err)
+	}
+	failed, err := r.Outbox().Get(entry.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if failed.Status != StatusFailed || len(failed.TxHashes) != 0 {
+		t.Fatalf("status %s with %d transactions, want failed with none", failed.Status, len(failed.TxHashes))
+	}
+	if owner := l2.owner(t, node); owner != other {
+		t.Errorf("owner = %s, want %s", owner, other)
+	}
+	if spent, err := r.Spent(); err != nil || spent.Sign() != 0 {
+		t.Errorf("Spent() = %s, %v; want nothing for a request never sent", spent, err)
+	}
+}
+
+func TestRelayerSpendCap(t *testing.T) {
+	ctx := context.Background()
+	relayerKey, _ := crypto.GenerateKey()
+	l2 := newSimulatedL2(t, crypto.PubkeyToAddress(relayerKey.PublicKey))
+	r := newTestRelayer(t, NewOutbox(memorydb.New()), l2, relayerKey, time.Unix(1_700_000_000, 0))
+	// Room for one request at its most, from any sender.
+	maxCost := new(big.Int).Mul(new(big.Int).SetUint64(r.txGas(&Request{Gas: 100000})), r.config.MaxFeeCap)
+	r.config.SpendCap = new(big.Int).Sub(new(big.Int).Add(maxCost, maxCost), common.Big1)
+
+	var keys []*ecdsa.PrivateKey
+	var nodes [][32]byte
+	for _, label := range []string{"alice", "bob", "carol"} {
+		key, _ := crypto.GenerateKey()
+		keys = append(keys, key)
+		nodes = append(nodes, l2.giveNode(t, label, crypto.PubkeyToAddress(key.PublicKey)))
+	}
+	if _, err := r.Submit(ctx, signedRequest(t, r, keys[0], nodes[0], 0)); err != nil {
+		t.Fatalf("first request: %v", err)
+	}
+	if _, err := r.Submit(ctx, signedRequest(t, r, keys[1], nodes[1], 0)); !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("second request: %v, want ErrQuotaExceeded", err)
+	}
+
+	// Once mined the first counts at the gas it used, leaving room.
+	if err := r.Process(ctx); err != nil {
+		t.Fatal(err)
+	}
+	l2.Commit()
+	if err := r.Process(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if spent, err := r.Spent(); err != nil || spent.Cmp(maxCost) >= 0 {
+		t.Fatalf("Spent() = %s, %v; want below %s", spent, err, maxCost)
+	}
+	if _, err := r.Submit(ctx, signedRequest(t, r, keys[2], nodes[2], 0)); err != nil {
+		t.Fatalf("request after the first was mined: %v", err)
+	}
+
+	// The window moves on.
+	r.now = func() time.Time { return time.Unix(1_700_000_000, 0).Add(r.config.QuotaWindow + time.Second) }
+	if spent, err := r.Spent(); err != nil || spent.Sign() != 0 {
+		t.Errorf("Spent() in the next window = %s, %v; want 0", spent, err)
+	}
+}
+
+// Requests can be submitted while a pass waits on the L2.
+func TestRelayerSubmitsDuringProcess(t *testing.T) {
+	ctx := context.Background()
+	relayerKey, _ := crypto.GenerateKey()
+	aliceKey, _ := crypto.GenerateKey()
+	bobKey, _ := crypto.GenerateKey()
+	l2 := newSimulatedL2(t, crypto.PubkeyToAddress(relayerKey.PublicKey))
+	r := newTestRelayer(t, NewOutbox(memorydb.New()), l2, relayerKey, time.Unix(1_700_000_000, 0))
+	alice := l2.giveNode(t, "alice", crypto.PubkeyToAddress(aliceKey.PublicKey))
+	bob := l2.giveNode(t, "bob", crypto.PubkeyToAddress(bobKey.PublicKey))
+
+	if _, err := r.Submit(ctx, signedRequest(t, r, aliceKey, alice, 0)); err != nil {
+		t.Fatal(err)
+	}
+	l2.sending, l2.release = make(chan struct{}), make(chan struct{})
+	processed := make(chan error, 1)
+	go func() { processed <- r.Process(ctx) }()
+	<-l2.sending
+
+	submitted := make(chan error, 1)
+	go func() {
+		_, err := r.Submit(ctx, signedRequest(t, r, bobKey, bob, 0))
+		submitted <- err
+	}()
+	select {
+	case err := <-submitted:
+		if err != nil {
+			t.Errorf("submitting during a pass: %v", err)
+		}
+		close(l2.release)
+	case <-time.After(5 * time.Second):
+		t.Error("Submit blocked by a pass waiting on SendTransaction")
+		close(l2.release)
+		<-submitted
+	}
+	if err := <-processed; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewRejectsConfig(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		modify func(*Config)
+	}{
+		{"bump percent", func(c *Config) { c.BumpPercent = 5 }},
+		{"max fee cap", func(c *Config) { c.MaxFeeCap = nil }},
+		{"max gas", func(c *Config) { c.MaxGas = 0 }},
+		{"max attempts", func(c *Config) { c.MaxAttempts = 0 }},
+		{"quota window", func(c *Config) { c.QuotaWindow = 0 }},
+		{"bump after", func(c *Config) { c.BumpAfter = -time.Minute }},
+		{"poll interval", func(c *Config) { c.PollInterval = 0 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := testConfig()
+			tt.modify(&config)
+			if _, err := New(NewOutbox(memorydb.New()), nil, key, config, zerolog.Nop()); err == nil {
+				t.Fatalf("New accepted an invalid %s", tt.name)
+			}
+		})
+	}
+}
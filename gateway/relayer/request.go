@@ -0,0 +1,173 @@
+package relayer
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xpaulio/eth-sf-ens-rr/bindings"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// The forwarder's EIP-712 domain name and version, fixed by MinimalForwarder.
+const (
+	DomainName    = "MinimalForwarder"
+	DomainVersion = "0.0.1"
+)
+
+// Types are the EIP-712 types of a forward request.
+var Types = apitypes.Types{
+	"EIP712Domain": {
+		{Name: "name", Type: "string"},
+		{Name: "version", Type: "string"},
+		{Name: "chainId", Type: "uint256"},
+		{Name: "verifyingContract", Type: "address"},
+	},
+	"ForwardRequest": {
+		{Name: "from", Type: "address"},
+		{Name: "to", Type: "address"},
+		{Name: "value", Type: "uint256"},
+		{Name: "gas", Type: "uint256"},
+		{Name: "nonce", Type: "uint256"},
+		{Name: "data", Type: "bytes"},
+	},
+}
+
+// Methods are the L2 registry setters the relayer forwards, keyed by
+// selector. Each checks that its calldata decodes.
+var Methods = map[[4]byte]struct {
+	Name   string
+	Unpack func([]byte) error
+}{
+	bindings.L2ENSRegistrySelectors["setOwner(bytes32,address)"]: {"setOwner", func(data []byte) error {
+		_, err := bindings.UnpackL2ENSRegistrySetOwnerArgs(data)
+		return err
+	}},
+	bindings.L2ENSRegistrySelectors["setResolver(bytes32,address)"]: {"setResolver", func(data []byte) error {
+		_, err := bindings.UnpackL2ENSRegistrySetResolverArgs(data)
+		return err
+	}},
+	bindings.L2ENSRegistrySelectors["setSubnodeRecord(bytes32,bytes32,address,address,uint64)"]: {"setSubnodeRecord", func(data []byte) error {
+		_, err := bindings.UnpackL2ENSRegistrySetSubnodeRecordArgs(data)
+		return err
+	}},
+}
+
+// Request is a forwarder ForwardRequest and the EIP-712 signature of its
+// sender.
+type Request struct {
+	From      common.Address
+	To        common.Address
+	Value     *big.Int
+	Gas       uint64
+	Nonce     uint64
+	Data      []byte
+	Signature []byte
+}
+
+// Domain returns the EIP-712 domain of the forwarder at forwarder.
+func Domain(chainID *big.Int, forwarder common.Address) apitypes.TypedDataDomain {
+	return apitypes.TypedDataDomain{
+		Name:              DomainName,
+		Version:           DomainVersion,
+		ChainId:           (*math.HexOrDecimal256)(chainID),
+		VerifyingContract: forwarder.Hex(),
+	}
+}
+
+// TypedData returns req as the EIP-712 message its sender signs.
+func (req *Request) TypedData(domain apitypes.TypedDataDomain) apitypes.TypedData {
+	return apitypes.TypedData{
+		Types:       Types,
+		PrimaryType: "ForwardRequest",
+		Domain:      domain,
+		Message: apitypes.TypedDataMessage{
+			"from":  req.From.Hex(),
+			"to":    req.To.Hex(),
+			"value": new(big.Int).Set(req.value()).String(),
+			"gas":   new(big.Int).SetUint64(req.Gas).String(),
+			"nonce": new(big.Int).SetUint64(req.Nonce).String(),
+			"data":  hexutil.Encode(req.Data),
+		},
+	}
+}
+
+// Digest returns the EIP-712 hash req's sender signs.
+func (req *Request) Digest(domain apitypes.TypedDataDomain) (common.Hash, error) {
+	hash, _, err := apitypes.TypedDataAndHash(req.TypedData(domain))
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("hashing forward request: %w", err)
+	}
+	return common.BytesToHash(hash), nil
+}
+
+// Verify checks that req is signed by its sender, as the forwarder would,
+// and returns its digest. The signature's recovery id is normalised to 27 or
+// 28, the only values the forwarder accepts.
+func (req *Request) Verify(domain apitypes.TypedDataDomain) (common.Hash, error) {
+	digest, err := req.Digest(domain)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if len(req.Signature) != crypto.SignatureLength {
+		return common.Hash{}, fmt.Errorf("signature must be %d bytes", crypto.SignatureLength)
+	}
+	sig := common.CopyBytes(req.Signature)
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+	r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:64])
+	if !crypto.ValidateSignatureValues(sig[64], r, s, true) {
+		return common.Hash{}, errors.New("invalid signature values")
+	}
+	pub, err := crypto.SigToPub(digest[:], sig)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("recovering signer: %w", err)
+	}
+	if signer := crypto.PubkeyToAddress(*pub); signer != req.From {
+		return common.Hash{}, fmt.Errorf("signed by %s, not %s", signer, req.From)
+	}
+	req.Signature[64] = sig[64] + 27
+	return digest, nil
+}
+
+// Method returns the name of the registry setter req calls, checking that
+// its calldata decodes.
+func (req *Request) Method() (string, error) {
+	if len(req.Data) < 4 {
+		return "", errors.New("calldata too short")
+	}
+	var selector [4]byte
+	copy(selector[:], req.Data)
+	method, ok := Methods[selector]
+	if !ok {
+		return "", fmt.Errorf("function 0x%x is not relayed", selector)
+	}
+	if err := method.Unpack(req.Data[4:]); err != nil {
+		return "", err
+	}
+	return method.Name, nil
+}
+
+// forwardRequest converts req for the forwarder binding.
+func (req *Request) forwardRequest() bindings.MinimalForwarderForwardRequest {
+	return bindings.MinimalForwarderForwardRequest{
+		From:  req.From,
+		To:    req.To,
+		Value: req.value(),
+		Gas:   new(big.Int).SetUint64(req.Gas),
+		Nonce: new(big.Int).SetUint64(req.Nonce),
+		Data:  req.Data,
+	}
+}
+
+func (req *Request) value() *big.Int {
+	if req.Value == nil {
+		return new(big.Int)
+	}
+	return req.Value
+}
@@ -0,0 +1,213 @@
+// Package verifier checks the L2StateProof responses of the ENS gateway
+// offline, reproducing the checks L1ENSRegistry makes in its callbacks.
+//
+// Verify runs the same steps as the contract: the state root must be included
+// in its batch (StateCommitmentChain.verifyStateCommitment), the L2 registry
+// account must be in the state trie and the slot in its storage trie
+// (OptimismHelper.getStorageValue). The one check that needs an L1 node, that
+// the batch header was appended to the state commitment chain, is left to
+// the caller through HashBatchHeader.
+package verifier
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"strings"
+
+	"github.com/0xpaulio/eth-sf-ens-rr/bindings"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// Errors mirroring the custom errors OptimismHelper reverts with.
+var (
+	ErrInvalidStateRoot = errors.New("InvalidStateRoot")
+	ErrAccountDNE       = errors.New("AccountDNE")
+	ErrStorageDNE       = errors.New("StorageDNE")
+)
+
+// L2StateProof is OptimismHelper.L2StateProof, the proof passed to the L1
+// registry's callbacks.
+type L2StateProof struct {
+	StateRoot            [32]byte `json:"stateRoot"`
+	StateRootBatchHeader struct {
+		BatchIndex        *big.Int `json:"batchIndex"`
+		BatchRoot         [32]byte `json:"batchRoot"`
+		BatchSize         *big.Int `json:"batchSize"`
+		PrevTotalElements *big.Int `json:"prevTotalElements"`
+		ExtraData         []byte   `json:"extraData"`
+	} `json:"stateRootBatchHeader"`
+	StateRootProof struct {
+		Index    *big.Int   `json:"index"`
+		Siblings [][32]byte `json:"siblings"`
+	} `json:"stateRootProof"`
+	StateTrieWitness   []byte `json:"stateTrieWitness"`
+	StorageTrieWitness []byte `json:"storageTrieWitness"`
+}
+
+// L2BlockNumber returns the L2 block whose state root the proof commits to.
+func (p *L2StateProof) L2BlockNumber() *big.Int {
+	block := new(big.Int).Add(p.StateRootBatchHeader.PrevTotalElements, p.StateRootProof.Index)
+	return block.Add(block, big.NewInt(1))
+}
+
+var proofArgs = func() abi.Arguments {
+	parsed, err := abi.JSON(strings.NewReader(bindings.L2StateProofABI))
+	if err != nil {
+		panic(err)
+	}
+	return parsed.Methods["l2StateProof"].Inputs
+}()
+
+// Encode ABI-encodes proof as the bytes the L1 callbacks decode.
+func Encode(proof *L2StateProof) ([]byte, error) {
+	return proofArgs.Pack(proof)
+}
+
+// Decode is the inverse of Encode.
+func Decode(encoded []byte) (*L2StateProof, error) {
+	unpacked, err := proofArgs.Unpack(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("decoding L2StateProof: %w", err)
+	}
+	if len(unpacked) != 1 {
+		return nil, fmt.Errorf("decoding L2StateProof: expected 1 value, got %d", len(unpacked))
+	}
+	return abi.ConvertType(unpacked[0], new(L2StateProof)).(*L2StateProof), nil
+}
+
+// Verify decodes an encoded L2StateProof and returns the value of slot in
+// target's storage, as the L1 registry's callbacks would.
+func Verify(encoded []byte, target common.Address, slot common.Hash) (common.Hash, error) {
+	proof, err := Decode(encoded)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := VerifyStateRoot(proof); err != nil {
+		return common.Hash{}, err
+	}
+	return GetStorageValue(target, slot, proof)
+}
+
+// HashBatchHeader returns Lib_OVMCodec.hashBatchHeader, the hash the state
+// commitment chain stores for the batch.
+func HashBatchHeader(proof *L2StateProof) common.Hash {
+	h := proof.StateRootBatchHeader
+	uint256 := mustType("uint256")
+	args := abi.Arguments{{Type: mustType("bytes32")}, {Type: uint256}, {Type: uint256}, {Type: mustType("bytes")}}
+	packed, err := args.Pack(h.BatchRoot, h.BatchSize, h.PrevTotalElements, h.ExtraData)
+	if err != nil {
+		panic(err)
+	}
+	return crypto.Keccak256Hash(packed)
+}
+
+func mustType(t string) abi.Type {
+	typ, err := abi.NewType(t, "", nil)
+	if err != nil {
+		panic(err)
+	}
+	return typ
+}
+
+// VerifyStateRoot checks the state root's inclusion in its batch as
+// Lib_MerkleTree.verify does. It returns an error wrapping
+// ErrInvalidStateRoot if the proof does not hold.
+func VerifyStateRoot(proof *L2StateProof) error {
+	size := proof.StateRootBatchHeader.BatchSize
+	index := proof.StateRootProof.Index
+	siblings := proof.StateRootProof.Siblings
+	switch {
+	case size == nil || size.Sign() <= 0 || !size.IsUint64():
+		return fmt.Errorf("%w: invalid batch size %v", ErrInvalidStateRoot, size)
+	case index == nil || index.Sign() < 0 || index.Cmp(size) >= 0:
+		return fmt.Errorf("%w: index %v out of bounds for batch size %s", ErrInvalidStateRoot, index, size)
+	case len(siblings) != ceilLog2(size.Uint64()):
+		return fmt.Errorf("%w: %d siblings for batch size %s", ErrInvalidStateRoot, len(siblings), size)
+	}
+
+	computed := common.Hash(proof.StateRoot)
+	i := new(big.Int).Set(index)
+	for _, sibling := range siblings {
+		if i.Bit(0) == 1 {
+			computed = crypto.Keccak256Hash(sibling[:], computed[:])
+		} else {
+			computed = crypto.Keccak256Hash(computed[:], sibling[:])
+		}
+		i.Rsh(i, 1)
+	}
+	if computed != proof.StateRootBatchHeader.BatchRoot {
+		return fmt.Errorf("%w: computed batch root %s, header has %s", ErrInvalidStateRoot, computed, common.Hash(proof.StateRootBatchHeader.BatchRoot))
+	}
+	return nil
+}
+
+// ceilLog2 is Lib_MerkleTree._ceilLog2.
+func ceilLog2(n uint64) int {
+	if n <= 1 {
+		return 0
+	}
+	return bits.Len64(n - 1)
+}
+
+// GetStorageValue looks up slot in target's storage through the proof's
+// witnesses, as OptimismHelper.getStorageValue does. It returns an error
+// wrapping ErrAccountDNE or ErrStorageDNE when the witnesses prove the
+// account or slot absent.
+func GetStorageValue(target common.Address, slot common.Hash, proof *L2StateProof) (common.Hash, error) {
+	accountRLP, err := secureTrieGet(proof.StateRoot, target.Bytes(), proof.StateTrieWitness)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("state trie witness: %w", err)
+	}
+	if accountRLP == nil {
+		return common.Hash{}, fmt.Errorf("%w: %s", ErrAccountDNE, target)
+	}
+	var account types.StateAccount
+	if err := rlp.DecodeBytes(accountRLP, &account); err != nil {
+		return common.Hash{}, fmt.Errorf("decoding account %s: %w", target, err)
+	}
+	valueRLP, err := secureTrieGet(account.Root, slot[:], proof.StorageTrieWitness)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("storage trie witness: %w", err)
+	}
+	if valueRLP == nil {
+		return common.Hash{}, fmt.Errorf("%w: slot %s", ErrStorageDNE, slot)
+	}
+	var value []byte
+	if err := rlp.DecodeBytes(valueRLP, &value); err != nil {
+		return common.Hash{}, fmt.Errorf("decoding storage value: %w", err)
+	}
+	// toBytes32PadLeft keeps the first 32 bytes, right aligned.
+	if len(value) > common.HashLength {
+		value = value[:common.HashLength]
+	}
+	return common.BytesToHash(value), nil
+}
+
+// secureTrieGet looks up keccak(key) in the trie rooted at root using an RLP
+// list of proof nodes. It returns nil for a valid exclusion proof.
+func secureTrieGet(root common.Hash, key []byte, witness []byte) ([]byte, error) {
+	var nodes [][]byte
+	if len(witness) > 0 {
+		if err := rlp.DecodeBytes(witness, &nodes); err != nil {
+			return nil, fmt.Errorf("decoding witness: %w", err)
+		}
+	}
+	if root == types.EmptyRootHash && len(nodes) == 0 {
+		return nil, nil
+	}
+	db := memorydb.New()
+	for _, node := range nodes {
+		if err := db.Put(crypto.Keccak256(node), node); err != nil {
+			return nil, err
+		}
+	}
+	return trie.VerifyProof(root, crypto.Keccak256(key), db)
+}
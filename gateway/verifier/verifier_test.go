@@ -0,0 +1,132 @@
+package verifier
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+var (
+	registry = common.HexToAddress("0xE933897412cc2164331e542B2a2Be491612C233F")
+	slot     = common.HexToHash("0x859ecef2e168dc10231b000bd53493b42bc9d944cac29d94582c1e1d43592131")
+	value    = common.HexToHash("0xbeef")
+)
+
+// newProof builds an encoded proof of key in addr's storage, with the state
+// root third of a four root batch.
+func newProof(t *testing.T, addr common.Address, key common.Hash) []byte {
+	t.Helper()
+	db := state.NewDatabase(rawdb.NewMemoryDatabase())
+	statedb, err := state.New(common.Hash{}, db, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	statedb.SetNonce(registry, 1)
+	statedb.SetState(registry, slot, value)
+	statedb.SetBalance(common.HexToAddress("0x01"), big.NewInt(1))
+	root, err := statedb.Commit(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if statedb, err = state.New(root, db, nil); err != nil {
+		t.Fatal(err)
+	}
+	accountProof, err := statedb.GetProof(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var storageProof [][]byte
+	if statedb.Exist(addr) {
+		if storageProof, err = statedb.GetStorageProof(addr, key); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	leaves := []common.Hash{common.HexToHash("0xa0"), common.HexToHash("0xa1"), root, common.HexToHash("0xa3")}
+	left := crypto.Keccak256Hash(leaves[0][:], leaves[1][:])
+	right := crypto.Keccak256Hash(leaves[2][:], leaves[3][:])
+
+	proof := &L2StateProof{StateRoot: root}
+	proof.StateRootBatchHeader.BatchIndex = big.NewInt(7)
+	proof.StateRootBatchHeader.BatchRoot = crypto.Keccak256Hash(left[:], right[:])
+	proof.StateRootBatchHeader.BatchSize = big.NewInt(4)
+	proof.StateRootBatchHeader.PrevTotalElements = big.NewInt(100)
+	proof.StateRootProof.Index = big.NewInt(2)
+	proof.StateRootProof.Siblings = [][32]byte{leaves[3], left}
+	if proof.StateTrieWitness, err = rlp.EncodeToBytes(accountProof); err != nil {
+		t.Fatal(err)
+	}
+	if proof.StorageTrieWitness, err = rlp.EncodeToBytes(storageProof); err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := Encode(proof)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return encoded
+}
+
+func TestVerify(t *testing.T) {
+	got, err := Verify(newProof(t, registry, slot), registry, slot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != value {
+		t.Fatalf("value = %s, want %s", got, value)
+	}
+}
+
+func TestVerifyStorageDNE(t *testing.T) {
+	missing := common.HexToHash("0x01")
+	if _, err := Verify(newProof(t, registry, missing), registry, missing); !errors.Is(err, ErrStorageDNE) {
+		t.Fatalf("err = %v, want %v", err, ErrStorageDNE)
+	}
+}
+
+func TestVerifyAccountDNE(t *testing.T) {
+	missing := common.HexToAddress("0x02")
+	if _, err := Verify(newProof(t, missing, slot), missing, slot); !errors.Is(err, ErrAccountDNE) {
+		t.Fatalf("err = %v, want %v", err, ErrAccountDNE)
+	}
+}
+
+func TestVerifyRejectsWrongSlot(t *testing.T) {
+	// The witnesses prove slot, so they cannot prove another slot present.
+	if _, err := Verify(newProof(t, registry, slot), registry, common.HexToHash("0x01")); err == nil {
+		t.Fatal("expected error for a slot the witnesses do not cover")
+	}
+}
+
+func TestVerifyStateRootRejectsBadInclusion(t *testing.T) {
+	proof, err := Decode(newProof(t, registry, slot))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyStateRoot(proof); err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]func(p *L2StateProof){
+		"sibling":  func(p *L2StateProof) { p.StateRootProof.Siblings[0][0] ^= 1 },
+		"index":    func(p *L2StateProof) { p.StateRootProof.Index = big.NewInt(3) },
+		"bounds":   func(p *L2StateProof) { p.StateRootProof.Index = big.NewInt(4) },
+		"siblings": func(p *L2StateProof) { p.StateRootBatchHeader.BatchSize = big.NewInt(8) },
+	}
+	for name, tamper := range tests {
+		t.Run(name, func(t *testing.T) {
+			proof, err := Decode(newProof(t, registry, slot))
+			if err != nil {
+				t.Fatal(err)
+			}
+			tamper(proof)
+			if err := VerifyStateRoot(proof); !errors.Is(err, ErrInvalidStateRoot) {
+				t.Fatalf("err = %v, want %v", err, ErrInvalidStateRoot)
+			}
+		})
+	}
+}